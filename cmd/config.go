@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -13,10 +14,88 @@ const (
 )
 
 type Config struct {
-	ServerAddress string   `mapstructure:"SERVER_ADDRESS"`
-	AuthToken     string   `mapstructure:"AUTH_TOKEN"`
-	LogMode       string   `mapstructure:"LOG_MODE"`
-	DbConfig      DbConfig `mapstructure:"DB_CONFIG"`
+	ServerAddress string       `mapstructure:"SERVER_ADDRESS"`
+	AuthToken     string       `mapstructure:"AUTH_TOKEN"`
+	LogMode       string       `mapstructure:"LOG_MODE"`
+	DbConfig      DbConfig     `mapstructure:"DB_CONFIG"`
+	UploadConfig  UploadConfig `mapstructure:"UPLOAD_CONFIG"`
+	ReportConfig  ReportConfig `mapstructure:"REPORT_CONFIG"`
+}
+
+type ReportConfig struct {
+	Overtime OvertimeConfig `mapstructure:"OVERTIME"`
+	Premiums PremiumsConfig `mapstructure:"PREMIUMS"`
+	Rounding RoundingConfig `mapstructure:"ROUNDING"`
+	// HoursRounding is the default hours policy, job groups listed in its JOB_GROUPS have their own
+	HoursRounding HoursRoundingConfig `mapstructure:"HOURS_ROUNDING"`
+	PaySchedule   PayScheduleConfig   `mapstructure:"PAY_SCHEDULE"`
+	// PaySchedules are the named schedules job groups and employees are paid on instead of PAY_SCHEDULE
+	PaySchedules []NamedPayScheduleConfig `mapstructure:"PAY_SCHEDULES"`
+}
+
+// PayScheduleConfig decides the pay periods of the reports
+type PayScheduleConfig struct {
+	// Frequency is `weekly`, `biweekly`, `semi_monthly` or `monthly`
+	Frequency string `mapstructure:"FREQUENCY"`
+	// AnchorDate is the first day of a pay period as yyyy-mm-dd, required by weekly and biweekly schedules
+	AnchorDate string `mapstructure:"ANCHOR_DATE"`
+}
+
+type NamedPayScheduleConfig struct {
+	Name              string `mapstructure:"NAME"`
+	PayScheduleConfig `mapstructure:",squash"`
+	// JobGroups are paid on the schedule, unless the employee is assigned another one
+	JobGroups []string `mapstructure:"JOB_GROUPS"`
+}
+
+// HoursPolicyConfig rounds work log hours to an increment of hours, and caps the hours paid per day.
+// Hours are read as exact decimals, 0 disables the increment or the cap
+type HoursPolicyConfig struct {
+	Increment string `mapstructure:"INCREMENT"`
+	// Mode is `nearest`, `up` or `down`
+	Mode     string `mapstructure:"MODE"`
+	DailyCap string `mapstructure:"DAILY_CAP"`
+}
+
+type HoursRoundingConfig struct {
+	HoursPolicyConfig `mapstructure:",squash"`
+	JobGroups         []JobGroupHoursPolicyConfig `mapstructure:"JOB_GROUPS"`
+}
+
+type JobGroupHoursPolicyConfig struct {
+	JobGroup          string `mapstructure:"JOB_GROUP"`
+	HoursPolicyConfig `mapstructure:",squash"`
+}
+
+// RoundingConfig decides how report amounts are rounded to cents
+type RoundingConfig struct {
+	// Mode is `half_up`, or `half_even` for banker's rounding
+	Mode string `mapstructure:"MODE"`
+	// Scope is `line` to round each line item and premium, or `total` to only round the employee's total
+	Scope string `mapstructure:"SCOPE"`
+}
+
+// PremiumsConfig holds the rate multipliers of hours worked on holidays and weekend days, read as exact
+// decimals. 1 pays no premium
+type PremiumsConfig struct {
+	// Calendar is the name of the holiday calendar, empty value disables holiday premiums
+	Calendar          string `mapstructure:"CALENDAR"`
+	HolidayMultiplier string `mapstructure:"HOLIDAY_MULTIPLIER"`
+	WeekendMultiplier string `mapstructure:"WEEKEND_MULTIPLIER"`
+	// WeekendDays are week day names, eg. `saturday`
+	WeekendDays []string `mapstructure:"WEEKEND_DAYS"`
+}
+
+// OvertimeConfig holds the hour thresholds after which hours are paid at a multiplier, read as exact decimals.
+// 0 disables a threshold
+type OvertimeConfig struct {
+	DailyOvertimeHours   string `mapstructure:"DAILY_OVERTIME_HOURS"`
+	DailyDoubleTimeHours string `mapstructure:"DAILY_DOUBLE_TIME_HOURS"`
+	WeeklyOvertimeHours  string `mapstructure:"WEEKLY_OVERTIME_HOURS"`
+	OvertimeMultiplier   string `mapstructure:"OVERTIME_MULTIPLIER"`
+	DoubleTimeMultiplier string `mapstructure:"DOUBLE_TIME_MULTIPLIER"`
+	// WeekStart is the day weekly hours are counted from, eg. `monday`
+	WeekStart string `mapstructure:"WEEK_START"`
 }
 
 type DbConfig struct {
@@ -29,6 +108,65 @@ type DbConfig struct {
 	SchemaName   string `mapstructure:"SCHEMA_NAME"`
 }
 
+type UploadConfig struct {
+	// ValidationPolicy is either `reject` or `import_valid`
+	ValidationPolicy string                      `mapstructure:"VALIDATION_POLICY"`
+	CSVProfiles      map[string]CSVProfileConfig `mapstructure:"CSV_PROFILES"`
+	Workers          int                         `mapstructure:"WORKERS"`
+	PollInterval     time.Duration               `mapstructure:"POLL_INTERVAL"`
+	// ReportIdPattern is the regex parsing the report id from the filename when the client doesn't
+	// set it, its first group is the id. Empty value makes the report id mandatory
+	ReportIdPattern string `mapstructure:"REPORT_ID_PATTERN"`
+	// JobTimeout is how long a job can stay in processing without its worker touching it before another worker
+	// picks it up, workers touch their job a few times per timeout
+	JobTimeout time.Duration    `mapstructure:"JOB_TIMEOUT"`
+	Duplicates DuplicatesConfig `mapstructure:"DUPLICATES"`
+	JobGroups  JobGroupsConfig  `mapstructure:"JOB_GROUPS"`
+	// Employees is the action on work logs of unknown, not yet hired or terminated employees, either
+	// `off`, `reject` or `warn`
+	Employees string `mapstructure:"EMPLOYEES"`
+}
+
+// JobGroupsConfig is the normalization applied to csv job group values, nothing is normalized by default
+type JobGroupsConfig struct {
+	CaseInsensitive bool `mapstructure:"CASE_INSENSITIVE"`
+	// Aliases is a list, as viper lowercases map keys
+	Aliases []JobGroupAliasConfig `mapstructure:"ALIASES"`
+}
+
+type JobGroupAliasConfig struct {
+	Alias    string `mapstructure:"ALIAS"`
+	JobGroup string `mapstructure:"JOB_GROUP"`
+}
+
+// DuplicatesConfig holds the action of each duplicate work log rule, either `off`, `reject`, `warn` or `merge`
+type DuplicatesConfig struct {
+	// Exact matches work logs with the same employee, date, hours and job group
+	Exact string `mapstructure:"EXACT"`
+	// Overlap matches work logs of the same employee on the same date
+	Overlap string `mapstructure:"OVERLAP"`
+}
+
+type CSVProfileConfig struct {
+	Delimiter  string `mapstructure:"DELIMITER"`
+	LazyQuotes bool   `mapstructure:"LAZY_QUOTES"`
+	// DateFormats are either `iso8601`, `dd/mm/yyyy`, `mm/dd/yyyy`, `dd-mm-yyyy` or a go time layout
+	DateFormats []string `mapstructure:"DATE_FORMATS"`
+	// DateFormat is a single go time layout, eg. `2006-01-02`, kept for older config files
+	DateFormat string           `mapstructure:"DATE_FORMAT"`
+	Columns    CSVColumnsConfig `mapstructure:"COLUMNS"`
+}
+
+// CSVColumnsConfig maps work log fields to csv header names
+type CSVColumnsConfig struct {
+	Date       string `mapstructure:"DATE"`
+	Hours      string `mapstructure:"HOURS"`
+	EmployeeId string `mapstructure:"EMPLOYEE_ID"`
+	JobGroup   string `mapstructure:"JOB_GROUP"`
+	// CostCenter is optional, logs of files without it are allocated to the employees' cost centers
+	CostCenter string `mapstructure:"COST_CENTER"`
+}
+
 // LoadConfig reads configuration from file or environment variables.
 func LoadConfig(path string) (*Config, error) {
 	if path != "" {
@@ -47,6 +185,24 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	viper.SetDefault("UPLOAD_CONFIG.WORKERS", 2)
+	viper.SetDefault("UPLOAD_CONFIG.POLL_INTERVAL", "2s")
+	viper.SetDefault("UPLOAD_CONFIG.JOB_TIMEOUT", "30m")
+	viper.SetDefault("UPLOAD_CONFIG.REPORT_ID_PATTERN", `time-report-(\d+)\.csv$`)
+	viper.SetDefault("UPLOAD_CONFIG.DUPLICATES.EXACT", "reject")
+	viper.SetDefault("UPLOAD_CONFIG.DUPLICATES.OVERLAP", "off")
+	viper.SetDefault("UPLOAD_CONFIG.EMPLOYEES", "warn")
+	viper.SetDefault("REPORT_CONFIG.OVERTIME.OVERTIME_MULTIPLIER", "1.5")
+	viper.SetDefault("REPORT_CONFIG.OVERTIME.DOUBLE_TIME_MULTIPLIER", "2")
+	viper.SetDefault("REPORT_CONFIG.OVERTIME.WEEK_START", "monday")
+	viper.SetDefault("REPORT_CONFIG.PREMIUMS.HOLIDAY_MULTIPLIER", "1")
+	viper.SetDefault("REPORT_CONFIG.PREMIUMS.WEEKEND_MULTIPLIER", "1")
+	viper.SetDefault("REPORT_CONFIG.PREMIUMS.WEEKEND_DAYS", []string{"saturday", "sunday"})
+	viper.SetDefault("REPORT_CONFIG.ROUNDING.MODE", "half_up")
+	viper.SetDefault("REPORT_CONFIG.ROUNDING.SCOPE", "line")
+	viper.SetDefault("REPORT_CONFIG.HOURS_ROUNDING.MODE", "nearest")
+	viper.SetDefault("REPORT_CONFIG.PAY_SCHEDULE.FREQUENCY", "semi_monthly")
+
 	cfg := Config{}
 	if err := viper.Unmarshal(&cfg); err != nil {
 		logrus.Errorf("error unmarshalling config file: %s", err)
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var (
+	employeeName            string
+	employeeStatus          string
+	employeeHireDate        string
+	employeeTerminationDate string
+	employeeJobGroup        string
+	employeeDepartment      string
+	employeeCostCenter      string
+)
+
+var employeesCmd = &cobra.Command{
+	Use:   "employees",
+	Short: "Manage the employees work logs are paid to",
+}
+
+var employeesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List employees",
+	Args:    cobra.NoArgs,
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var status *payroll.EmployeeStatus
+		if employeeStatus != "" {
+			s := payroll.EmployeeStatus(employeeStatus)
+			status = &s
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			employees, err := s.GetEmployees(status)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tSTATUS\tHIRE DATE\tTERMINATION DATE\tJOB GROUP\tDEPARTMENT\tCOST CENTER")
+			for _, e := range employees {
+				group := "-"
+				if e.DefaultJobGroup != nil {
+					group = string(*e.DefaultJobGroup)
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Id, e.Name, e.Status,
+					formatEffectiveDate(e.HireDate), formatEffectiveDate(e.TerminationDate), group,
+					formatOptional(e.Department), formatOptional(e.CostCenter))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var employeesCreateCmd = &cobra.Command{
+	Use:     "create <employee id> --name <name>",
+	Short:   "Register an employee under the employee id of the time reports",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e, err := parseEmployee(args[0])
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			e, err := s.CreateEmployee(e)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created employee %d %s\n", e.Id, e.Name)
+			return nil
+		})
+	},
+}
+
+var employeesUpdateCmd = &cobra.Command{
+	Use:     "update <employee id> --name <name>",
+	Short:   "Replace the details of an employee, unset flags clear the detail",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e, err := parseEmployee(args[0])
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			e, err := s.UpdateEmployee(e)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "updated employee %d %s\n", e.Id, e.Name)
+			return nil
+		})
+	},
+}
+
+var employeesDeleteCmd = &cobra.Command{
+	Use:     "delete <employee id>",
+	Short:   "Delete an employee, their uploaded work logs are kept",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := parseEmployeeId(args[0])
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeleteEmployee(id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted employee %d\n", id)
+			return nil
+		})
+	},
+}
+
+var employeesImportCmd = &cobra.Command{
+	Use:     "import <file>",
+	Short:   "Create or update employees from a csv file with employee id, name, status, hire date, termination date and default job group columns",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		policy, err := handler.ParseValidationPolicy(cfg.UploadConfig.ValidationPolicy)
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			jobGroups, err := s.GetJobGroups()
+			if err != nil {
+				return err
+			}
+
+			parsed, rejection := handler.ParseEmployeeImport(data, handler.NewJobGroupResolver(jobGroups,
+				newJobGroupConfig(cfg.UploadConfig.JobGroups)), policy)
+			if len(parsed.RowErrors) > 0 {
+				w := tabwriter.NewWriter(cmd.ErrOrStderr(), 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "LINE\tCOLUMN\tVALUE\tREASON")
+				for _, e := range parsed.RowErrors {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", e.Line, e.Column, e.Value, e.Reason)
+				}
+				w.Flush()
+			}
+			if rejection != "" {
+				return errors.New(rejection)
+			}
+
+			res, err := s.ImportEmployees(parsed.Employees)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "imported %d of %d rows: %d created, %d updated, %d unchanged\n", len(parsed.Employees),
+				parsed.RowsTotal, len(res.Created), len(res.Updated), len(res.Unchanged))
+			return nil
+		})
+	},
+}
+
+// parseEmployee func builds the employee from the id argument and the employee flags
+func parseEmployee(arg string) (payroll.Employee, error) {
+	id, err := parseEmployeeId(arg)
+	if err != nil {
+		return payroll.Employee{}, err
+	}
+
+	e := payroll.Employee{Id: id, Name: employeeName, Status: payroll.EmployeeStatus(employeeStatus)}
+	if employeeJobGroup != "" {
+		group := payroll.JobGroup(employeeJobGroup)
+		e.DefaultJobGroup = &group
+	}
+	if employeeDepartment != "" {
+		e.Department = &employeeDepartment
+	}
+	if employeeCostCenter != "" {
+		e.CostCenter = &employeeCostCenter
+	}
+	if e.HireDate, err = parseOptionalDate("hire-date", employeeHireDate); err != nil {
+		return payroll.Employee{}, err
+	}
+	if e.TerminationDate, err = parseOptionalDate("termination-date", employeeTerminationDate); err != nil {
+		return payroll.Employee{}, err
+	}
+	return e, nil
+}
+
+func formatOptional(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
+
+func parseEmployeeId(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid employee id %s, expected a positive integer", s)
+	}
+	return id, nil
+}
+
+func init() {
+	employeesListCmd.Flags().StringVar(&employeeStatus, "status", "", "only list the employees of the status, either active or terminated")
+	for _, c := range []*cobra.Command{employeesCreateCmd, employeesUpdateCmd} {
+		c.Flags().StringVar(&employeeName, "name", "", "name of the employee")
+		c.Flags().StringVar(&employeeStatus, "status", "", "either active or terminated, active when not set")
+		c.Flags().StringVar(&employeeHireDate, "hire-date", "", "first day the employee can be paid for, as yyyy-mm-dd")
+		c.Flags().StringVar(&employeeTerminationDate, "termination-date", "", "last day the employee can be paid for, as yyyy-mm-dd")
+		c.Flags().StringVar(&employeeJobGroup, "job-group", "", "default job group of the employee")
+		c.Flags().StringVar(&employeeDepartment, "department", "", "home department of the employee")
+		c.Flags().StringVar(&employeeCostCenter, "cost-center", "", "cost center the employee's work logs are allocated to when the time report doesn't set one")
+		c.MarkFlagRequired("name")
+	}
+	employeesCmd.AddCommand(employeesListCmd, employeesCreateCmd, employeesUpdateCmd, employeesImportCmd, employeesDeleteCmd)
+	rootCmd.AddCommand(employeesCmd)
+}
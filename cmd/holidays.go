@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var holidaysCmd = &cobra.Command{
+	Use:   "holidays",
+	Short: "Manage the holiday calendars used for holiday premiums",
+}
+
+var holidaysListCmd = &cobra.Command{
+	Use:     "list [calendar]",
+	Short:   "List the holiday calendars, or the holidays of a calendar",
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPayrollService(func(s handler.PayrollService) error {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			if len(args) == 0 {
+				calendars, err := s.GetHolidayCalendars()
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintln(w, "CALENDAR\tHOLIDAYS")
+				for _, c := range calendars {
+					fmt.Fprintf(w, "%s\t%d\n", c.Name, c.Holidays)
+				}
+				return w.Flush()
+			}
+
+			holidays, err := s.GetHolidays(args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(w, "DATE\tNAME")
+			for _, h := range holidays {
+				fmt.Fprintf(w, "%s\t%s\n", h.Date.Format(time.DateOnly), h.Name)
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var holidaysImportCmd = &cobra.Command{
+	Use:     "import <calendar> <file>",
+	Short:   "Import a calendar from an ical file, or a csv file with date,name columns, replacing its holidays",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+
+		holidays, err := handler.ParseHolidays(data)
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			holidays, err := s.ImportHolidays(args[0], holidays)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "imported %d holidays into calendar %s\n", len(holidays), args[0])
+			return nil
+		})
+	},
+}
+
+var holidaysAddCmd = &cobra.Command{
+	Use:     "add <calendar> <date> <name>",
+	Short:   "Add a holiday to a calendar, or rename the holiday on the same date",
+	Args:    cobra.MinimumNArgs(3),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date, err := parseOptionalDate("date", args[1])
+		if err != nil || date == nil {
+			return fmt.Errorf("invalid date %s, expected yyyy-mm-dd", args[1])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			h, err := s.SaveHoliday(payroll.Holiday{Calendar: args[0], Date: *date, Name: strings.Join(args[2:], " ")})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "added holiday %s on %s to calendar %s\n", h.Name, h.Date.Format(time.DateOnly), h.Calendar)
+			return nil
+		})
+	},
+}
+
+var holidaysDeleteCmd = &cobra.Command{
+	Use:     "delete <calendar> [date]",
+	Short:   "Delete a holiday of a calendar, or the calendar with all its holidays",
+	Args:    cobra.RangeArgs(1, 2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			return withPayrollService(func(s handler.PayrollService) error {
+				if err := s.DeleteHolidayCalendar(args[0]); err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "deleted calendar %s\n", args[0])
+				return nil
+			})
+		}
+
+		date, err := parseOptionalDate("date", args[1])
+		if err != nil || date == nil {
+			return fmt.Errorf("invalid date %s, expected yyyy-mm-dd", args[1])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeleteHoliday(args[0], *date); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted holiday on %s from calendar %s\n", args[1], args[0])
+			return nil
+		})
+	},
+}
+
+func init() {
+	holidaysCmd.AddCommand(holidaysListCmd, holidaysImportCmd, holidaysAddCmd, holidaysDeleteCmd)
+	rootCmd.AddCommand(holidaysCmd)
+}
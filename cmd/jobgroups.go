@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var jobGroupsCmd = &cobra.Command{
+	Use:   "job-groups",
+	Short: "Manage job groups and their hourly rates",
+}
+
+var jobGroupsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List job groups and their hourly rates",
+	Args:    cobra.NoArgs,
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPayrollService(func(s handler.PayrollService) error {
+			rates, err := s.GetJobGroupRates()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "JOB GROUP\tVERSION\tRATE\tEFFECTIVE FROM")
+			for _, r := range rates {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", r.JobGroup, r.Version, handler.FormatRate(r.Rate), formatEffectiveDate(r.EffectiveFrom))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var jobGroupsRatesCmd = &cobra.Command{
+	Use:     "rates <job group>",
+	Short:   "List every rate version of a job group",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPayrollService(func(s handler.PayrollService) error {
+			rates, err := s.GetJobGroupRateHistory(payroll.JobGroup(args[0]))
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tRATE\tEFFECTIVE FROM\tEFFECTIVE TO")
+			for _, r := range rates {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.Version, handler.FormatRate(r.Rate), formatEffectiveDate(r.EffectiveFrom), formatEffectiveDate(r.EffectiveTo))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var jobGroupsCreateCmd = &cobra.Command{
+	Use:     "create <job group> <rate>",
+	Short:   "Create a job group with its hourly rate",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rate, err := parseRate(args[1])
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			j, err := s.CreateJobGroup(payroll.JobGroupRate{JobGroup: payroll.JobGroup(args[0]), Rate: rate})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created job group %s with rate %s\n", j.JobGroup, handler.FormatRate(j.Rate))
+			return nil
+		})
+	},
+}
+
+var effectiveFrom string
+
+var jobGroupsUpdateCmd = &cobra.Command{
+	Use:     "update <job group> <rate>",
+	Short:   "Change the hourly rate of a job group from the effective date",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rate, err := parseRate(args[1])
+		if err != nil {
+			return err
+		}
+		from, err := time.ParseInLocation(time.DateOnly, effectiveFrom, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid effective date %s, expected yyyy-mm-dd", effectiveFrom)
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			j, err := s.ChangeJobGroupRate(payroll.JobGroupRate{JobGroup: payroll.JobGroup(args[0]), Rate: rate, EffectiveFrom: &from})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "changed job group %s to rate %s from %s, version %d\n",
+				j.JobGroup, handler.FormatRate(j.Rate), formatEffectiveDate(j.EffectiveFrom), j.Version)
+			return nil
+		})
+	},
+}
+
+var jobGroupsDeleteCmd = &cobra.Command{
+	Use:     "delete <job group>",
+	Short:   "Delete a job group that no work log belongs to",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeleteJobGroup(payroll.JobGroup(args[0])); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted job group %s\n", args[0])
+			return nil
+		})
+	},
+}
+
+func parseRate(s string) (decimal.Decimal, error) {
+	rate, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid rate %s, expected a number", s)
+	}
+	return rate, nil
+}
+
+// formatEffectiveDate func formats an optional effective date, nil is unbounded
+func formatEffectiveDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateOnly)
+}
+
+func init() {
+	jobGroupsUpdateCmd.Flags().StringVar(&effectiveFrom, "effective-from", time.Now().Format(time.DateOnly), "first day the rate is in force, as yyyy-mm-dd")
+	jobGroupsCmd.AddCommand(jobGroupsListCmd, jobGroupsRatesCmd, jobGroupsCreateCmd, jobGroupsUpdateCmd, jobGroupsDeleteCmd)
+	rootCmd.AddCommand(jobGroupsCmd)
+}
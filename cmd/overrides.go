@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var (
+	overrideEmployeeId    int
+	overrideJobGroup      string
+	overrideEffectiveFrom string
+	overrideEffectiveTo   string
+)
+
+var rateOverridesCmd = &cobra.Command{
+	Use:   "rate-overrides",
+	Short: "Manage employee rates replacing the job group rates",
+}
+
+var rateOverridesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List rate overrides",
+	Args:    cobra.NoArgs,
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var employeeId *int
+		if cmd.Flags().Changed("employee") {
+			employeeId = &overrideEmployeeId
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			overrides, err := s.GetRateOverrides(employeeId)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tEMPLOYEE\tJOB GROUP\tRATE\tEFFECTIVE FROM\tEFFECTIVE TO")
+			for _, o := range overrides {
+				group := "*"
+				if o.JobGroup != nil {
+					group = string(*o.JobGroup)
+				}
+				fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n", o.Id, o.EmployeeId, group, handler.FormatRate(o.Rate),
+					formatEffectiveDate(o.EffectiveFrom), formatEffectiveDate(o.EffectiveTo))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var rateOverridesCreateCmd = &cobra.Command{
+	Use:     "create <employee id> <rate>",
+	Short:   "Override the rate of an employee, for one job group with --job-group or every group",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		employeeId, err := strconv.Atoi(args[0])
+		if err != nil || employeeId < 1 {
+			return fmt.Errorf("invalid employee id %s, expected a positive integer", args[0])
+		}
+		rate, err := parseRate(args[1])
+		if err != nil {
+			return err
+		}
+
+		o := payroll.RateOverride{EmployeeId: employeeId, Rate: rate}
+		if overrideJobGroup != "" {
+			group := payroll.JobGroup(overrideJobGroup)
+			o.JobGroup = &group
+		}
+		if o.EffectiveFrom, err = parseOptionalDate("effective-from", overrideEffectiveFrom); err != nil {
+			return err
+		}
+		if o.EffectiveTo, err = parseOptionalDate("effective-to", overrideEffectiveTo); err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			o, err := s.CreateRateOverride(o)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created rate override %d for employee %d with rate %s\n", o.Id, o.EmployeeId, handler.FormatRate(o.Rate))
+			return nil
+		})
+	},
+}
+
+var rateOverridesDeleteCmd = &cobra.Command{
+	Use:     "delete <override id>",
+	Short:   "Delete a rate override",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid override id %s, expected an integer", args[0])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeleteRateOverride(id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted rate override %d\n", id)
+			return nil
+		})
+	},
+}
+
+// parseOptionalDate func parses a yyyy-mm-dd flag value, empty value is unbounded
+func parseOptionalDate(flag, s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s %s, expected yyyy-mm-dd", flag, s)
+	}
+	return &t, nil
+}
+
+func init() {
+	rateOverridesListCmd.Flags().IntVar(&overrideEmployeeId, "employee", 0, "only list the overrides of the employee")
+	rateOverridesCreateCmd.Flags().StringVar(&overrideJobGroup, "job-group", "", "job group the override applies to, every group when not set")
+	rateOverridesCreateCmd.Flags().StringVar(&overrideEffectiveFrom, "effective-from", "", "first day the override is in force, as yyyy-mm-dd")
+	rateOverridesCreateCmd.Flags().StringVar(&overrideEffectiveTo, "effective-to", "", "last day the override is in force, as yyyy-mm-dd")
+	rateOverridesCmd.AddCommand(rateOverridesListCmd, rateOverridesCreateCmd, rateOverridesDeleteCmd)
+	rootCmd.AddCommand(rateOverridesCmd)
+}
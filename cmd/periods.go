@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var payPeriodsCmd = &cobra.Command{
+	Use:   "pay-periods",
+	Short: "Manage the pay periods, closing them once their report is final and marking them paid",
+}
+
+var payPeriodsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List pay periods",
+	Args:    cobra.NoArgs,
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withPayrollService(func(s handler.PayrollService) error {
+			periods, err := s.GetPeriods()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tSTART DATE\tEND DATE\tSTATE\tCLOSED\tPAID")
+			for _, p := range periods {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", p.Id, p.StartDate.Format(time.DateOnly), p.EndDate.Format(time.DateOnly),
+					p.State, formatTimestamp(p.ClosedTs), formatTimestamp(p.PaidTs))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var payPeriodsCreateCmd = &cobra.Command{
+	Use:     "create <start date> <end date>",
+	Short:   "Add an open pay period, dates as yyyy-mm-dd and inclusive",
+	Args:    cobra.ExactArgs(2),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start, err := time.ParseInLocation(time.DateOnly, args[0], time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid start date %s, expected yyyy-mm-dd", args[0])
+		}
+		end, err := time.ParseInLocation(time.DateOnly, args[1], time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid end date %s, expected yyyy-mm-dd", args[1])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			p, err := s.CreatePeriod(payroll.PayrollPeriod{StartDate: start, EndDate: end})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created pay period %d from %s to %s\n", p.Id, p.StartDate.Format(time.DateOnly),
+				p.EndDate.Format(time.DateOnly))
+			return nil
+		})
+	},
+}
+
+// newPeriodStateCmd func returns the command moving a pay period to the state
+func newPeriodStateCmd(use, short string, state payroll.PeriodState) *cobra.Command {
+	return &cobra.Command{
+		Use:     use + " <period id>",
+		Short:   short,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: checkConfig,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parsePeriodId(args[0])
+			if err != nil {
+				return err
+			}
+
+			return withPayrollService(func(s handler.PayrollService) error {
+				p, err := s.SetPeriodState(id, state)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "pay period %d from %s to %s is %s\n", p.Id, p.StartDate.Format(time.DateOnly),
+					p.EndDate.Format(time.DateOnly), p.State)
+				if state == payroll.PeriodClosed {
+					fmt.Fprintf(cmd.OutOrStdout(), "snapshot of %d employee pay periods taken\n", len(p.Snapshot))
+				}
+				return nil
+			})
+		},
+	}
+}
+
+var payPeriodsDeleteCmd = &cobra.Command{
+	Use:     "delete <period id>",
+	Short:   "Delete an open pay period",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := parsePeriodId(args[0])
+		if err != nil {
+			return err
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeletePeriod(id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted pay period %d\n", id)
+			return nil
+		})
+	},
+}
+
+func parsePeriodId(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid period id %s, expected an integer", s)
+	}
+	return id, nil
+}
+
+// formatTimestamp func formats an optional timestamp, unset ones are shown as a dash
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
+
+func init() {
+	payPeriodsCmd.AddCommand(payPeriodsListCmd, payPeriodsCreateCmd,
+		newPeriodStateCmd("close", "Close a pay period, snapshotting its report. Work logs dated in it are only accepted as adjustments", payroll.PeriodClosed),
+		newPeriodStateCmd("pay", "Mark a closed pay period as paid, paid periods are final", payroll.PeriodPaid),
+		newPeriodStateCmd("reopen", "Open a closed pay period again for corrections, its snapshot is cleared", payroll.PeriodOpen),
+		payPeriodsDeleteCmd)
+	rootCmd.AddCommand(payPeriodsCmd)
+}
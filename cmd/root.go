@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/joshinjohnson/wave-exercise/handler"
 	"github.com/joshinjohnson/wave-exercise/pkg/db"
 	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/joshinjohnson/wave-exercise/pkg/worker"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,7 +23,7 @@ import (
 
 const (
 	appName        = "payroll-api"
-	defaultTimeout = 30 * time.Minute
+	defaultTimeout = time.Minute
 	failureCount   = 3
 )
 
@@ -31,41 +34,66 @@ var (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "payroll",
-	Short: "Employee Payroll Generator API",
-	PreRunE: func(cmd *cobra.Command, args []string) error {
-		if cfg == nil {
-			return errors.New("error while reading config file")
-		}
-
-		return nil
-	},
+	Use:     "payroll",
+	Short:   "Employee Payroll Generator API",
+	PreRunE: checkConfig,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 		log.Info("setting up dependencies")
 
-		dbConfig := make(map[string]string, 0)
-		dbConfig[db.UsernameField] = cfg.DbConfig.User
-		dbConfig[db.PasswordField] = cfg.DbConfig.Password
-		dbConfig[db.HostNameField] = cfg.DbConfig.Hostname
-		dbConfig[db.PortField] = cfg.DbConfig.Port
-		dbConfig[db.DbNameField] = cfg.DbConfig.DatabaseName
-		dbConfig[db.SchemaField] = cfg.DbConfig.SchemaName
-		dbConfig[db.SSLModeField] = cfg.DbConfig.SSLMode
-
-		dbW, err := db.NewDbWrapper(ctx, dbConfig)
+		dbW, err := newDbWrapper(ctx)
 		if err != nil {
 			log.Errorf("error while setting up db client: %v", err)
 			os.Exit(1)
 		}
 		defer dbW.DB.Close()
 
-		payrollService := payroll.NewPayrollService(dbW)
-		payrollHandler := handler.NewPayrollHandler(payrollService)
+		if err := checkWorkerConfig(cfg.UploadConfig); err != nil {
+			log.Errorf("error while reading upload config: %v", err)
+			os.Exit(1)
+		}
+
+		validationPolicy, err := handler.ParseValidationPolicy(cfg.UploadConfig.ValidationPolicy)
+		if err != nil {
+			log.Errorf("error while reading upload config: %v", err)
+			os.Exit(1)
+		}
+
+		schemaProfiles, err := newSchemaProfiles(cfg.UploadConfig.CSVProfiles)
+		if err != nil {
+			log.Errorf("error while reading upload config: %v", err)
+			os.Exit(1)
+		}
+
+		var reportIdPattern *regexp.Regexp
+		if cfg.UploadConfig.ReportIdPattern != "" {
+			reportIdPattern, err = regexp.Compile(cfg.UploadConfig.ReportIdPattern)
+			if err != nil || reportIdPattern.NumSubexp() < 1 {
+				log.Errorf("error while reading upload config: invalid report id pattern %s", cfg.UploadConfig.ReportIdPattern)
+				os.Exit(1)
+			}
+		}
+
+		payrollConfig, err := newPayrollConfig()
+		if err != nil {
+			log.Errorf("error while reading payroll config: %v", err)
+			os.Exit(1)
+		}
+
+		payrollService := payroll.NewPayrollService(dbW, payrollConfig)
+		payrollHandler := handler.NewPayrollHandler(payrollService, handler.Config{
+			ValidationPolicy: validationPolicy,
+			SchemaProfiles:   schemaProfiles,
+			ReportIdPattern:  reportIdPattern,
+			JobGroups:        newJobGroupConfig(cfg.UploadConfig.JobGroups),
+		})
 		authMiddleware := handler.NewAuthorization(cfg.AuthToken)
 		contextMiddleware := handler.NewContext()
 
+		uploadWorkers := worker.NewPool(payrollService, payrollHandler.ProcessUpload, cfg.UploadConfig.Workers,
+			cfg.UploadConfig.PollInterval, cfg.UploadConfig.JobTimeout)
+
 		h := handler.Handler(payrollHandler)
 		h = removeTrailingSlash(h)
 		h = addDefaultHeader(h)
@@ -83,8 +111,12 @@ var rootCmd = &cobra.Command{
 		log.Info("finished setting up dependencies, starting services")
 
 		var wg sync.WaitGroup
+		wg.Add(1)
+		go uploadWorkers.Run(ctx, &wg)
+
 		wg.Add(1)
 		startAPIHandler(&wg, &apiServer)
+		cancel()
 		wg.Wait()
 
 		log.Info("successfully stopped payroll-api")
@@ -115,6 +147,321 @@ func initConfig() {
 	}
 }
 
+func checkConfig(cmd *cobra.Command, args []string) error {
+	if cfg == nil {
+		return errors.New("error while reading config file")
+	}
+
+	return nil
+}
+
+// checkWorkerConfig func rejects upload worker settings the pool can't run with. Workers touch their job a few
+// times per job timeout, so it's at least a second
+func checkWorkerConfig(c UploadConfig) error {
+	if c.Workers <= 0 {
+		return fmt.Errorf("invalid number of upload workers %d, it must be positive", c.Workers)
+	}
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("invalid poll interval %s, it must be positive", c.PollInterval)
+	}
+	if c.JobTimeout < time.Second {
+		return fmt.Errorf("invalid job timeout %s, it must be at least 1s", c.JobTimeout)
+	}
+	return nil
+}
+
+func newDbWrapper(ctx context.Context) (*db.DbWrapper, error) {
+	dbConfig := make(map[string]string, 0)
+	dbConfig[db.UsernameField] = cfg.DbConfig.User
+	dbConfig[db.PasswordField] = cfg.DbConfig.Password
+	dbConfig[db.HostNameField] = cfg.DbConfig.Hostname
+	dbConfig[db.PortField] = cfg.DbConfig.Port
+	dbConfig[db.DbNameField] = cfg.DbConfig.DatabaseName
+	dbConfig[db.SchemaField] = cfg.DbConfig.SchemaName
+	dbConfig[db.SSLModeField] = cfg.DbConfig.SSLMode
+
+	return db.NewDbWrapper(ctx, dbConfig)
+}
+
+// withPayrollService func runs a cli command against the payroll service, closing the db client afterwards
+func withPayrollService(run func(s handler.PayrollService) error) error {
+	payrollConfig, err := newPayrollConfig()
+	if err != nil {
+		return fmt.Errorf("error while reading payroll config: %v", err)
+	}
+
+	dbW, err := newDbWrapper(context.Background())
+	if err != nil {
+		return fmt.Errorf("error while setting up db client: %v", err)
+	}
+	defer dbW.DB.Close()
+
+	return run(payroll.NewPayrollService(dbW, payrollConfig))
+}
+
+func newPayrollConfig() (payroll.Config, error) {
+	exact, err := payroll.ParseDuplicateAction(cfg.UploadConfig.Duplicates.Exact)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	overlap, err := payroll.ParseDuplicateAction(cfg.UploadConfig.Duplicates.Overlap)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	employees, err := payroll.ParseEmployeeCheck(cfg.UploadConfig.Employees)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	overtime, err := newOvertimeRules(cfg.ReportConfig.Overtime)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	premiums := cfg.ReportConfig.Premiums
+	holidayMultiplier, err := parseDecimal("holiday multiplier", premiums.HolidayMultiplier)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+	weekendMultiplier, err := parseDecimal("weekend multiplier", premiums.WeekendMultiplier)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+	weekendDays := make([]time.Weekday, 0, len(premiums.WeekendDays))
+	for _, d := range premiums.WeekendDays {
+		weekday, err := payroll.ParseWeekday(d)
+		if err != nil {
+			return payroll.Config{}, err
+		}
+		weekendDays = append(weekendDays, weekday)
+	}
+
+	roundingMode, err := payroll.ParseRoundingMode(cfg.ReportConfig.Rounding.Mode)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	roundingScope, err := payroll.ParseRoundingScope(cfg.ReportConfig.Rounding.Scope)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	hoursRounding, err := newHoursRounding(cfg.ReportConfig.HoursRounding)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	schedule, err := newPaySchedule(cfg.ReportConfig.PaySchedule)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	schedules, err := newPaySchedules(cfg.ReportConfig.PaySchedules)
+	if err != nil {
+		return payroll.Config{}, err
+	}
+
+	return payroll.Config{
+		Duplicates: payroll.DuplicateRules{
+			Exact:   exact,
+			Overlap: overlap,
+		},
+		Employees: employees,
+		Report: payroll.ReportConfig{
+			Overtime: overtime,
+			Premiums: payroll.PremiumRules{
+				Calendar:          premiums.Calendar,
+				HolidayMultiplier: holidayMultiplier,
+				WeekendMultiplier: weekendMultiplier,
+				WeekendDays:       weekendDays,
+			},
+			Rounding: payroll.Rounding{
+				Mode:  roundingMode,
+				Scope: roundingScope,
+			},
+			Hours:     hoursRounding,
+			Schedule:  schedule,
+			Schedules: schedules,
+		},
+	}, nil
+}
+
+func newOvertimeRules(c OvertimeConfig) (payroll.OvertimeRules, error) {
+	weekStart, err := payroll.ParseWeekday(c.WeekStart)
+	if err != nil {
+		return payroll.OvertimeRules{}, err
+	}
+
+	rules := payroll.OvertimeRules{
+		WeekStart: weekStart,
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"daily overtime hours", c.DailyOvertimeHours, &rules.DailyOvertime},
+		{"daily double time hours", c.DailyDoubleTimeHours, &rules.DailyDoubleTime},
+		{"weekly overtime hours", c.WeeklyOvertimeHours, &rules.WeeklyOvertime},
+		{"overtime multiplier", c.OvertimeMultiplier, &rules.OvertimeMultiplier},
+		{"double time multiplier", c.DoubleTimeMultiplier, &rules.DoubleTimeMultiplier},
+	} {
+		if *v.dst, err = parseDecimal(v.name, v.value); err != nil {
+			return payroll.OvertimeRules{}, err
+		}
+	}
+	if rules.DailyOvertime.IsNegative() || rules.DailyDoubleTime.IsNegative() || rules.WeeklyOvertime.IsNegative() {
+		return payroll.OvertimeRules{}, errors.New("overtime hours can't be negative")
+	}
+
+	return rules, nil
+}
+
+// parseDecimal func reads a decimal config value exactly, unset values are 0
+func parseDecimal(name, s string) (decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return decimal.Zero, nil
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s %s, expected a decimal", name, s)
+	}
+	return d, nil
+}
+
+func newPaySchedule(c PayScheduleConfig) (payroll.PaySchedule, error) {
+	frequency, err := payroll.ParsePayFrequency(c.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	var anchor *time.Time
+	if c.AnchorDate != "" {
+		t, err := time.ParseInLocation(time.DateOnly, c.AnchorDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pay schedule anchor date %s, expected yyyy-mm-dd", c.AnchorDate)
+		}
+		anchor = &t
+	}
+
+	return payroll.NewPaySchedule(frequency, anchor)
+}
+
+func newPaySchedules(c []NamedPayScheduleConfig) (payroll.PaySchedules, error) {
+	schedules := payroll.PaySchedules{
+		Named:     make(map[string]payroll.PaySchedule, len(c)),
+		JobGroups: make(map[payroll.JobGroup]string),
+	}
+	for _, named := range c {
+		if named.Name == "" {
+			return payroll.PaySchedules{}, errors.New("pay schedule name can't be empty")
+		}
+		if _, ok := schedules.Named[named.Name]; ok {
+			return payroll.PaySchedules{}, fmt.Errorf("pay schedule %s is configured twice", named.Name)
+		}
+
+		schedule, err := newPaySchedule(named.PayScheduleConfig)
+		if err != nil {
+			return payroll.PaySchedules{}, fmt.Errorf("pay schedule %s: %v", named.Name, err)
+		}
+		schedules.Named[named.Name] = schedule
+
+		for _, group := range named.JobGroups {
+			if other, ok := schedules.JobGroups[payroll.JobGroup(group)]; ok {
+				return payroll.PaySchedules{}, fmt.Errorf("job group %s is paid on both the %s and %s pay schedules", group, other, named.Name)
+			}
+			schedules.JobGroups[payroll.JobGroup(group)] = named.Name
+		}
+	}
+
+	return schedules, nil
+}
+
+func newHoursRounding(c HoursRoundingConfig) (payroll.HoursRounding, error) {
+	defaultPolicy, err := newHoursPolicy(c.HoursPolicyConfig)
+	if err != nil {
+		return payroll.HoursRounding{}, err
+	}
+
+	groups := make(map[payroll.JobGroup]payroll.HoursPolicy, len(c.JobGroups))
+	for _, g := range c.JobGroups {
+		policy, err := newHoursPolicy(g.HoursPolicyConfig)
+		if err != nil {
+			return payroll.HoursRounding{}, fmt.Errorf("job group %s: %v", g.JobGroup, err)
+		}
+		groups[payroll.JobGroup(strings.TrimSpace(g.JobGroup))] = policy
+	}
+
+	return payroll.HoursRounding{
+		Default:   defaultPolicy,
+		JobGroups: groups,
+	}, nil
+}
+
+func newHoursPolicy(c HoursPolicyConfig) (payroll.HoursPolicy, error) {
+	mode, err := payroll.ParseHoursRoundingMode(c.Mode)
+	if err != nil {
+		return payroll.HoursPolicy{}, err
+	}
+	increment, err := parseDecimal("hours rounding increment", c.Increment)
+	if err != nil {
+		return payroll.HoursPolicy{}, err
+	}
+	dailyCap, err := parseDecimal("daily cap", c.DailyCap)
+	if err != nil {
+		return payroll.HoursPolicy{}, err
+	}
+	if increment.IsNegative() || dailyCap.IsNegative() {
+		return payroll.HoursPolicy{}, errors.New("hours rounding increment and daily cap can't be negative")
+	}
+
+	return payroll.HoursPolicy{
+		Increment: increment,
+		Mode:      mode,
+		DailyCap:  dailyCap,
+	}, nil
+}
+
+func newJobGroupConfig(c JobGroupsConfig) handler.JobGroupConfig {
+	aliases := make(map[string]payroll.JobGroup, len(c.Aliases))
+	for _, a := range c.Aliases {
+		aliases[strings.TrimSpace(a.Alias)] = payroll.JobGroup(strings.TrimSpace(a.JobGroup))
+	}
+
+	return handler.JobGroupConfig{
+		CaseInsensitive: c.CaseInsensitive,
+		Aliases:         aliases,
+	}
+}
+
+func newSchemaProfiles(profilesConfig map[string]CSVProfileConfig) (handler.SchemaProfiles, error) {
+	profiles := make([]handler.SchemaProfile, 0, len(profilesConfig))
+	for name, c := range profilesConfig {
+		dateFormats := c.DateFormats
+		if c.DateFormat != "" {
+			dateFormats = append(dateFormats, c.DateFormat)
+		}
+
+		profile, err := handler.NewSchemaProfile(name, c.Delimiter, c.LazyQuotes, dateFormats, map[string]string{
+			handler.FieldDate:       c.Columns.Date,
+			handler.FieldHours:      c.Columns.Hours,
+			handler.FieldEmployeeId: c.Columns.EmployeeId,
+			handler.FieldJobGroup:   c.Columns.JobGroup,
+			handler.FieldCostCenter: c.Columns.CostCenter,
+		})
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return handler.NewSchemaProfiles(profiles...), nil
+}
+
 func removeTrailingSlash(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		request.URL.Path = strings.TrimSuffix(request.URL.Path, "/")
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var scheduleEmployeeId int
+
+var paySchedulesCmd = &cobra.Command{
+	Use:   "pay-schedules",
+	Short: "Manage the pay schedules employees are paid on",
+}
+
+var paySchedulesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List pay schedule assignments",
+	Args:    cobra.NoArgs,
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var employeeId *int
+		if cmd.Flags().Changed("employee") {
+			employeeId = &scheduleEmployeeId
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			assignments, err := s.GetScheduleAssignments(employeeId)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tEMPLOYEE\tSCHEDULE\tEFFECTIVE FROM")
+			for _, a := range assignments {
+				fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", a.Id, a.EmployeeId, a.Schedule, a.EffectiveFrom.Format(time.DateOnly))
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var paySchedulesAssignCmd = &cobra.Command{
+	Use:     "assign <employee id> <schedule> <effective from>",
+	Short:   "Switch an employee to a pay schedule of the config from the effective date, as yyyy-mm-dd",
+	Args:    cobra.ExactArgs(3),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		employeeId, err := parseEmployeeId(args[0])
+		if err != nil {
+			return err
+		}
+		from, err := time.ParseInLocation(time.DateOnly, args[2], time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid effective date %s, expected yyyy-mm-dd", args[2])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			a, err := s.CreateScheduleAssignment(payroll.ScheduleAssignment{EmployeeId: employeeId, Schedule: args[1], EffectiveFrom: from})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created pay schedule assignment %d, employee %d is paid %s from %s\n", a.Id, a.EmployeeId,
+				a.Schedule, a.EffectiveFrom.Format(time.DateOnly))
+			return nil
+		})
+	},
+}
+
+var paySchedulesDeleteCmd = &cobra.Command{
+	Use:     "delete <assignment id>",
+	Short:   "Delete a pay schedule assignment",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid assignment id %s, expected an integer", args[0])
+		}
+
+		return withPayrollService(func(s handler.PayrollService) error {
+			if err := s.DeleteScheduleAssignment(id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted pay schedule assignment %d\n", id)
+			return nil
+		})
+	},
+}
+
+func init() {
+	paySchedulesListCmd.Flags().IntVar(&scheduleEmployeeId, "employee", 0, "only list the assignments of the employee")
+	paySchedulesCmd.AddCommand(paySchedulesListCmd, paySchedulesAssignCmd, paySchedulesDeleteCmd)
+	rootCmd.AddCommand(paySchedulesCmd)
+}
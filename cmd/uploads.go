@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/spf13/cobra"
+)
+
+var deletedBy string
+
+var uploadsCmd = &cobra.Command{
+	Use:   "uploads",
+	Short: "Manage processed time reports",
+}
+
+var uploadsDeleteCmd = &cobra.Command{
+	Use:     "delete <report id>",
+	Short:   "Roll back an upload, deleting every version of the time report and all its work logs",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: checkConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reportId, err := strconv.Atoi(args[0])
+		if err != nil || reportId < 1 {
+			return fmt.Errorf("invalid report id %s, expected a positive integer", args[0])
+		}
+		if deletedBy == "" {
+			return errors.New("--by is required when the current os user can't be looked up")
+		}
+
+		var d payroll.ReportDeletion
+		err = withPayrollService(func(s handler.PayrollService) error {
+			d, err = s.DeleteReport(reportId, deletedBy)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "deleted report %d: %d versions, %d work logs\n", d.ReportId, d.Versions, d.WorkLogs)
+		return nil
+	},
+}
+
+func init() {
+	var currentUser string
+	if u, err := user.Current(); err == nil {
+		currentUser = u.Username
+	}
+
+	uploadsDeleteCmd.Flags().StringVar(&deletedBy, "by", currentUser, "name of the person deleting the report, recorded in the audit log")
+	uploadsCmd.AddCommand(uploadsDeleteCmd)
+	rootCmd.AddCommand(uploadsCmd)
+}
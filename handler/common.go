@@ -1,19 +1,121 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
 )
 
 type PayrollService interface {
-	InsertLogs(filenameId int, logs []payroll.WorkLog) error
+	InsertLogs(reportId int, logs []payroll.WorkLog, opts payroll.InsertOptions) (payroll.InsertResult, error)
 	GetReport(limit, offset uint64) (payroll.PayrollReport, error)
+	GetCostReport(from, to *time.Time) (payroll.CostReport, error)
+	EnqueueUpload(job payroll.UploadJob) (payroll.UploadJob, error)
+	GetUploadJob(id string) (payroll.UploadJob, error)
+	PreviewLogs(reportId int, logs []payroll.WorkLog, opts payroll.InsertOptions) (payroll.ReportPreview, error)
+	GetReportVersions(reportId int) ([]payroll.ReportVersion, error)
+	DeleteReport(reportId int, deletedBy string) (payroll.ReportDeletion, error)
+	GetWorkLogs(filter payroll.WorkLogFilter) ([]payroll.WorkLog, error)
+	GetJobGroups() ([]payroll.JobGroup, error)
+	GetJobGroupRates() ([]payroll.JobGroupRate, error)
+	GetJobGroupRate(group payroll.JobGroup) (payroll.JobGroupRate, error)
+	CreateJobGroup(j payroll.JobGroupRate) (payroll.JobGroupRate, error)
+	GetJobGroupRateHistory(group payroll.JobGroup) ([]payroll.JobGroupRate, error)
+	ChangeJobGroupRate(j payroll.JobGroupRate) (payroll.JobGroupRate, error)
+	DeleteJobGroup(group payroll.JobGroup) error
+	GetRateOverrides(employeeId *int) ([]payroll.RateOverride, error)
+	CreateRateOverride(o payroll.RateOverride) (payroll.RateOverride, error)
+	DeleteRateOverride(id int) error
+	GetScheduleAssignments(employeeId *int) ([]payroll.ScheduleAssignment, error)
+	CreateScheduleAssignment(a payroll.ScheduleAssignment) (payroll.ScheduleAssignment, error)
+	DeleteScheduleAssignment(id int) error
+	GetPeriods() ([]payroll.PayrollPeriod, error)
+	GetPeriod(id int) (payroll.PayrollPeriod, error)
+	CreatePeriod(p payroll.PayrollPeriod) (payroll.PayrollPeriod, error)
+	SetPeriodState(id int, state payroll.PeriodState) (payroll.PayrollPeriod, error)
+	DeletePeriod(id int) error
+	GetHolidayCalendars() ([]payroll.HolidayCalendar, error)
+	GetHolidays(calendar string) ([]payroll.Holiday, error)
+	SaveHoliday(h payroll.Holiday) (payroll.Holiday, error)
+	ImportHolidays(calendar string, holidays []payroll.Holiday) ([]payroll.Holiday, error)
+	DeleteHoliday(calendar string, date time.Time) error
+	DeleteHolidayCalendar(calendar string) error
+	GetEmployees(status *payroll.EmployeeStatus) ([]payroll.Employee, error)
+	GetEmployee(id int) (payroll.Employee, error)
+	CreateEmployee(e payroll.Employee) (payroll.Employee, error)
+	UpdateEmployee(e payroll.Employee) (payroll.Employee, error)
+	DeleteEmployee(id int) error
+	ImportEmployees(employees []payroll.Employee) (payroll.EmployeeImport, error)
 }
 
 // API response messages
 var (
-	ErrHTTPForbidden           = "Forbidden"
-	ErrHTTPInternalServerError = "Internal Server Error"
-	ErrCSVFileProcessingError  = "Error reading csv file. Please upload a valid csv file"
-	MsgUploadSuccessful        = "Upload successful"
-	// ErrCSVFileAlreadyProcessedError = "Error reading csv file. Already processed file with same id"
+	ErrHTTPForbidden                   = "Forbidden"
+	ErrHTTPInternalServerError         = "Internal Server Error"
+	ErrCSVFileProcessingError          = "Error reading csv file. Please upload a valid csv file"
+	ErrCSVHeaderMismatchError          = "Error reading csv file. Header doesn't match the csv profile"
+	ErrCSVUnknownProfileError          = "Error reading csv file. Unknown csv profile"
+	ErrCSVNoProfileMatchError          = "Error reading csv file. Header doesn't match any csv profile"
+	ErrCSVInvalidRows                  = "Error reading csv file. File contains invalid rows"
+	ErrCSVFileAlreadyProcessedError    = "Error reading csv file. Already processed file with same id"
+	ErrUploadNotFound                  = "Upload not found"
+	ErrInvalidAmendError               = "Invalid amend value, expected true or false"
+	ErrInvalidAdjustmentError          = "Invalid adjustment value, expected true or false"
+	ErrAmendedReportNotFoundError      = "Error amending report. No processed report with same id"
+	ErrReportNotFoundError             = "Report not found"
+	ErrInvalidDryRunError              = "Invalid dry_run value, expected true or false"
+	ErrInvalidReportIdError            = "Invalid report id, expected a positive integer"
+	ErrReportIdMismatchError           = "The report_id form field and X-Report-Id header don't match"
+	ErrMissingReportIdError            = "Report id is missing, set the report_id form field or X-Report-Id header"
+	ErrMissingActorError               = "X-Actor header is missing, set it to the name of the person deleting the report"
+	ErrInvalidLimitError               = "Invalid limit, expected a value between 1 and 1000"
+	ErrInvalidOffsetError              = "Invalid offset, expected a positive integer"
+	ErrInvalidDateRangeError           = "Invalid dates, expected end_date on or after start_date"
+	ErrDuplicateLogsError              = "Error importing csv file. File contains duplicate work logs"
+	ErrInvalidJSONError                = "Invalid request body, expected json"
+	ErrInvalidJobGroupError            = "Invalid job group, expected a name of up to 32 characters and a rate of 0 or more"
+	ErrJobGroupExistsError             = "Job group already exists"
+	ErrJobGroupNotFoundError           = "Job group not found"
+	ErrJobGroupInUseError              = "Job group has work logs and can't be deleted"
+	ErrInvalidEffectiveDateError       = "Invalid effective_from, a rate change must start after the current rate"
+	ErrInvalidRateOverrideError        = "Invalid rate override, expected a positive employee id, a rate of 0 or more and effective_to on or after effective_from"
+	ErrRateOverrideOverlapsError       = "Rate override overlaps another override of the employee and job group"
+	ErrRateOverrideNotFoundError       = "Rate override not found"
+	ErrInvalidScheduleAssignmentError  = "Invalid pay schedule assignment, expected a positive employee id, a schedule and effective_from"
+	ErrPayScheduleNotFoundError        = "Pay schedule not found, expected the name of a pay schedule of the config"
+	ErrScheduleAssignmentExistsError   = "Employee already switches pay schedule on effective_from"
+	ErrScheduleAssignmentNotFoundError = "Pay schedule assignment not found"
+	ErrInvalidPeriodError              = "Invalid pay period, expected start_date and end_date on or after it"
+	ErrInvalidPeriodStateError         = "Invalid state, expected open, closed or paid"
+	ErrInvalidPeriodTransitionError    = "Pay period can't change to the state, open periods are closed, closed periods are paid or opened again, and paid periods are final"
+	ErrPeriodOverlapsError             = "Pay period overlaps another pay period"
+	ErrPeriodNotFoundError             = "Pay period not found"
+	ErrPeriodLockedError               = "Pay period isn't open and can't be deleted"
+	ErrClosedPeriodLogsError           = "Error importing csv file. File contains work logs dated in closed pay periods, upload it as an adjustment"
+	ErrOverlappingPeriodLogsError      = "Error importing csv file. File contains work logs paid in pay periods overlapping other pay periods of the employee, assign the employee a pay schedule"
+	ErrReportPeriodClosedError         = "Report has work logs in closed pay periods and can't be changed"
+	ErrHolidayFileError                = "Error reading holiday file. Please upload an ical file, or a csv file with date,name columns"
+	ErrInvalidHolidayError             = "Invalid holiday, expected a date and a name, and a calendar name of up to 64 characters"
+	ErrCalendarNotFoundError           = "Holiday calendar not found"
+	ErrHolidayNotFoundError            = "Holiday not found"
+	ErrInvalidEmployeeError            = "Invalid employee, expected a positive id, a name, an active or terminated status and termination_date on or after hire_date"
+	ErrEmployeeExistsError             = "Employee already exists"
+	ErrEmployeeNotFoundError           = "Employee not found"
+	ErrEmployeeFileError               = "Error reading employee file. Please upload a valid csv file"
+	ErrEmployeeHeaderMismatchError     = "Error reading employee file. Header is missing the employee id or name column"
+	ErrEmployeeLogsError               = "Error importing csv file. File contains work logs of unknown, not yet hired or terminated employees"
+	MsgUploadSuccessful                = "Upload successful"
+	MsgUploadDuplicatesFound           = "Upload successful, duplicate work logs were found"
+	MsgUploadPartiallySuccessful       = "Upload successful, invalid rows were skipped"
+	MsgUploadEmployeeIssuesFound       = "Upload successful, work logs of unknown, not yet hired or terminated employees were found"
+	MsgUploadPreview                   = "Dry run, nothing was saved"
+	MsgJobGroupDeleted                 = "Job group deleted"
+	MsgRateOverrideDeleted             = "Rate override deleted"
+	MsgScheduleAssignmentDeleted       = "Pay schedule assignment deleted"
+	MsgPeriodDeleted                   = "Pay period deleted"
+	MsgHolidayDeleted                  = "Holiday deleted"
+	MsgEmployeeImportSuccessful        = "Import successful"
+	MsgEmployeeImportPartial           = "Import successful, invalid rows were skipped"
+	MsgEmployeeDeleted                 = "Employee deleted"
+	MsgCalendarDeleted                 = "Holiday calendar deleted"
 )
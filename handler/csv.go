@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+)
+
+// ValidationPolicy decides what happens to an upload that contains invalid rows
+type ValidationPolicy string
+
+const (
+	// PolicyReject rejects the whole file if any row is invalid
+	PolicyReject ValidationPolicy = "reject"
+	// PolicyImportValid imports the valid rows and reports the invalid ones
+	PolicyImportValid ValidationPolicy = "import_valid"
+)
+
+var ErrCSVHeaderMismatch = errors.New("csv header doesn't match the schema profile")
+
+// ParseValidationPolicy func converts config value to a validation policy, defaults to PolicyReject
+func ParseValidationPolicy(s string) (ValidationPolicy, error) {
+	switch ValidationPolicy(s) {
+	case "", PolicyReject:
+		return PolicyReject, nil
+	case PolicyImportValid:
+		return PolicyImportValid, nil
+	}
+	return "", fmt.Errorf("unknown validation policy: %s", s)
+}
+
+// ParseResult holds the valid work logs of a time report along with the problems found in the invalid rows
+type ParseResult struct {
+	WorkLogs  []payroll.WorkLog
+	RowErrors []payroll.RowError
+	RowsTotal int
+}
+
+// HeaderLine func returns the first line of a csv file
+func HeaderLine(data string) string {
+	headerLine, _, _ := strings.Cut(data, "\n")
+	return strings.TrimSuffix(headerLine, "\r")
+}
+
+// ParseWorkLogs func reads every row of a time report csv laid out as described by the profile,
+// collecting errors for invalid rows instead of stopping on the first one
+func ParseWorkLogs(r io.Reader, profile SchemaProfile, jobGroups JobGroupResolver) (ParseResult, error) {
+	result := ParseResult{
+		WorkLogs:  make([]payroll.WorkLog, 0),
+		RowErrors: make([]payroll.RowError, 0),
+	}
+
+	reader := profile.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return result, fmt.Errorf("error reading csv header: %v", err)
+	}
+
+	index, missing := profile.ColumnIndex(header)
+	if len(missing) > 0 {
+		for _, column := range missing {
+			result.RowErrors = append(result.RowErrors, payroll.RowError{
+				Line:   1,
+				Column: column,
+				Reason: "column not found in header",
+			})
+		}
+		return result, ErrCSVHeaderMismatch
+	}
+
+	parser := rowParser{
+		profile:   profile,
+		index:     index,
+		jobGroups: jobGroups,
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		result.RowsTotal++
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// the partial row holds the fields read before the failing one, the error's column is a position in the line
+			result.RowErrors = append(result.RowErrors, payroll.RowError{
+				Line:   parseErr.Line,
+				Column: parser.columnAt(len(row)),
+				Reason: fmt.Sprintf("%v at position %d", parseErr.Err, parseErr.Column),
+			})
+			continue
+		} else if err != nil {
+			return result, fmt.Errorf("error reading csv file: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		workLog, rowErrors := parser.parse(line, row)
+		if len(rowErrors) > 0 {
+			result.RowErrors = append(result.RowErrors, rowErrors...)
+			continue
+		}
+
+		result.WorkLogs = append(result.WorkLogs, workLog)
+	}
+
+	return result, nil
+}
+
+// rowParser converts csv rows to work logs using the header positions of a profile's columns
+type rowParser struct {
+	profile   SchemaProfile
+	index     map[string]int
+	jobGroups JobGroupResolver
+}
+
+// parse func converts a single csv row to a work log, returning an error for every invalid column
+func (p rowParser) parse(line int, row []string) (payroll.WorkLog, []payroll.RowError) {
+	workLog := payroll.WorkLog{
+		Line: line,
+	}
+	rowErrors := make([]payroll.RowError, 0)
+
+	values := make(map[string]string, len(workLogFields))
+	for _, field := range workLogFields {
+		if p.index[field] >= len(row) {
+			rowErrors = append(rowErrors, payroll.RowError{
+				Line:   line,
+				Column: p.profile.Columns[field],
+				Reason: "missing column",
+			})
+			continue
+		}
+		values[field] = strings.TrimSpace(row[p.index[field]])
+	}
+	if len(rowErrors) > 0 {
+		return workLog, rowErrors
+	}
+
+	logDate, err := p.parseDate(values[FieldDate])
+	if err != nil {
+		rowErrors = append(rowErrors, p.newRowError(line, FieldDate, values, err))
+	} else {
+		workLog.Date = *logDate
+	}
+
+	logHours, err := decimal.NewFromString(values[FieldHours])
+	if err != nil {
+		rowErrors = append(rowErrors, p.newRowError(line, FieldHours, values, fmt.Errorf("hours is not a number")))
+	} else if logHours.IsNegative() {
+		rowErrors = append(rowErrors, p.newRowError(line, FieldHours, values, fmt.Errorf("hours can't be negative")))
+	} else {
+		workLog.HoursLogged = logHours
+	}
+
+	employeeID, err := strconv.ParseInt(values[FieldEmployeeId], 10, 32)
+	if err != nil || employeeID < 1 {
+		rowErrors = append(rowErrors, p.newRowError(line, FieldEmployeeId, values, fmt.Errorf("employee id is not a positive integer")))
+	} else {
+		workLog.EmployeeId = int(employeeID)
+	}
+
+	logJobGroup, err := p.jobGroups.Resolve(values[FieldJobGroup])
+	if err != nil {
+		rowErrors = append(rowErrors, p.newRowError(line, FieldJobGroup, values, err))
+	} else {
+		workLog.JobGroup = logJobGroup
+	}
+
+	if i, ok := p.index[FieldCostCenter]; ok && i < len(row) {
+		if costCenter := strings.TrimSpace(row[i]); costCenter != "" {
+			workLog.CostCenter = &costCenter
+		}
+	}
+
+	return workLog, rowErrors
+}
+
+func (p rowParser) parseDate(s string) (*time.Time, error) {
+	if len(p.profile.DateFormats) == 0 {
+		return ParseTime(s)
+	}
+
+	t, err := ParseDate(s, p.profile.DateFormats)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (p rowParser) newRowError(line int, field string, values map[string]string, err error) payroll.RowError {
+	return payroll.RowError{
+		Line:   line,
+		Column: p.profile.Columns[field],
+		Value:  values[field],
+		Reason: err.Error(),
+	}
+}
+
+// columnAt func returns the profile's header name for the column position, if it's mapped
+func (p rowParser) columnAt(idx int) string {
+	for field, i := range p.index {
+		if i == idx {
+			return p.profile.Columns[field]
+		}
+	}
+	return ""
+}
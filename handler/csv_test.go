@@ -0,0 +1,134 @@
+package handler_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobGroups = handler.NewJobGroupResolver([]payroll.JobGroup{"A", "B"}, handler.JobGroupConfig{})
+
+func TestParseWorkLogs(t *testing.T) {
+	csv := "date,hours worked,employee id,job group\n" +
+		"14/11/2023,7.5,1,A\n" +
+		"9/11/2023,4,2,B\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.RowsTotal)
+	assert.Len(t, result.WorkLogs, 2)
+	assert.Empty(t, result.RowErrors)
+	assert.Equal(t, "7.5", result.WorkLogs[0].HoursLogged.String())
+	assert.Equal(t, 2, result.WorkLogs[1].EmployeeId)
+}
+
+func TestParseWorkLogs_CollectsRowErrors(t *testing.T) {
+	csv := "date,hours worked,employee id,job group\n" +
+		"14/11/2023,7.5,1,A\n" +
+		"32/11/2023,four,2,B\n" +
+		"9/11/2023,4,x,C\n" +
+		"9/11/2023,4,3\n" +
+		"9/11/2023,4,3,b\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.RowsTotal)
+	assert.Len(t, result.WorkLogs, 1)
+	assert.Equal(t, []payroll.RowError{
+		{Line: 3, Column: "date", Value: "32/11/2023", Reason: "invalid date specified"},
+		{Line: 3, Column: "hours worked", Value: "four", Reason: "hours is not a number"},
+		{Line: 4, Column: "employee id", Value: "x", Reason: "employee id is not a positive integer"},
+		{Line: 4, Column: "job group", Value: "C", Reason: "unknown job group"},
+		{Line: 5, Column: "job group", Value: "", Reason: "missing column"},
+		{Line: 6, Column: "job group", Value: "b", Reason: "unknown job group"},
+	}, result.RowErrors)
+}
+
+func TestParseWorkLogs_ProfileColumns(t *testing.T) {
+	profile, err := handler.NewSchemaProfile("iso", ";", false, []string{handler.DateISO8601}, map[string]string{
+		handler.FieldDate:       "Work Date",
+		handler.FieldHours:      "Hours",
+		handler.FieldEmployeeId: "Employee",
+		handler.FieldJobGroup:   "Group",
+	})
+	assert.NoError(t, err)
+
+	csv := "group;notes;employee;hours;work date\n" +
+		"B;night shift;7;4.5;2023-11-14\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), profile, jobGroups)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.RowErrors)
+	assert.Equal(t, []payroll.WorkLog{
+		{EmployeeId: 7, JobGroup: "B", HoursLogged: decimal.RequireFromString("4.5"), Date: time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local), Line: 2},
+	}, result.WorkLogs)
+}
+
+func TestParseWorkLogs_CostCenter(t *testing.T) {
+	csv := "date,hours worked,employee id,job group,cost center\n" +
+		"14/11/2023,7.5,1,A,CC-100\n" +
+		"9/11/2023,4,2,B,\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.RowErrors)
+	assert.Equal(t, "CC-100", *result.WorkLogs[0].CostCenter)
+	assert.Nil(t, result.WorkLogs[1].CostCenter)
+}
+
+func TestParseWorkLogs_HeaderMismatch(t *testing.T) {
+	csv := "date,hours,employee id,job group\n" +
+		"14/11/2023,7.5,1,A\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.ErrorIs(t, err, handler.ErrCSVHeaderMismatch)
+	assert.Equal(t, []payroll.RowError{
+		{Line: 1, Column: "hours worked", Reason: "column not found in header"},
+	}, result.RowErrors)
+}
+
+func TestParseWorkLogs_MalformedQuotes(t *testing.T) {
+	csv := "date,hours worked,employee id,job group\n" +
+		"14/11/2023,7.5,\"1\"x,A\n" +
+		"14/11/2023,7.5,1,A\"\n" +
+		"9/11/2023,4,2,B\n"
+
+	result, err := handler.ParseWorkLogs(strings.NewReader(csv), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.RowsTotal)
+	assert.Len(t, result.WorkLogs, 1)
+	assert.Equal(t, []payroll.RowError{
+		{Line: 2, Column: "employee id", Reason: "extraneous or missing \" in quoted-field at position 18"},
+		{Line: 3, Column: "job group", Reason: "bare \" in non-quoted-field at position 19"},
+	}, result.RowErrors)
+}
+
+func TestParseWorkLogs_EmptyFile(t *testing.T) {
+	_, err := handler.ParseWorkLogs(strings.NewReader(""), handler.DefaultSchemaProfile(), jobGroups)
+
+	assert.Error(t, err)
+}
+
+func TestParseValidationPolicy(t *testing.T) {
+	policy, err := handler.ParseValidationPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, handler.PolicyReject, policy)
+
+	policy, err = handler.ParseValidationPolicy("import_valid")
+	assert.NoError(t, err)
+	assert.Equal(t, handler.PolicyImportValid, policy)
+
+	_, err = handler.ParseValidationPolicy("skip")
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// named date formats a csv profile can accept
+const (
+	DateISO8601      = "iso8601"
+	DateDayMonthYear = "dd/mm/yyyy"
+	DateMonthDayYear = "mm/dd/yyyy"
+	DateDayMonthDash = "dd-mm-yyyy"
+)
+
+var namedDateLayouts = map[string]string{
+	DateISO8601:      "2006-01-02",
+	DateDayMonthYear: "2/1/2006",
+	DateMonthDayYear: "1/2/2006",
+	DateDayMonthDash: "2-1-2006",
+}
+
+var ErrAmbiguousDate = errors.New("ambiguous date")
+
+// DateFormat is a date format accepted by a csv profile
+type DateFormat struct {
+	Name   string
+	Layout string
+}
+
+// dateLayoutTokens are the elements of a go time layout a date format can be made of, longest first so a token
+// isn't read as the start of a shorter one
+var dateLayoutTokens = []string{"January", "2006", "Jan", "01", "02", "_2", "06", "1", "2"}
+
+// NewDateFormat func returns the named date format, any other value is used as a go time layout,
+// which must contain a year, month and day and nothing but separators otherwise
+func NewDateFormat(s string) (DateFormat, error) {
+	name := strings.TrimSpace(s)
+	if layout, ok := namedDateLayouts[strings.ToLower(name)]; ok {
+		return DateFormat{
+			Name:   strings.ToLower(name),
+			Layout: layout,
+		}, nil
+	}
+
+	// a layout missing any of the date parts doesn't round trip a reference date
+	ref := time.Date(2023, time.November, 14, 0, 0, 0, 0, time.UTC)
+	if t, err := time.Parse(name, ref.Format(name)); name == "" || !isDateLayout(name) || err != nil || !t.Equal(ref) {
+		return DateFormat{}, fmt.Errorf("invalid date format %q", s)
+	}
+
+	return DateFormat{
+		Name:   name,
+		Layout: name,
+	}, nil
+}
+
+// isDateLayout func reports whether the layout is made of year, month and day elements and separators only, time
+// of day, zone and weekday elements are letters or digits left over
+func isDateLayout(layout string) bool {
+	for layout != "" {
+		token := ""
+		for _, t := range dateLayoutTokens {
+			if strings.HasPrefix(layout, t) {
+				token = t
+				break
+			}
+		}
+		if token == "" {
+			r, size := utf8.DecodeRuneInString(layout)
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return false
+			}
+			token = layout[:size]
+		}
+		layout = layout[len(token):]
+	}
+	return true
+}
+
+// ParseDate func parses the date with every accepted format. A date that's valid in more than one format,
+// and means a different day in each, is an error instead of a guess
+func ParseDate(s string, formats []DateFormat) (time.Time, error) {
+	var res time.Time
+	var matched []string
+
+	for _, format := range formats {
+		t, err := time.ParseInLocation(format.Layout, s, time.Local)
+		if err != nil {
+			continue
+		}
+		if len(matched) > 0 && !t.Equal(res) {
+			return time.Time{}, fmt.Errorf("%w, it's valid as both %s and %s", ErrAmbiguousDate, matched[0], format.Name)
+		}
+		res = t
+		matched = append(matched, format.Name)
+	}
+
+	if len(matched) == 0 {
+		names := make([]string, 0, len(formats))
+		for _, format := range formats {
+			names = append(names, format.Name)
+		}
+		return time.Time{}, fmt.Errorf("invalid date specified, expected %s", strings.Join(names, " or "))
+	}
+
+	return res, nil
+}
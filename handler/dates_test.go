@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func dateFormats(t *testing.T, names ...string) []handler.DateFormat {
+	formats := make([]handler.DateFormat, 0, len(names))
+	for _, name := range names {
+		format, err := handler.NewDateFormat(name)
+		assert.NoError(t, err)
+		formats = append(formats, format)
+	}
+	return formats
+}
+
+func TestParseDate(t *testing.T) {
+	formats := dateFormats(t, handler.DateISO8601, handler.DateDayMonthDash, handler.DateMonthDayYear)
+
+	for _, s := range []string{"2023-11-14", "14-11-2023", "11/14/2023"} {
+		date, err := handler.ParseDate(s, formats)
+		assert.NoError(t, err, s)
+		assert.Equal(t, time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local), date, s)
+	}
+}
+
+func TestParseDate_CalendarValidation(t *testing.T) {
+	_, err := handler.ParseDate("31/02/2023", dateFormats(t, handler.DateDayMonthYear))
+	assert.Error(t, err)
+
+	_, err = handler.ParseDate("2023-02-29", dateFormats(t, handler.DateISO8601))
+	assert.Error(t, err)
+
+	_, err = handler.ParseTime("31/02/2023")
+	assert.Error(t, err)
+}
+
+func TestParseDate_Ambiguous(t *testing.T) {
+	formats := dateFormats(t, handler.DateDayMonthYear, handler.DateMonthDayYear)
+
+	_, err := handler.ParseDate("05/11/2023", formats)
+	assert.ErrorIs(t, err, handler.ErrAmbiguousDate)
+
+	// same day in both formats
+	date, err := handler.ParseDate("05/05/2023", formats)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 5, 0, 0, 0, 0, time.Local), date)
+
+	date, err = handler.ParseDate("14/11/2023", formats)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local), date)
+}
+
+func TestNewDateFormat(t *testing.T) {
+	format, err := handler.NewDateFormat("02.01.2006")
+	assert.NoError(t, err)
+	assert.Equal(t, "02.01.2006", format.Layout)
+
+	_, err = handler.NewDateFormat("01/2006")
+	assert.Error(t, err)
+
+	_, err = handler.NewDateFormat("yyyy.mm.dd")
+	assert.Error(t, err)
+
+	format, err = handler.NewDateFormat("2 January 2006")
+	assert.NoError(t, err)
+	assert.Equal(t, "2 January 2006", format.Layout)
+
+	// layouts with time of day, zone or weekday elements round trip the reference date as well
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04:05Z07:00", "2006-01-02 MST", "Mon 02/01/2006", "2006-01-02 -0700", "2006.002"} {
+		_, err = handler.NewDateFormat(layout)
+		assert.Error(t, err, layout)
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/sirupsen/logrus"
+)
+
+// header names of the employee csv columns, only the id and name columns are required
+const (
+	EmployeeColumnId              = "employee id"
+	EmployeeColumnName            = "name"
+	EmployeeColumnStatus          = "status"
+	EmployeeColumnHireDate        = "hire date"
+	EmployeeColumnTerminationDate = "termination date"
+	EmployeeColumnJobGroup        = "default job group"
+	EmployeeColumnDepartment      = "department"
+	EmployeeColumnCostCenter      = "cost center"
+)
+
+var employeeColumns = []string{EmployeeColumnId, EmployeeColumnName, EmployeeColumnStatus, EmployeeColumnHireDate,
+	EmployeeColumnTerminationDate, EmployeeColumnJobGroup, EmployeeColumnDepartment, EmployeeColumnCostCenter}
+
+// EmployeeParseResult holds the valid employees of an employee csv along with the problems found in the invalid rows
+type EmployeeParseResult struct {
+	Employees []payroll.Employee
+	RowErrors []payroll.RowError
+	RowsTotal int
+}
+
+// ParseEmployeeImport func parses an employee csv and applies the validation policy, returning the reason
+// the file was rejected, if it was
+func ParseEmployeeImport(data []byte, jobGroups JobGroupResolver, policy ValidationPolicy) (EmployeeParseResult, string) {
+	parsed, err := ParseEmployees(bytes.NewReader(data), jobGroups)
+	if errors.Is(err, ErrCSVHeaderMismatch) {
+		return parsed, ErrEmployeeHeaderMismatchError
+	} else if err != nil {
+		logrus.Errorf("error reading employee file: %v", err)
+		return parsed, ErrEmployeeFileError
+	}
+
+	if len(parsed.RowErrors) > 0 && (policy == PolicyReject || len(parsed.Employees) == 0) {
+		return parsed, ErrCSVInvalidRows
+	}
+
+	return parsed, ""
+}
+
+// ParseEmployees func reads every row of an employee csv, eg. an HR system export, collecting errors for invalid
+// rows instead of stopping on the first one. Columns are matched by header name regardless of case, extra columns
+// are ignored, and dates are yyyy-mm-dd
+func ParseEmployees(r io.Reader, jobGroups JobGroupResolver) (EmployeeParseResult, error) {
+	result := EmployeeParseResult{
+		Employees: make([]payroll.Employee, 0),
+		RowErrors: make([]payroll.RowError, 0),
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return result, fmt.Errorf("error reading csv header: %v", err)
+	}
+
+	index := make(map[string]int, len(employeeColumns))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\xef\xbb\xbf")))
+		if _, ok := index[column]; !ok {
+			index[column] = i
+		}
+	}
+	for _, column := range []string{EmployeeColumnId, EmployeeColumnName} {
+		if _, ok := index[column]; !ok {
+			result.RowErrors = append(result.RowErrors, payroll.RowError{
+				Line:   1,
+				Column: column,
+				Reason: "column not found in header",
+			})
+		}
+	}
+	if len(result.RowErrors) > 0 {
+		return result, ErrCSVHeaderMismatch
+	}
+
+	lines := make(map[int]int)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		result.RowsTotal++
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.RowErrors = append(result.RowErrors, payroll.RowError{
+				Line:   parseErr.Line,
+				Reason: parseErr.Err.Error(),
+			})
+			continue
+		} else if err != nil {
+			return result, fmt.Errorf("error reading csv file: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		e, rowErrors := parseEmployeeRow(line, row, index, jobGroups)
+		if len(rowErrors) == 0 {
+			if first, ok := lines[e.Id]; ok {
+				rowErrors = append(rowErrors, payroll.RowError{
+					Line:   line,
+					Column: EmployeeColumnId,
+					Value:  strconv.Itoa(e.Id),
+					Reason: fmt.Sprintf("employee id already on line %d", first),
+				})
+			}
+		}
+		if len(rowErrors) > 0 {
+			result.RowErrors = append(result.RowErrors, rowErrors...)
+			continue
+		}
+
+		lines[e.Id] = line
+		result.Employees = append(result.Employees, e)
+	}
+
+	return result, nil
+}
+
+// parseEmployeeRow func converts a single csv row to an employee, returning an error for every invalid column.
+// Empty optional columns leave the detail unset
+func parseEmployeeRow(line int, row []string, index map[string]int, jobGroups JobGroupResolver) (payroll.Employee, []payroll.RowError) {
+	values := make(map[string]string, len(employeeColumns))
+	for _, column := range employeeColumns {
+		if i, ok := index[column]; ok && i < len(row) {
+			values[column] = strings.TrimSpace(row[i])
+		}
+	}
+
+	e := payroll.Employee{
+		Name:   values[EmployeeColumnName],
+		Status: payroll.EmployeeActive,
+	}
+	rowErrors := make([]payroll.RowError, 0)
+	newRowError := func(column, reason string) payroll.RowError {
+		return payroll.RowError{
+			Line:   line,
+			Column: column,
+			Value:  values[column],
+			Reason: reason,
+		}
+	}
+
+	id, err := strconv.ParseInt(values[EmployeeColumnId], 10, 32)
+	if err != nil || id < 1 {
+		rowErrors = append(rowErrors, newRowError(EmployeeColumnId, "employee id is not a positive integer"))
+	} else {
+		e.Id = int(id)
+	}
+
+	if e.Name == "" {
+		rowErrors = append(rowErrors, newRowError(EmployeeColumnName, "name is missing"))
+	}
+
+	if s := strings.ToLower(values[EmployeeColumnStatus]); s != "" {
+		e.Status = payroll.EmployeeStatus(s)
+		if e.Status != payroll.EmployeeActive && e.Status != payroll.EmployeeTerminated {
+			rowErrors = append(rowErrors, newRowError(EmployeeColumnStatus, "status is neither active nor terminated"))
+		}
+	}
+
+	parseDate := func(column string) *time.Time {
+		if values[column] == "" {
+			return nil
+		}
+		t, err := time.ParseInLocation(time.DateOnly, values[column], time.Local)
+		if err != nil {
+			rowErrors = append(rowErrors, newRowError(column, "invalid date specified, expected yyyy-mm-dd"))
+			return nil
+		}
+		return &t
+	}
+	e.HireDate = parseDate(EmployeeColumnHireDate)
+	e.TerminationDate = parseDate(EmployeeColumnTerminationDate)
+	if e.HireDate != nil && e.TerminationDate != nil && e.TerminationDate.Before(*e.HireDate) {
+		rowErrors = append(rowErrors, newRowError(EmployeeColumnTerminationDate, "termination date is before the hire date"))
+	}
+
+	if values[EmployeeColumnJobGroup] != "" {
+		group, err := jobGroups.Resolve(values[EmployeeColumnJobGroup])
+		if err != nil {
+			rowErrors = append(rowErrors, newRowError(EmployeeColumnJobGroup, err.Error()))
+		} else {
+			e.DefaultJobGroup = &group
+		}
+	}
+
+	if department := values[EmployeeColumnDepartment]; department != "" {
+		e.Department = &department
+	}
+	if costCenter := values[EmployeeColumnCostCenter]; costCenter != "" {
+		e.CostCenter = &costCenter
+	}
+
+	return e, rowErrors
+}
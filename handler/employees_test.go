@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEmployees(t *testing.T) {
+	csv := "Employee ID,Name,Department,Status,Hire Date,Termination Date,Default Job Group,Cost Center\n" +
+		"1,Ada Lovelace,R&D,active,2023-01-09,,A,CC-100\n" +
+		"2,Bob,Sales,terminated,2022-05-01,2023-10-31,,\n"
+
+	result, err := handler.ParseEmployees(strings.NewReader(csv), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.RowsTotal)
+	assert.Empty(t, result.RowErrors)
+	assert.Len(t, result.Employees, 2)
+
+	group := payroll.JobGroup("A")
+	assert.Equal(t, "Ada Lovelace", result.Employees[0].Name)
+	assert.Equal(t, &group, result.Employees[0].DefaultJobGroup)
+	assert.Equal(t, time.Date(2023, 1, 9, 0, 0, 0, 0, time.Local), *result.Employees[0].HireDate)
+	assert.Nil(t, result.Employees[0].TerminationDate)
+	assert.Equal(t, payroll.EmployeeTerminated, result.Employees[1].Status)
+	assert.Nil(t, result.Employees[1].DefaultJobGroup)
+	assert.Equal(t, "R&D", *result.Employees[0].Department)
+	assert.Equal(t, "CC-100", *result.Employees[0].CostCenter)
+	assert.Equal(t, "Sales", *result.Employees[1].Department)
+	assert.Nil(t, result.Employees[1].CostCenter)
+}
+
+func TestParseEmployees_CollectsRowErrors(t *testing.T) {
+	csv := "employee id,name,status,hire date,default job group\n" +
+		"1,Ada,active,2023-01-09,A\n" +
+		"x,,retired,09/01/2023,Z\n" +
+		"1,Ada again,,,\n"
+
+	result, err := handler.ParseEmployees(strings.NewReader(csv), jobGroups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.RowsTotal)
+	assert.Len(t, result.Employees, 1)
+	assert.Equal(t, []payroll.RowError{
+		{Line: 3, Column: "employee id", Value: "x", Reason: "employee id is not a positive integer"},
+		{Line: 3, Column: "name", Reason: "name is missing"},
+		{Line: 3, Column: "status", Value: "retired", Reason: "status is neither active nor terminated"},
+		{Line: 3, Column: "hire date", Value: "09/01/2023", Reason: "invalid date specified, expected yyyy-mm-dd"},
+		{Line: 3, Column: "default job group", Value: "Z", Reason: "unknown job group"},
+		{Line: 4, Column: "employee id", Value: "1", Reason: "employee id already on line 2"},
+	}, result.RowErrors)
+}
+
+func TestParseEmployeeImport(t *testing.T) {
+	_, rejection := handler.ParseEmployeeImport([]byte("id,full name\n1,Ada\n"), jobGroups, handler.PolicyReject)
+	assert.Equal(t, handler.ErrEmployeeHeaderMismatchError, rejection)
+
+	data := []byte("employee id,name\n1,Ada\n0,Bob\n")
+
+	_, rejection = handler.ParseEmployeeImport(data, jobGroups, handler.PolicyReject)
+	assert.Equal(t, handler.ErrCSVInvalidRows, rejection)
+
+	parsed, rejection := handler.ParseEmployeeImport(data, jobGroups, handler.PolicyImportValid)
+	assert.Empty(t, rejection)
+	assert.Len(t, parsed.Employees, 1)
+}
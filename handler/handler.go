@@ -1,10 +1,12 @@
 package handler
 
 import (
-	"encoding/csv"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,22 +14,64 @@ import (
 
 	"github.com/discord-gophers/goapi-gen/types"
 	openapi_types "github.com/discord-gophers/goapi-gen/types"
+	"github.com/go-chi/render"
 	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
+// defaultWorkLogsLimit is the number of work logs returned when the client doesn't set a limit, and the max limit
+const defaultWorkLogsLimit = 1000
+
+// defaultReportLimit is the number of employee reports returned when the client doesn't set a limit, and the max limit
+const defaultReportLimit = 1000
+
 type PayrollHandler struct {
 	payrollService PayrollService
+	cfg            Config
+}
+
+// Config holds the upload behaviour of the handler
+type Config struct {
+	// ValidationPolicy decides whether a file with invalid rows is rejected
+	// as a whole or only its valid rows are imported
+	ValidationPolicy ValidationPolicy
+	// SchemaProfiles are the csv layouts accepted by the upload endpoint
+	SchemaProfiles SchemaProfiles
+	// ReportIdPattern parses the report id from the filename when the client doesn't set it,
+	// nil makes the report id mandatory
+	ReportIdPattern *regexp.Regexp
+	// JobGroups is the normalization applied to csv job group values before they're validated
+	JobGroups JobGroupConfig
 }
 
-func NewPayrollHandler(payrollService PayrollService) PayrollHandler {
+func NewPayrollHandler(payrollService PayrollService, cfg Config) PayrollHandler {
 	return PayrollHandler{
 		payrollService: payrollService,
+		cfg:            cfg,
 	}
 }
 
-func (h PayrollHandler) GetReport(http.ResponseWriter, *http.Request) *Response {
-	report, err := h.payrollService.GetReport(1000, 0)
+func (h PayrollHandler) GetReport(w http.ResponseWriter, r *http.Request, params GetReportParams) *Response {
+	limit, offset := uint64(defaultReportLimit), uint64(0)
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > defaultReportLimit {
+			return GetReportJSON400Response(Error{
+				Message: ErrInvalidLimitError,
+			})
+		}
+		limit = uint64(*params.Limit)
+	}
+	if params.Offset != nil {
+		if *params.Offset < 0 {
+			return GetReportJSON400Response(Error{
+				Message: ErrInvalidOffsetError,
+			})
+		}
+		offset = uint64(*params.Offset)
+	}
+
+	report, err := h.payrollService.GetReport(limit, offset)
 	if err != nil {
 		logrus.Errorf("error while generating report: %v", err)
 		return GetReportJSON500Response(Error{})
@@ -36,7 +80,24 @@ func (h PayrollHandler) GetReport(http.ResponseWriter, *http.Request) *Response
 	return GetReportJSON200Response(ConvertReport(report))
 }
 
-func (h PayrollHandler) PostUpload(w http.ResponseWriter, r *http.Request) *Response {
+func (h PayrollHandler) GetReportCostCenters(w http.ResponseWriter, r *http.Request, params GetReportCostCentersParams) *Response {
+	from, to := convertOptionalDate(params.StartDate), convertOptionalDate(params.EndDate)
+	if from != nil && to != nil && to.Before(*from) {
+		return GetReportCostCentersJSON400Response(Error{
+			Message: ErrInvalidDateRangeError,
+		})
+	}
+
+	report, err := h.payrollService.GetCostReport(from, to)
+	if err != nil {
+		logrus.Errorf("error while generating cost report: %v", err)
+		return GetReportCostCentersJSON500Response(Error{})
+	}
+
+	return GetReportCostCentersJSON200Response(ConvertCostReport(report))
+}
+
+func (h PayrollHandler) PostUpload(w http.ResponseWriter, r *http.Request, params PostUploadParams) *Response {
 	// Parse the multipart form data
 	// 10 MB maximum file size
 	err := r.ParseMultipartForm(10 << 20)
@@ -57,171 +118,1737 @@ func (h PayrollHandler) PostUpload(w http.ResponseWriter, r *http.Request) *Resp
 	}
 	defer file.Close()
 
-	// get csv file id
-	filename := strings.ReplaceAll(handler.Filename, ".csv", "")
-	filenameParts := strings.Split(filename, "-")
-	if len(filenameParts) < 3 {
-		logrus.Errorf("error while parsing file name")
+	reportId, err := h.reportId(r.FormValue("report_id"), params.XReportID, handler.Filename)
+	if err != nil {
+		logrus.Errorf("error while reading report id: %v", err)
+		return PostUploadJSON400Response(Error{
+			Message: err.Error(),
+		})
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		logrus.Errorf("error reading CSV file: %v", err)
 		return PostUploadJSON400Response(Error{
 			Message: ErrCSVFileProcessingError,
 		})
 	}
 
-	filenameId, err := strconv.Atoi(filenameParts[2])
+	// the profile is resolved upfront, so a file no worker could read is rejected straight away
+	profile, err := h.schemaProfile(r.FormValue("profile"), HeaderLine(string(data)))
 	if err != nil {
 		logrus.Errorf("error reading CSV file: %v", err)
 		return PostUploadJSON400Response(Error{
+			Message: err.Error(),
+		})
+	}
+
+	amend, err := parseFormBool(r.FormValue("amend"))
+	if err != nil {
+		return PostUploadJSON400Response(Error{
+			Message: ErrInvalidAmendError,
+		})
+	}
+
+	adjustment, err := parseFormBool(r.FormValue("adjustment"))
+	if err != nil {
+		return PostUploadJSON400Response(Error{
+			Message: ErrInvalidAdjustmentError,
+		})
+	}
+
+	if dryRun, err := parseFormBool(r.FormValue("dry_run")); err != nil {
+		return PostUploadJSON400Response(Error{
+			Message: ErrInvalidDryRunError,
+		})
+	} else if dryRun {
+		return h.previewUpload(reportId, payroll.InsertOptions{Amend: amend, Adjustment: adjustment}, profile, data)
+	}
+
+	job, err := h.payrollService.EnqueueUpload(payroll.UploadJob{
+		ReportId:   reportId,
+		Amend:      amend,
+		Adjustment: adjustment,
+		Filename:   handler.Filename,
+		Profile:    profile.Name,
+		Payload:    data,
+	})
+	if err != nil {
+		logrus.Errorf("error while queueing upload: %v", err)
+		return PostUploadJSON500Response(Error{
 			Message: ErrCSVFileProcessingError,
 		})
 	}
 
-	// Create a CSV reader
-	reader := csv.NewReader(file)
+	return PostUploadJSON202Response(ConvertUploadJob(job))
+}
 
-	// Ignore header
-	reader.Read()
+func (h PayrollHandler) GetUploadsJobID(w http.ResponseWriter, r *http.Request, jobID string) *Response {
+	job, err := h.payrollService.GetUploadJob(jobID)
+	if errors.Is(err, payroll.ErrUploadNotFound) {
+		return GetUploadsJobIDJSON404Response(Error{
+			Message: ErrUploadNotFound,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching upload job: %v", err)
+		return GetUploadsJobIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
 
-	serviceWorkLogs := make([]payroll.WorkLog, 0)
-	// Process each row line by line
-	for {
-		row, err := reader.Read()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			logrus.Errorf("error reading CSV file: %v", err)
-			return PostUploadJSON400Response(Error{
-				Message: ErrCSVFileProcessingError,
-			})
-		}
+	return GetUploadsJobIDJSON200Response(ConvertUploadJob(job))
+}
 
-		if len(row) < 3 {
-			logrus.Errorf("error reading CSV file: no. of rows is less than 3")
-			return PostUploadJSON400Response(Error{
-				Message: ErrCSVFileProcessingError,
-			})
-		}
+func (h PayrollHandler) GetUploadsReportIDVersions(w http.ResponseWriter, r *http.Request, reportID int) *Response {
+	versions, err := h.payrollService.GetReportVersions(reportID)
+	if errors.Is(err, payroll.ErrReportNotFound) {
+		return GetUploadsReportIDVersionsJSON404Response(Error{
+			Message: ErrReportNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching report versions: %v", err)
+		return GetUploadsReportIDVersionsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
 
-		logDate, err := ParseTime(row[0])
-		if err != nil || logDate == nil {
-			logrus.Errorf("error reading CSV file: %v", err)
-			return PostUploadJSON400Response(Error{
-				Message: ErrCSVFileProcessingError,
-			})
-		}
+	return GetUploadsReportIDVersionsJSON200Response(ConvertReportVersions(reportID, versions))
+}
 
-		logHours, err := strconv.ParseFloat(row[1], 64)
-		if err != nil {
-			logrus.Errorf("error reading CSV file: %v", err)
-			return PostUploadJSON400Response(Error{
-				Message: ErrCSVFileProcessingError,
-			})
-		}
+func (h PayrollHandler) GetJobGroups(w http.ResponseWriter, r *http.Request) *Response {
+	rates, err := h.payrollService.GetJobGroupRates()
+	if err != nil {
+		logrus.Errorf("error while fetching job groups: %v", err)
+		return GetJobGroupsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
 
-		employeeID, err := strconv.ParseUint(row[2], 10, 64)
-		if err != nil {
-			logrus.Errorf("error reading CSV file: %v", err)
-			return PostUploadJSON400Response(Error{
-				Message: ErrCSVFileProcessingError,
-			})
-		}
+	res := JobGroups{
+		JobGroups: make([]JobGroup, 0, len(rates)),
+	}
+	for _, rate := range rates {
+		res.JobGroups = append(res.JobGroups, ConvertJobGroupRate(rate))
+	}
+	return GetJobGroupsJSON200Response(res)
+}
+
+func (h PayrollHandler) PostJobGroups(w http.ResponseWriter, r *http.Request) *Response {
+	var body PostJobGroupsJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostJobGroupsJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	bodyRate, err := decimal.NewFromString(body.Rate)
+	if err != nil {
+		return PostJobGroupsJSON400Response(Error{
+			Message: ErrInvalidJobGroupError,
+		})
+	}
+
+	rate, err := h.payrollService.CreateJobGroup(payroll.JobGroupRate{
+		JobGroup:      payroll.JobGroup(body.JobGroup),
+		Rate:          bodyRate,
+		EffectiveFrom: convertOptionalDate(body.EffectiveFrom),
+	})
+	if errors.Is(err, payroll.ErrInvalidJobGroup) || errors.Is(err, payroll.ErrInvalidRate) {
+		return PostJobGroupsJSON400Response(Error{
+			Message: ErrInvalidJobGroupError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupExists) {
+		return PostJobGroupsJSON409Response(Error{
+			Message: ErrJobGroupExistsError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while creating job group: %v", err)
+		return PostJobGroupsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PostJobGroupsJSON201Response(ConvertJobGroupRate(rate))
+}
+
+func (h PayrollHandler) GetJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response {
+	rate, err := h.payrollService.GetJobGroupRate(payroll.JobGroup(jobGroup))
+	if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return GetJobGroupsJobGroupJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching job group: %v", err)
+		return GetJobGroupsJobGroupJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
 
-		logJobGroup := ConvertWorkGroup(row[3])
+	return GetJobGroupsJobGroupJSON200Response(ConvertJobGroupRate(rate))
+}
 
-		serviceWorkLogs = append(serviceWorkLogs, payroll.WorkLog{
-			EmployeeId:  int(employeeID),
-			HoursLogged: logHours,
-			JobGroup:    logJobGroup,
-			Date:        *logDate,
+func (h PayrollHandler) PutJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response {
+	var body PutJobGroupsJobGroupJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PutJobGroupsJobGroupJSON400Response(Error{
+			Message: ErrInvalidJSONError,
 		})
 	}
 
-	err = h.payrollService.InsertLogs(filenameId, serviceWorkLogs)
+	bodyRate, err := decimal.NewFromString(body.Rate)
 	if err != nil {
-		logrus.Errorf("error while inserting logs: %v", err)
-		return PostUploadJSON500Response(Error{
-			Message: ErrCSVFileProcessingError,
+		return PutJobGroupsJobGroupJSON400Response(Error{
+			Message: ErrInvalidJobGroupError,
 		})
 	}
 
-	return PostUploadJSON200Response(Ok{
-		Message: MsgUploadSuccessful,
+	// the new rate is in force from today unless the change is scheduled
+	effectiveFrom := convertOptionalDate(body.EffectiveFrom)
+	if effectiveFrom == nil {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		effectiveFrom = &today
+	}
+
+	rate, err := h.payrollService.ChangeJobGroupRate(payroll.JobGroupRate{
+		JobGroup:      payroll.JobGroup(jobGroup),
+		Rate:          bodyRate,
+		EffectiveFrom: effectiveFrom,
 	})
+	if errors.Is(err, payroll.ErrInvalidJobGroup) || errors.Is(err, payroll.ErrInvalidRate) {
+		return PutJobGroupsJobGroupJSON400Response(Error{
+			Message: ErrInvalidJobGroupError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return PutJobGroupsJobGroupJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrInvalidEffectiveDate) {
+		return PutJobGroupsJobGroupJSON409Response(Error{
+			Message: ErrInvalidEffectiveDateError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while changing job group rate: %v", err)
+		return PutJobGroupsJobGroupJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PutJobGroupsJobGroupJSON200Response(ConvertJobGroupRate(rate))
 }
 
-func ConvertReport(r payroll.PayrollReport) PayrollReport {
-	empPayrolls := make([]WorkerPayrollBiWeek, 0, len(r.EmployeeReports))
+// GetJobGroupsJobGroupRates func returns every rate version of the job group, oldest first
+func (h PayrollHandler) GetJobGroupsJobGroupRates(w http.ResponseWriter, r *http.Request, jobGroup string) *Response {
+	rates, err := h.payrollService.GetJobGroupRateHistory(payroll.JobGroup(jobGroup))
+	if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return GetJobGroupsJobGroupRatesJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching job group rates: %v", err)
+		return GetJobGroupsJobGroupRatesJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
 
-	// sort by empId and payperiod
-	sort.Slice(r.EmployeeReports, func(i, j int) bool {
-		if r.EmployeeReports[i].EmployeeId < r.EmployeeReports[j].EmployeeId {
-			return true
-		} else if r.EmployeeReports[i].EmployeeId > r.EmployeeReports[j].EmployeeId {
-			return false
-		}
+	res := JobGroupRates{
+		JobGroup: jobGroup,
+		Rates:    make([]JobGroup, 0, len(rates)),
+	}
+	for _, rate := range rates {
+		res.Rates = append(res.Rates, ConvertJobGroupRate(rate))
+	}
+	return GetJobGroupsJobGroupRatesJSON200Response(res)
+}
 
-		return r.EmployeeReports[i].PayPeriod.StartDate.Before(r.EmployeeReports[j].PayPeriod.StartDate)
+func (h PayrollHandler) DeleteJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response {
+	err := h.payrollService.DeleteJobGroup(payroll.JobGroup(jobGroup))
+	if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return DeleteJobGroupsJobGroupJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupInUse) {
+		return DeleteJobGroupsJobGroupJSON409Response(Error{
+			Message: ErrJobGroupInUseError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting job group: %v", err)
+		return DeleteJobGroupsJobGroupJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return DeleteJobGroupsJobGroupJSON200Response(Ok{
+		Message: MsgJobGroupDeleted,
 	})
+}
 
-	for _, empReport := range r.EmployeeReports {
-		empPayrolls = append(empPayrolls, WorkerPayrollBiWeek{
-			AmountPaid: fmt.Sprintf("$%.2f", empReport.AmountPaid),
-			EmployeeID: uint64(empReport.EmployeeId),
-			PayPeriod: struct {
-				EndDate   *types.Date "json:\"end_date,omitempty\""
-				StartDate *types.Date "json:\"start_date,omitempty\""
-			}{
-				StartDate: ConvertDate(empReport.PayPeriod.StartDate),
-				EndDate:   ConvertDate(empReport.PayPeriod.EndDate),
-			},
+// GetRateOverrides func returns the rate overrides of the employee, or of every employee
+func (h PayrollHandler) GetRateOverrides(w http.ResponseWriter, r *http.Request, params GetRateOverridesParams) *Response {
+	overrides, err := h.payrollService.GetRateOverrides(params.EmployeeID)
+	if err != nil {
+		logrus.Errorf("error while fetching rate overrides: %v", err)
+		return GetRateOverridesJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
 		})
 	}
 
-	return PayrollReport{
-		EmployeeReports: empPayrolls,
+	res := RateOverrides{
+		RateOverrides: make([]RateOverride, 0, len(overrides)),
+	}
+	for _, o := range overrides {
+		res.RateOverrides = append(res.RateOverrides, ConvertRateOverride(o))
 	}
+	return GetRateOverridesJSON200Response(res)
 }
 
-// ConvertDate func converts internal time object to openapi object
-func ConvertDate(t time.Time) *openapi_types.Date {
-	return &openapi_types.Date{
-		Time: t,
+func (h PayrollHandler) PostRateOverrides(w http.ResponseWriter, r *http.Request) *Response {
+	var body PostRateOverridesJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostRateOverridesJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	bodyRate, err := decimal.NewFromString(body.Rate)
+	if err != nil {
+		return PostRateOverridesJSON400Response(Error{
+			Message: ErrInvalidRateOverrideError,
+		})
+	}
+
+	o := payroll.RateOverride{
+		EmployeeId:    body.EmployeeID,
+		Rate:          bodyRate,
+		EffectiveFrom: convertOptionalDate(body.EffectiveFrom),
+		EffectiveTo:   convertOptionalDate(body.EffectiveTo),
+	}
+	if body.JobGroup != nil {
+		group := payroll.JobGroup(*body.JobGroup)
+		o.JobGroup = &group
+	}
+
+	o, err = h.payrollService.CreateRateOverride(o)
+	if errors.Is(err, payroll.ErrInvalidRateOverride) || errors.Is(err, payroll.ErrInvalidRate) {
+		return PostRateOverridesJSON400Response(Error{
+			Message: ErrInvalidRateOverrideError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return PostRateOverridesJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrRateOverrideOverlaps) {
+		return PostRateOverridesJSON409Response(Error{
+			Message: ErrRateOverrideOverlapsError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while creating rate override: %v", err)
+		return PostRateOverridesJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PostRateOverridesJSON201Response(ConvertRateOverride(o))
+}
+
+func (h PayrollHandler) DeleteRateOverridesOverrideID(w http.ResponseWriter, r *http.Request, overrideID int) *Response {
+	err := h.payrollService.DeleteRateOverride(overrideID)
+	if errors.Is(err, payroll.ErrRateOverrideNotFound) {
+		return DeleteRateOverridesOverrideIDJSON404Response(Error{
+			Message: ErrRateOverrideNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting rate override: %v", err)
+		return DeleteRateOverridesOverrideIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
 	}
+
+	return DeleteRateOverridesOverrideIDJSON200Response(Ok{
+		Message: MsgRateOverrideDeleted,
+	})
 }
 
-// ConvertWorkGroup func converts internal job group object to openapi object
-func ConvertWorkGroup(s string) payroll.JobGroup {
-	logJobGroup := payroll.GroupA
-	if s == "B" {
-		logJobGroup = payroll.GroupB
+// GetPayScheduleAssignments func returns the pay schedule assignments of the employee, or of every employee
+func (h PayrollHandler) GetPayScheduleAssignments(w http.ResponseWriter, r *http.Request, params GetPayScheduleAssignmentsParams) *Response {
+	assignments, err := h.payrollService.GetScheduleAssignments(params.EmployeeID)
+	if err != nil {
+		logrus.Errorf("error while fetching pay schedule assignments: %v", err)
+		return GetPayScheduleAssignmentsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	res := ScheduleAssignments{
+		ScheduleAssignments: make([]ScheduleAssignment, 0, len(assignments)),
 	}
-	return logJobGroup
+	for _, a := range assignments {
+		res.ScheduleAssignments = append(res.ScheduleAssignments, ConvertScheduleAssignment(a))
+	}
+	return GetPayScheduleAssignmentsJSON200Response(res)
 }
 
-// ParseTime func converts time string to time object
-func ParseTime(s string) (*time.Time, error) {
-	parts := strings.Split(s, "/")
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid date specified")
+func (h PayrollHandler) PostPayScheduleAssignments(w http.ResponseWriter, r *http.Request) *Response {
+	var body PostPayScheduleAssignmentsJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostPayScheduleAssignmentsJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	a := payroll.ScheduleAssignment{
+		EmployeeId: body.EmployeeID,
+		Schedule:   body.Schedule,
+	}
+	if !body.EffectiveFrom.IsZero() {
+		a.EffectiveFrom = *convertOptionalDate(&body.EffectiveFrom)
+	}
+
+	a, err := h.payrollService.CreateScheduleAssignment(a)
+	if errors.Is(err, payroll.ErrInvalidScheduleAssignment) {
+		return PostPayScheduleAssignmentsJSON400Response(Error{
+			Message: ErrInvalidScheduleAssignmentError,
+		})
+	} else if errors.Is(err, payroll.ErrPayScheduleNotFound) {
+		return PostPayScheduleAssignmentsJSON404Response(Error{
+			Message: ErrPayScheduleNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrEmployeeNotFound) {
+		return PostPayScheduleAssignmentsJSON404Response(Error{
+			Message: ErrEmployeeNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrScheduleAssignmentExists) {
+		return PostPayScheduleAssignmentsJSON409Response(Error{
+			Message: ErrScheduleAssignmentExistsError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while creating pay schedule assignment: %v", err)
+		return PostPayScheduleAssignmentsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PostPayScheduleAssignmentsJSON201Response(ConvertScheduleAssignment(a))
+}
+
+func (h PayrollHandler) DeletePayScheduleAssignmentsAssignmentID(w http.ResponseWriter, r *http.Request, assignmentID int) *Response {
+	err := h.payrollService.DeleteScheduleAssignment(assignmentID)
+	if errors.Is(err, payroll.ErrScheduleAssignmentNotFound) {
+		return DeletePayScheduleAssignmentsAssignmentIDJSON404Response(Error{
+			Message: ErrScheduleAssignmentNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting pay schedule assignment: %v", err)
+		return DeletePayScheduleAssignmentsAssignmentIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
 	}
-	day, err := strconv.Atoi(parts[0])
+
+	return DeletePayScheduleAssignmentsAssignmentIDJSON200Response(Ok{
+		Message: MsgScheduleAssignmentDeleted,
+	})
+}
+
+func (h PayrollHandler) GetPayPeriods(w http.ResponseWriter, r *http.Request) *Response {
+	periods, err := h.payrollService.GetPeriods()
 	if err != nil {
-		return nil, fmt.Errorf("invalid date specified: %v", err)
+		logrus.Errorf("error while fetching pay periods: %v", err)
+		return GetPayPeriodsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	res := PayrollPeriods{
+		PayPeriods: make([]PayrollPeriod, 0, len(periods)),
+	}
+	for _, p := range periods {
+		res.PayPeriods = append(res.PayPeriods, ConvertPayrollPeriod(p))
+	}
+	return GetPayPeriodsJSON200Response(res)
+}
+
+func (h PayrollHandler) PostPayPeriods(w http.ResponseWriter, r *http.Request) *Response {
+	var body PostPayPeriodsJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostPayPeriodsJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	var p payroll.PayrollPeriod
+	if !body.StartDate.IsZero() && !body.EndDate.IsZero() {
+		p.StartDate, p.EndDate = *convertOptionalDate(&body.StartDate), *convertOptionalDate(&body.EndDate)
+	}
+
+	p, err := h.payrollService.CreatePeriod(p)
+	if errors.Is(err, payroll.ErrInvalidPeriod) {
+		return PostPayPeriodsJSON400Response(Error{
+			Message: ErrInvalidPeriodError,
+		})
+	} else if errors.Is(err, payroll.ErrPeriodOverlaps) {
+		return PostPayPeriodsJSON409Response(Error{
+			Message: ErrPeriodOverlapsError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while creating pay period: %v", err)
+		return PostPayPeriodsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PostPayPeriodsJSON201Response(ConvertPayrollPeriod(p))
+}
+
+func (h PayrollHandler) GetPayPeriodsPeriodID(w http.ResponseWriter, r *http.Request, periodID int) *Response {
+	p, err := h.payrollService.GetPeriod(periodID)
+	if errors.Is(err, payroll.ErrPeriodNotFound) {
+		return GetPayPeriodsPeriodIDJSON404Response(Error{
+			Message: ErrPeriodNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching pay period: %v", err)
+		return GetPayPeriodsPeriodIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return GetPayPeriodsPeriodIDJSON200Response(ConvertPayrollPeriod(p))
+}
+
+// PutPayPeriodsPeriodIDState func closes, pays or reopens the pay period
+func (h PayrollHandler) PutPayPeriodsPeriodIDState(w http.ResponseWriter, r *http.Request, periodID int) *Response {
+	var body PutPayPeriodsPeriodIDStateJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PutPayPeriodsPeriodIDStateJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
 	}
-	month, err := strconv.Atoi(parts[1])
+
+	state, err := payroll.ParsePeriodState(body.State.ToValue())
 	if err != nil {
-		return nil, fmt.Errorf("invalid date specified: %v", err)
+		return PutPayPeriodsPeriodIDStateJSON400Response(Error{
+			Message: ErrInvalidPeriodStateError,
+		})
+	}
+
+	p, err := h.payrollService.SetPeriodState(periodID, state)
+	if errors.Is(err, payroll.ErrPeriodNotFound) {
+		return PutPayPeriodsPeriodIDStateJSON404Response(Error{
+			Message: ErrPeriodNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrInvalidPeriodTransition) {
+		return PutPayPeriodsPeriodIDStateJSON409Response(Error{
+			Message: ErrInvalidPeriodTransitionError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while changing pay period state: %v", err)
+		return PutPayPeriodsPeriodIDStateJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PutPayPeriodsPeriodIDStateJSON200Response(ConvertPayrollPeriod(p))
+}
+
+func (h PayrollHandler) DeletePayPeriodsPeriodID(w http.ResponseWriter, r *http.Request, periodID int) *Response {
+	err := h.payrollService.DeletePeriod(periodID)
+	if errors.Is(err, payroll.ErrPeriodNotFound) {
+		return DeletePayPeriodsPeriodIDJSON404Response(Error{
+			Message: ErrPeriodNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrPeriodLocked) {
+		return DeletePayPeriodsPeriodIDJSON409Response(Error{
+			Message: ErrPeriodLockedError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting pay period: %v", err)
+		return DeletePayPeriodsPeriodIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
 	}
-	year, err := strconv.Atoi(parts[2])
+
+	return DeletePayPeriodsPeriodIDJSON200Response(Ok{
+		Message: MsgPeriodDeleted,
+	})
+}
+
+// GetEmployees func returns the registered employees, or only the ones of the status
+func (h PayrollHandler) GetEmployees(w http.ResponseWriter, r *http.Request, params GetEmployeesParams) *Response {
+	var status *payroll.EmployeeStatus
+	if params.Status != nil {
+		s := payroll.EmployeeStatus(*params.Status)
+		if s != payroll.EmployeeActive && s != payroll.EmployeeTerminated {
+			return GetEmployeesJSON400Response(Error{
+				Message: ErrInvalidEmployeeError,
+			})
+		}
+		status = &s
+	}
+
+	employees, err := h.payrollService.GetEmployees(status)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date specified: %v", err)
+		logrus.Errorf("error while fetching employees: %v", err)
+		return GetEmployeesJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
 	}
 
-	if day < 1 || day > 31 || month < 1 || month > 12 || len(parts[2]) != 4 {
-		return nil, fmt.Errorf("invalid date specified")
+	res := Employees{
+		Employees: make([]Employee, 0, len(employees)),
+	}
+	for _, e := range employees {
+		res.Employees = append(res.Employees, ConvertEmployee(e))
+	}
+	return GetEmployeesJSON200Response(res)
+}
+
+func (h PayrollHandler) PostEmployees(w http.ResponseWriter, r *http.Request) *Response {
+	var body PostEmployeesJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostEmployeesJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	e := convertEmployeeInput(body.ID, EmployeeInput{
+		Name:            body.Name,
+		DefaultJobGroup: body.DefaultJobGroup,
+		HireDate:        body.HireDate,
+		TerminationDate: body.TerminationDate,
+		Department:      body.Department,
+		CostCenter:      body.CostCenter,
+	})
+	if body.Status != nil {
+		e.Status = payroll.EmployeeStatus(body.Status.ToValue())
+	}
+
+	e, err := h.payrollService.CreateEmployee(e)
+	if errors.Is(err, payroll.ErrInvalidEmployee) {
+		return PostEmployeesJSON400Response(Error{
+			Message: ErrInvalidEmployeeError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return PostEmployeesJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrEmployeeExists) {
+		return PostEmployeesJSON409Response(Error{
+			Message: ErrEmployeeExistsError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while creating employee: %v", err)
+		return PostEmployeesJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
 	}
 
-	// TODO: Check error
-	t := time.Date(year, GetMonth(month), day, 0, 0, 0, 0, time.Local)
+	return PostEmployeesJSON201Response(ConvertEmployee(e))
+}
+
+// PostEmployeesImport func creates or updates the employees of a csv file, responding with the employees
+// created, updated and unchanged
+func (h PayrollHandler) PostEmployeesImport(w http.ResponseWriter, r *http.Request) *Response {
+	// 10 MB maximum file size
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		logrus.Errorf("error while parsing employee file: %v", err)
+		return PostEmployeesImportJSON400Response(Error{
+			Message: ErrEmployeeFileError,
+		})
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		logrus.Errorf("error while parsing employee file: %v", err)
+		return PostEmployeesImportJSON400Response(Error{
+			Message: ErrEmployeeFileError,
+		})
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		logrus.Errorf("error reading employee file: %v", err)
+		return PostEmployeesImportJSON400Response(Error{
+			Message: ErrEmployeeFileError,
+		})
+	}
+
+	jobGroups, err := h.payrollService.GetJobGroups()
+	if err != nil {
+		logrus.Errorf("error while fetching job groups: %v", err)
+		return PostEmployeesImportJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	parsed, rejection := ParseEmployeeImport(data, NewJobGroupResolver(jobGroups, h.cfg.JobGroups), h.cfg.ValidationPolicy)
+	res := EmployeeImport{
+		Message:      MsgEmployeeImportSuccessful,
+		RowsTotal:    parsed.RowsTotal,
+		RowsRejected: parsed.RowsTotal - len(parsed.Employees),
+		Errors:       ConvertRowErrors(parsed.RowErrors),
+		Created:      make([]int, 0),
+		Updated:      make([]int, 0),
+		Unchanged:    make([]int, 0),
+	}
+	if rejection != "" {
+		res.Message = rejection
+		res.RowsRejected = parsed.RowsTotal
+		return PostEmployeesImportJSON422Response(res)
+	}
+
+	imported, err := h.payrollService.ImportEmployees(parsed.Employees)
+	if errors.Is(err, payroll.ErrInvalidEmployee) {
+		return PostEmployeesImportJSON400Response(Error{
+			Message: ErrInvalidEmployeeError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return PostEmployeesImportJSON400Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while importing employees: %v", err)
+		return PostEmployeesImportJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	if len(parsed.RowErrors) > 0 {
+		res.Message = MsgEmployeeImportPartial
+	}
+	res.RowsImported = len(parsed.Employees)
+	res.Created, res.Updated, res.Unchanged = imported.Created, imported.Updated, imported.Unchanged
+	return PostEmployeesImportJSON200Response(res)
+}
+
+func (h PayrollHandler) GetEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response {
+	e, err := h.payrollService.GetEmployee(employeeID)
+	if errors.Is(err, payroll.ErrEmployeeNotFound) {
+		return GetEmployeesEmployeeIDJSON404Response(Error{
+			Message: ErrEmployeeNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching employee: %v", err)
+		return GetEmployeesEmployeeIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return GetEmployeesEmployeeIDJSON200Response(ConvertEmployee(e))
+}
+
+// PutEmployeesEmployeeID func replaces the details of the employee
+func (h PayrollHandler) PutEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response {
+	var body PutEmployeesEmployeeIDJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PutEmployeesEmployeeIDJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	e, err := h.payrollService.UpdateEmployee(convertEmployeeInput(employeeID, EmployeeInput(body)))
+	if errors.Is(err, payroll.ErrInvalidEmployee) {
+		return PutEmployeesEmployeeIDJSON400Response(Error{
+			Message: ErrInvalidEmployeeError,
+		})
+	} else if errors.Is(err, payroll.ErrEmployeeNotFound) {
+		return PutEmployeesEmployeeIDJSON404Response(Error{
+			Message: ErrEmployeeNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrJobGroupNotFound) {
+		return PutEmployeesEmployeeIDJSON404Response(Error{
+			Message: ErrJobGroupNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while updating employee: %v", err)
+		return PutEmployeesEmployeeIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PutEmployeesEmployeeIDJSON200Response(ConvertEmployee(e))
+}
+
+func (h PayrollHandler) DeleteEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response {
+	err := h.payrollService.DeleteEmployee(employeeID)
+	if errors.Is(err, payroll.ErrEmployeeNotFound) {
+		return DeleteEmployeesEmployeeIDJSON404Response(Error{
+			Message: ErrEmployeeNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting employee: %v", err)
+		return DeleteEmployeesEmployeeIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return DeleteEmployeesEmployeeIDJSON200Response(Ok{
+		Message: MsgEmployeeDeleted,
+	})
+}
+
+func (h PayrollHandler) GetHolidayCalendars(w http.ResponseWriter, r *http.Request) *Response {
+	calendars, err := h.payrollService.GetHolidayCalendars()
+	if err != nil {
+		logrus.Errorf("error while fetching holiday calendars: %v", err)
+		return GetHolidayCalendarsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	res := HolidayCalendars{
+		Calendars: make([]HolidayCalendarSummary, 0, len(calendars)),
+	}
+	for _, c := range calendars {
+		res.Calendars = append(res.Calendars, HolidayCalendarSummary{
+			Name:     c.Name,
+			Holidays: c.Holidays,
+		})
+	}
+	return GetHolidayCalendarsJSON200Response(res)
+}
+
+func (h PayrollHandler) GetHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response {
+	holidays, err := h.payrollService.GetHolidays(calendar)
+	if errors.Is(err, payroll.ErrCalendarNotFound) {
+		return GetHolidayCalendarsCalendarJSON404Response(Error{
+			Message: ErrCalendarNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while fetching holidays: %v", err)
+		return GetHolidayCalendarsCalendarJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return GetHolidayCalendarsCalendarJSON200Response(ConvertHolidayCalendar(calendar, holidays))
+}
+
+// PutHolidayCalendarsCalendar func imports an ical or csv file, replacing every holiday of the calendar
+func (h PayrollHandler) PutHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response {
+	// 10 MB maximum file size
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		logrus.Errorf("error while parsing holiday file: %v", err)
+		return PutHolidayCalendarsCalendarJSON400Response(Error{
+			Message: ErrHolidayFileError,
+		})
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		logrus.Errorf("error while parsing holiday file: %v", err)
+		return PutHolidayCalendarsCalendarJSON400Response(Error{
+			Message: ErrHolidayFileError,
+		})
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		logrus.Errorf("error reading holiday file: %v", err)
+		return PutHolidayCalendarsCalendarJSON400Response(Error{
+			Message: ErrHolidayFileError,
+		})
+	}
+
+	holidays, err := ParseHolidays(data)
+	if err != nil {
+		return PutHolidayCalendarsCalendarJSON400Response(Error{
+			Message: err.Error(),
+		})
+	}
+
+	holidays, err = h.payrollService.ImportHolidays(calendar, holidays)
+	if errors.Is(err, payroll.ErrInvalidHoliday) {
+		return PutHolidayCalendarsCalendarJSON400Response(Error{
+			Message: ErrInvalidHolidayError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while importing holidays: %v", err)
+		return PutHolidayCalendarsCalendarJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PutHolidayCalendarsCalendarJSON200Response(ConvertHolidayCalendar(calendar, holidays))
+}
+
+func (h PayrollHandler) DeleteHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response {
+	err := h.payrollService.DeleteHolidayCalendar(calendar)
+	if errors.Is(err, payroll.ErrCalendarNotFound) {
+		return DeleteHolidayCalendarsCalendarJSON404Response(Error{
+			Message: ErrCalendarNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting holiday calendar: %v", err)
+		return DeleteHolidayCalendarsCalendarJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return DeleteHolidayCalendarsCalendarJSON200Response(Ok{
+		Message: MsgCalendarDeleted,
+	})
+}
+
+func (h PayrollHandler) PostHolidayCalendarsCalendarHolidays(w http.ResponseWriter, r *http.Request, calendar string) *Response {
+	var body PostHolidayCalendarsCalendarHolidaysJSONRequestBody
+	if err := render.Bind(r, &body); err != nil {
+		return PostHolidayCalendarsCalendarHolidaysJSON400Response(Error{
+			Message: ErrInvalidJSONError,
+		})
+	}
+
+	holiday, err := h.payrollService.SaveHoliday(payroll.Holiday{
+		Calendar: calendar,
+		Date:     *convertOptionalDate(&body.Date),
+		Name:     body.Name,
+	})
+	if errors.Is(err, payroll.ErrInvalidHoliday) {
+		return PostHolidayCalendarsCalendarHolidaysJSON400Response(Error{
+			Message: ErrInvalidHolidayError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while saving holiday: %v", err)
+		return PostHolidayCalendarsCalendarHolidaysJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return PostHolidayCalendarsCalendarHolidaysJSON201Response(ConvertHoliday(holiday))
+}
+
+func (h PayrollHandler) DeleteHolidayCalendarsCalendarHolidaysDate(w http.ResponseWriter, r *http.Request, calendar string, date types.Date) *Response {
+	err := h.payrollService.DeleteHoliday(calendar, *convertOptionalDate(&date))
+	if errors.Is(err, payroll.ErrHolidayNotFound) {
+		return DeleteHolidayCalendarsCalendarHolidaysDateJSON404Response(Error{
+			Message: ErrHolidayNotFoundError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting holiday: %v", err)
+		return DeleteHolidayCalendarsCalendarHolidaysDateJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return DeleteHolidayCalendarsCalendarHolidaysDateJSON200Response(Ok{
+		Message: MsgHolidayDeleted,
+	})
+}
+
+// GetWorklogs func returns the work logs matching the filters with the report and csv line they came from
+func (h PayrollHandler) GetWorklogs(w http.ResponseWriter, r *http.Request, params GetWorklogsParams) *Response {
+	filter := payroll.WorkLogFilter{
+		EmployeeId: params.EmployeeID,
+		ReportId:   params.ReportID,
+		Limit:      defaultWorkLogsLimit,
+	}
+	if params.StartDate != nil {
+		filter.StartDate = &params.StartDate.Time
+	}
+	if params.EndDate != nil {
+		filter.EndDate = &params.EndDate.Time
+	}
+	if params.IncludeRetired != nil {
+		filter.IncludeRetired = *params.IncludeRetired
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > defaultWorkLogsLimit {
+			return GetWorklogsJSON400Response(Error{
+				Message: ErrInvalidLimitError,
+			})
+		}
+		filter.Limit = uint64(*params.Limit)
+	}
+	if params.Offset != nil {
+		if *params.Offset < 0 {
+			return GetWorklogsJSON400Response(Error{
+				Message: ErrInvalidOffsetError,
+			})
+		}
+		filter.Offset = uint64(*params.Offset)
+	}
+
+	logs, err := h.payrollService.GetWorkLogs(filter)
+	if err != nil {
+		logrus.Errorf("error while fetching work logs: %v", err)
+		return GetWorklogsJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return GetWorklogsJSON200Response(ConvertWorkLogs(logs))
+}
+
+// DeleteUploadsReportID func rolls back an upload by deleting the report, its versions and work logs
+func (h PayrollHandler) DeleteUploadsReportID(w http.ResponseWriter, r *http.Request, reportID int, params DeleteUploadsReportIDParams) *Response {
+	actor := strings.TrimSpace(params.XActor)
+	if actor == "" {
+		return DeleteUploadsReportIDJSON400Response(Error{
+			Message: ErrMissingActorError,
+		})
+	}
+
+	deletion, err := h.payrollService.DeleteReport(reportID, actor)
+	if errors.Is(err, payroll.ErrReportNotFound) {
+		return DeleteUploadsReportIDJSON404Response(Error{
+			Message: ErrReportNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrReportPeriodClosed) {
+		return DeleteUploadsReportIDJSON409Response(Error{
+			Message: ErrReportPeriodClosedError,
+		})
+	} else if err != nil {
+		logrus.Errorf("error while deleting report: %v", err)
+		return DeleteUploadsReportIDJSON500Response(Error{
+			Message: ErrHTTPInternalServerError,
+		})
+	}
+
+	return DeleteUploadsReportIDJSON200Response(ConvertReportDeletion(deletion))
+}
+
+// ProcessUpload func parses and imports the file of a queued upload job, it's run by the upload workers
+func (h PayrollHandler) ProcessUpload(job payroll.UploadJob) payroll.UploadJob {
+	job.Status = payroll.UploadFailed
+
+	profile, ok := h.cfg.SchemaProfiles.Get(job.Profile)
+	if !ok {
+		job.Message = ErrCSVUnknownProfileError
+		return job
+	}
+
+	parsed, rejection := h.parseUpload(profile, job.Payload)
+	job.RowsTotal = parsed.RowsTotal
+	job.RowsRejected = parsed.RowsTotal - len(parsed.WorkLogs)
+	job.Errors = parsed.RowErrors
+	if rejection != "" {
+		job.Message = rejection
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	}
+
+	res, err := h.payrollService.InsertLogs(job.ReportId, parsed.WorkLogs, payroll.InsertOptions{
+		Amend:      job.Amend,
+		UploadedTs: job.CreatedTs,
+		Adjustment: job.Adjustment,
+		Job:        &job,
+	})
+	job.Duplicates = res.Duplicates
+	job.EmployeeIssues = res.EmployeeIssues
+	if errors.Is(err, payroll.ErrUploadReclaimed) {
+		// the worker that claimed the job since imports it, this result is dropped when it's finished
+		job.Message = ErrCSVFileProcessingError
+		return job
+	} else if errors.Is(err, payroll.ErrFileIdExists) {
+		job.Message = ErrCSVFileAlreadyProcessedError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrReportNotFound) {
+		job.Message = ErrAmendedReportNotFoundError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrDuplicateLogs) {
+		job.Message = ErrDuplicateLogsError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrEmployeeLogs) {
+		job.Message = ErrEmployeeLogsError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrClosedPeriodLogs) {
+		job.Message = ErrClosedPeriodLogsError
+		job.Errors = append(job.Errors, res.ClosedPeriodLogs...)
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrOverlappingPeriodLogs) {
+		job.Message = ErrOverlappingPeriodLogsError
+		job.Errors = append(job.Errors, res.OverlappingPeriodLogs...)
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if errors.Is(err, payroll.ErrReportPeriodClosed) {
+		job.Message = ErrReportPeriodClosedError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	} else if err != nil {
+		logrus.Errorf("error while inserting logs: %v", err)
+		job.Message = ErrCSVFileProcessingError
+		job.RowsRejected = parsed.RowsTotal
+		return job
+	}
+
+	job.Status = payroll.UploadSucceeded
+	job.ReportVersion = res.ReportVersion
+	job.RowsImported = res.Inserted
+	job.Message = MsgUploadSuccessful
+	if len(parsed.RowErrors) > 0 {
+		job.Message = MsgUploadPartiallySuccessful
+	} else if len(res.Duplicates) > 0 {
+		job.Message = MsgUploadDuplicatesFound
+	} else if len(res.EmployeeIssues) > 0 {
+		job.Message = MsgUploadEmployeeIssuesFound
+	}
+
+	return job
+}
+
+// previewUpload func runs the upload synchronously without saving it, and responds with its effect on the report
+func (h PayrollHandler) previewUpload(reportId int, opts payroll.InsertOptions, profile SchemaProfile, data []byte) *Response {
+	parsed, rejection := h.parseUpload(profile, data)
+	preview := UploadPreview{
+		Message:        MsgUploadPreview,
+		RowsTotal:      parsed.RowsTotal,
+		RowsValid:      len(parsed.WorkLogs),
+		RowsRejected:   parsed.RowsTotal - len(parsed.WorkLogs),
+		Errors:         ConvertRowErrors(parsed.RowErrors),
+		Changes:        make([]ReportChange, 0),
+		Duplicates:     make([]Duplicate, 0),
+		EmployeeIssues: make([]EmployeeIssue, 0),
+	}
+	if rejection != "" {
+		preview.Message = rejection
+		return PostUploadJSON422Response(preview)
+	}
+
+	reportPreview, err := h.payrollService.PreviewLogs(reportId, parsed.WorkLogs, opts)
+	if errors.Is(err, payroll.ErrFileIdExists) {
+		return PostUploadJSON409Response(Error{
+			Message: ErrCSVFileAlreadyProcessedError,
+		})
+	} else if errors.Is(err, payroll.ErrReportNotFound) {
+		return PostUploadJSON409Response(Error{
+			Message: ErrAmendedReportNotFoundError,
+		})
+	} else if errors.Is(err, payroll.ErrReportPeriodClosed) {
+		return PostUploadJSON409Response(Error{
+			Message: ErrReportPeriodClosedError,
+		})
+	} else if errors.Is(err, payroll.ErrDuplicateLogs) {
+		preview.Message = ErrDuplicateLogsError
+		preview.Duplicates = ConvertDuplicates(reportPreview.Duplicates)
+		return PostUploadJSON422Response(preview)
+	} else if errors.Is(err, payroll.ErrEmployeeLogs) {
+		preview.Message = ErrEmployeeLogsError
+		preview.Duplicates = ConvertDuplicates(reportPreview.Duplicates)
+		preview.EmployeeIssues = ConvertEmployeeIssues(reportPreview.EmployeeIssues)
+		return PostUploadJSON422Response(preview)
+	} else if errors.Is(err, payroll.ErrClosedPeriodLogs) {
+		preview.Message = ErrClosedPeriodLogsError
+		preview.Errors = append(preview.Errors, ConvertRowErrors(reportPreview.ClosedPeriodLogs)...)
+		preview.Duplicates = ConvertDuplicates(reportPreview.Duplicates)
+		preview.EmployeeIssues = ConvertEmployeeIssues(reportPreview.EmployeeIssues)
+		return PostUploadJSON422Response(preview)
+	} else if errors.Is(err, payroll.ErrOverlappingPeriodLogs) {
+		preview.Message = ErrOverlappingPeriodLogsError
+		preview.Errors = append(preview.Errors, ConvertRowErrors(reportPreview.OverlappingPeriodLogs)...)
+		preview.Duplicates = ConvertDuplicates(reportPreview.Duplicates)
+		preview.EmployeeIssues = ConvertEmployeeIssues(reportPreview.EmployeeIssues)
+		return PostUploadJSON422Response(preview)
+	} else if err != nil {
+		logrus.Errorf("error while previewing logs: %v", err)
+		return PostUploadJSON500Response(Error{
+			Message: ErrCSVFileProcessingError,
+		})
+	}
+
+	preview.ReportVersion = &reportPreview.ReportVersion
+	preview.Changes = ConvertReportChanges(reportPreview.Changes)
+	preview.Duplicates = ConvertDuplicates(reportPreview.Duplicates)
+	preview.EmployeeIssues = ConvertEmployeeIssues(reportPreview.EmployeeIssues)
+	return PostUploadJSON200Response(preview)
+}
+
+// parseUpload func parses the file and applies the validation policy, returning the reason
+// the file was rejected, if it was
+func (h PayrollHandler) parseUpload(profile SchemaProfile, data []byte) (ParseResult, string) {
+	// job groups are read for every upload, so groups added since startup are accepted
+	jobGroups, err := h.payrollService.GetJobGroups()
+	if err != nil {
+		logrus.Errorf("error while fetching job groups: %v", err)
+		return ParseResult{}, ErrCSVFileProcessingError
+	}
+
+	parsed, err := ParseWorkLogs(bytes.NewReader(data), profile, NewJobGroupResolver(jobGroups, h.cfg.JobGroups))
+	if errors.Is(err, ErrCSVHeaderMismatch) {
+		return parsed, ErrCSVHeaderMismatchError
+	} else if err != nil {
+		logrus.Errorf("error reading CSV file: %v", err)
+		return parsed, ErrCSVFileProcessingError
+	}
+
+	if len(parsed.RowErrors) > 0 && (h.cfg.ValidationPolicy == PolicyReject || len(parsed.WorkLogs) == 0) {
+		return parsed, ErrCSVInvalidRows
+	}
+
+	return parsed, ""
+}
+
+// reportId func returns the report id set in the form field or header, falling back to parsing it
+// from the filename with the configured pattern
+func (h PayrollHandler) reportId(formValue string, headerValue *int, filename string) (int, error) {
+	if formValue != "" {
+		id, err := strconv.Atoi(formValue)
+		if err != nil || id < 1 {
+			return 0, fmt.Errorf(ErrInvalidReportIdError)
+		}
+		if headerValue != nil && *headerValue != id {
+			return 0, fmt.Errorf(ErrReportIdMismatchError)
+		}
+		return id, nil
+	}
+
+	if headerValue != nil {
+		if *headerValue < 1 {
+			return 0, fmt.Errorf(ErrInvalidReportIdError)
+		}
+		return *headerValue, nil
+	}
+
+	if h.cfg.ReportIdPattern == nil {
+		return 0, fmt.Errorf(ErrMissingReportIdError)
+	}
+
+	id, err := ParseReportId(h.cfg.ReportIdPattern, filename)
+	if err != nil {
+		return 0, fmt.Errorf(ErrMissingReportIdError)
+	}
+	return id, nil
+}
+
+// schemaProfile func returns the profile requested by the client, or detects it from the header line
+func (h PayrollHandler) schemaProfile(name string, headerLine string) (SchemaProfile, error) {
+	if name != "" {
+		profile, ok := h.cfg.SchemaProfiles.Get(name)
+		if !ok {
+			return profile, fmt.Errorf(ErrCSVUnknownProfileError)
+		}
+		return profile, nil
+	}
+
+	profile, ok := h.cfg.SchemaProfiles.Detect(headerLine)
+	if !ok {
+		return profile, fmt.Errorf(ErrCSVNoProfileMatchError)
+	}
+	return profile, nil
+}
+
+func ConvertReport(r payroll.PayrollReport) PayrollReport {
+	empPayrolls := make([]WorkerPayrollBiWeek, 0, len(r.EmployeeReports))
+
+	// sort by empId and payperiod
+	sort.Slice(r.EmployeeReports, func(i, j int) bool {
+		if r.EmployeeReports[i].EmployeeId < r.EmployeeReports[j].EmployeeId {
+			return true
+		} else if r.EmployeeReports[i].EmployeeId > r.EmployeeReports[j].EmployeeId {
+			return false
+		}
+
+		return r.EmployeeReports[i].PayPeriod.StartDate.Before(r.EmployeeReports[j].PayPeriod.StartDate)
+	})
+
+	for _, empReport := range r.EmployeeReports {
+		empPayrolls = append(empPayrolls, WorkerPayrollBiWeek{
+			AmountPaid: FormatAmount(empReport.AmountPaid),
+			EmployeeID: uint64(empReport.EmployeeId),
+			PayPeriod: struct {
+				EndDate   *types.Date "json:\"end_date,omitempty\""
+				StartDate *types.Date "json:\"start_date,omitempty\""
+			}{
+				StartDate: ConvertDate(empReport.PayPeriod.StartDate),
+				EndDate:   ConvertDate(empReport.PayPeriod.EndDate),
+			},
+			LineItems:   ConvertLineItems(empReport.LineItems),
+			Buckets:     ConvertBucketTotals(empReport.Buckets),
+			Premiums:    ConvertPremiumLines(empReport.Premiums),
+			Adjustments: ConvertAdjustments(empReport.Adjustments),
+		})
+	}
+
+	return PayrollReport{
+		EmployeeReports: empPayrolls,
+	}
+}
+
+// ConvertCostReport func converts internal cost report object to openapi object
+func ConvertCostReport(r payroll.CostReport) CostReport {
+	res := CostReport{
+		CostLines: make([]CostLine, 0, len(r.CostLines)),
+	}
+	for _, l := range r.CostLines {
+		res.CostLines = append(res.CostLines, CostLine{
+			CostCenter: l.CostCenter,
+			Department: l.Department,
+			JobGroup:   string(l.JobGroup),
+			PayPeriod: PayPeriod{
+				StartDate: ConvertDate(l.PayPeriod.StartDate),
+				EndDate:   ConvertDate(l.PayPeriod.EndDate),
+			},
+			Employees:  l.Employees,
+			Hours:      l.Hours.String(),
+			AmountPaid: FormatAmount(l.AmountPaid),
+		})
+	}
+	return res
+}
+
+// ConvertLineItems func converts internal line item objects to openapi objects
+func ConvertLineItems(items []payroll.LineItem) []LineItem {
+	res := make([]LineItem, 0, len(items))
+	for _, item := range items {
+		var source LineItemRateSource
+		if err := source.FromValue(string(item.Source)); err != nil {
+			source = UnknownLineItemRateSource
+		}
+		var bucket LineItemBucket
+		if err := bucket.FromValue(string(item.Bucket)); err != nil {
+			bucket = UnknownLineItemBucket
+		}
+
+		res = append(res, LineItem{
+			JobGroup:    string(item.JobGroup),
+			RateSource:  source,
+			RateVersion: item.RateVersion,
+			Rate:        FormatRate(item.Rate),
+			Bucket:      bucket,
+			Multiplier:  item.Multiplier.String(),
+			Hours:       item.Hours.String(),
+			Amount:      FormatAmount(item.Amount),
+		})
+		if item.OverrideId != 0 {
+			id := item.OverrideId
+			res[len(res)-1].OverrideID = &id
+		}
+	}
+	return res
+}
+
+// ConvertAdjustments func converts internal adjustment objects to openapi objects
+func ConvertAdjustments(adjustments []payroll.Adjustment) []Adjustment {
+	res := make([]Adjustment, 0, len(adjustments))
+	for _, a := range adjustments {
+		res = append(res, Adjustment{
+			OriginalPayPeriod: PayPeriod{
+				StartDate: ConvertDate(a.PayPeriod.StartDate),
+				EndDate:   ConvertDate(a.PayPeriod.EndDate),
+			},
+			LineItems: ConvertLineItems(a.LineItems),
+			Premiums:  ConvertPremiumLines(a.Premiums),
+			Amount:    FormatAmount(a.Amount),
+		})
+	}
+	return res
+}
+
+// ConvertBucketTotals func converts internal bucket total objects to openapi objects
+func ConvertBucketTotals(totals []payroll.BucketTotal) []BucketTotal {
+	res := make([]BucketTotal, 0, len(totals))
+	for _, t := range totals {
+		var bucket BucketTotalBucket
+		if err := bucket.FromValue(string(t.Bucket)); err != nil {
+			bucket = UnknownBucketTotalBucket
+		}
+
+		res = append(res, BucketTotal{
+			Bucket: bucket,
+			Hours:  t.Hours.String(),
+			Amount: FormatAmount(t.Amount),
+		})
+	}
+	return res
+}
+
+// ConvertReportChanges func converts internal report change objects to openapi objects
+func ConvertReportChanges(changes []payroll.ReportChange) []ReportChange {
+	res := make([]ReportChange, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, ReportChange{
+			EmployeeID:   uint64(c.EmployeeId),
+			AmountBefore: FormatAmount(c.AmountBefore),
+			AmountAfter:  FormatAmount(c.AmountAfter),
+			Difference:   FormatAmount(c.Difference),
+			PayPeriod: PayPeriod{
+				StartDate: ConvertDate(c.PayPeriod.StartDate),
+				EndDate:   ConvertDate(c.PayPeriod.EndDate),
+			},
+		})
+	}
+	return res
+}
+
+// FormatAmount func formats an amount as dollars, eg. `$10.50` or `-$10.50`. Amounts with fractions of
+// a cent, ie. not rounded yet, are formatted exactly, eg. `$10.125`
+func FormatAmount(amount decimal.Decimal) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign, amount = "-", amount.Neg()
+	}
+	if amount.Equal(amount.Truncate(2)) {
+		return sign + "$" + amount.StringFixed(2)
+	}
+	return sign + "$" + amount.String()
+}
+
+// FormatRate func formats an hourly rate as a plain decimal, eg. `20.50`. Like amounts, rates with fractions
+// of a cent are formatted exactly, eg. `20.005`
+func FormatRate(rate decimal.Decimal) string {
+	if rate.Equal(rate.Truncate(2)) {
+		return rate.StringFixed(2)
+	}
+	return rate.String()
+}
+
+// parseFormBool func parses an optional boolean form value
+func parseFormBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+// ConvertUploadJob func converts internal upload job object to openapi object
+func ConvertUploadJob(j payroll.UploadJob) UploadJob {
+	var status UploadJobStatus
+	if err := status.FromValue(string(j.Status)); err != nil {
+		status = UnknownUploadJobStatus
+	}
+
+	return UploadJob{
+		ID:             j.Id,
+		ReportID:       j.ReportId,
+		ReportVersion:  j.ReportVersion,
+		Amend:          j.Amend,
+		Adjustment:     j.Adjustment,
+		Filename:       j.Filename,
+		Status:         status,
+		Message:        j.Message,
+		RowsTotal:      j.RowsTotal,
+		RowsImported:   j.RowsImported,
+		RowsRejected:   j.RowsRejected,
+		Errors:         ConvertRowErrors(j.Errors),
+		Duplicates:     ConvertDuplicates(j.Duplicates),
+		EmployeeIssues: ConvertEmployeeIssues(j.EmployeeIssues),
+		CreatedAt:      j.CreatedTs,
+		UpdatedAt:      j.UpdatedTs,
+	}
+}
+
+// ConvertReportVersions func converts internal report version objects to openapi object
+func ConvertReportVersions(reportId int, versions []payroll.ReportVersion) ReportVersions {
+	res := ReportVersions{
+		ReportID: reportId,
+		Versions: make([]ReportVersion, 0, len(versions)),
+	}
+	for _, v := range versions {
+		res.Versions = append(res.Versions, ReportVersion{
+			Version:      v.Version,
+			CreatedAt:    v.CreatedTs,
+			SupersededAt: v.SupersededTs,
+			WorklogCount: v.WorkLogs,
+		})
+	}
+	return res
+}
+
+// ConvertJobGroupRate func converts internal job group rate object to openapi object
+func ConvertJobGroupRate(j payroll.JobGroupRate) JobGroup {
+	res := JobGroup{
+		JobGroup: string(j.JobGroup),
+		Version:  j.Version,
+		Rate:     FormatRate(j.Rate),
+	}
+	if j.EffectiveFrom != nil {
+		res.EffectiveFrom = ConvertDate(*j.EffectiveFrom)
+	}
+	if j.EffectiveTo != nil {
+		res.EffectiveTo = ConvertDate(*j.EffectiveTo)
+	}
+	return res
+}
+
+// ConvertHoliday func converts internal holiday object to openapi object
+func ConvertHoliday(h payroll.Holiday) Holiday {
+	return Holiday{
+		Date: *ConvertDate(h.Date),
+		Name: h.Name,
+	}
+}
+
+// ConvertHolidayCalendar func converts internal holiday objects of a calendar to openapi object
+func ConvertHolidayCalendar(calendar string, holidays []payroll.Holiday) HolidayCalendar {
+	res := HolidayCalendar{
+		Name:     calendar,
+		Holidays: make([]Holiday, 0, len(holidays)),
+	}
+	for _, h := range holidays {
+		res.Holidays = append(res.Holidays, ConvertHoliday(h))
+	}
+	return res
+}
+
+// ConvertPremiumLines func converts internal premium line objects to openapi objects
+func ConvertPremiumLines(lines []payroll.PremiumLine) []PremiumLine {
+	res := make([]PremiumLine, 0, len(lines))
+	for _, line := range lines {
+		var kind PremiumLineKind
+		if err := kind.FromValue(string(line.Kind)); err != nil {
+			kind = UnknownPremiumLineKind
+		}
+
+		res = append(res, PremiumLine{
+			Kind:       kind,
+			JobGroup:   string(line.JobGroup),
+			Rate:       FormatRate(line.Rate),
+			Multiplier: line.Multiplier.String(),
+			Hours:      line.Hours.String(),
+			Amount:     FormatAmount(line.Amount),
+		})
+	}
+	return res
+}
+
+// ConvertRateOverride func converts internal rate override object to openapi object
+func ConvertRateOverride(o payroll.RateOverride) RateOverride {
+	res := RateOverride{
+		ID:         o.Id,
+		EmployeeID: o.EmployeeId,
+		Rate:       FormatRate(o.Rate),
+		CreatedAt:  o.CreatedTs,
+	}
+	if o.JobGroup != nil {
+		group := string(*o.JobGroup)
+		res.JobGroup = &group
+	}
+	if o.EffectiveFrom != nil {
+		res.EffectiveFrom = ConvertDate(*o.EffectiveFrom)
+	}
+	if o.EffectiveTo != nil {
+		res.EffectiveTo = ConvertDate(*o.EffectiveTo)
+	}
+	return res
+}
+
+// ConvertScheduleAssignment func converts internal pay schedule assignment object to openapi object
+func ConvertScheduleAssignment(a payroll.ScheduleAssignment) ScheduleAssignment {
+	return ScheduleAssignment{
+		ID:            a.Id,
+		EmployeeID:    a.EmployeeId,
+		Schedule:      a.Schedule,
+		EffectiveFrom: *ConvertDate(a.EffectiveFrom),
+		CreatedAt:     a.CreatedTs,
+	}
+}
+
+// ConvertPayrollPeriod func converts internal pay period object to openapi object
+func ConvertPayrollPeriod(p payroll.PayrollPeriod) PayrollPeriod {
+	var state PayrollPeriodState
+	if err := state.FromValue(string(p.State)); err != nil {
+		state = UnknownPayrollPeriodState
+	}
+
+	return PayrollPeriod{
+		ID:        p.Id,
+		StartDate: *ConvertDate(p.StartDate),
+		EndDate:   *ConvertDate(p.EndDate),
+		State:     state,
+		Snapshot:  ConvertReport(payroll.PayrollReport{EmployeeReports: p.Snapshot}).EmployeeReports,
+		ClosedAt:  p.ClosedTs,
+		PaidAt:    p.PaidTs,
+		CreatedAt: p.CreatedTs,
+		UpdatedAt: p.UpdatedTs,
+	}
+}
+
+// convertOptionalDate func converts an optional openapi date to a local date
+func convertOptionalDate(d *types.Date) *time.Time {
+	if d == nil {
+		return nil
+	}
+	t := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local)
+	return &t
+}
+
+// ConvertWorkLogs func converts internal work log objects to openapi object
+func ConvertWorkLogs(logs []payroll.WorkLog) WorkLogs {
+	res := WorkLogs{
+		Worklogs: make([]WorkLog, 0, len(logs)),
+	}
+	for _, l := range logs {
+		res.Worklogs = append(res.Worklogs, WorkLog{
+			ID:            l.Id,
+			EmployeeID:    l.EmployeeId,
+			Date:          *ConvertDate(l.Date),
+			Hours:         l.HoursLogged.String(),
+			JobGroup:      string(l.JobGroup),
+			ReportID:      l.ReportId,
+			ReportVersion: l.ReportVersion,
+			Line:          l.Line,
+			UploadedAt:    l.UploadedTs,
+			RetiredAt:     l.RetiredTs,
+			CostCenter:    l.CostCenter,
+			Department:    l.Department,
+			Adjustment:    l.Adjustment,
+		})
+		if l.RoundedHours != nil {
+			hours := l.RoundedHours.String()
+			res.Worklogs[len(res.Worklogs)-1].RoundedHours = &hours
+		}
+	}
+	return res
+}
+
+// ConvertReportDeletion func converts internal report deletion object to openapi object
+func ConvertReportDeletion(d payroll.ReportDeletion) ReportDeletion {
+	return ReportDeletion{
+		ReportID:     d.ReportId,
+		Versions:     d.Versions,
+		WorklogCount: d.WorkLogs,
+		DeletedBy:    d.DeletedBy,
+		DeletedAt:    d.DeletedTs,
+	}
+}
+
+// ConvertDuplicates func converts internal duplicate work log objects to openapi objects
+func ConvertDuplicates(duplicates []payroll.Duplicate) []Duplicate {
+	res := make([]Duplicate, 0, len(duplicates))
+	for _, d := range duplicates {
+		var rule DuplicateRule
+		if err := rule.FromValue(string(d.Rule)); err != nil {
+			rule = UnknownDuplicateRule
+		}
+		var action DuplicateAction
+		if err := action.FromValue(string(d.Action)); err != nil {
+			action = UnknownDuplicateAction
+		}
+
+		res = append(res, Duplicate{
+			Rule:            rule,
+			Action:          action,
+			Line:            d.Line,
+			EmployeeID:      d.EmployeeId,
+			Date:            *ConvertDate(d.Date),
+			Hours:           d.HoursLogged.String(),
+			JobGroup:        string(d.JobGroup),
+			MatchedReportID: d.MatchedReportId,
+			MatchedLine:     d.MatchedLine,
+		})
+	}
+	return res
+}
+
+// convertEmployeeInput func converts the openapi employee input to an internal employee object
+func convertEmployeeInput(id int, in EmployeeInput) payroll.Employee {
+	e := payroll.Employee{
+		Id:              id,
+		Name:            in.Name,
+		HireDate:        convertOptionalDate(in.HireDate),
+		TerminationDate: convertOptionalDate(in.TerminationDate),
+		Department:      in.Department,
+		CostCenter:      in.CostCenter,
+	}
+	if in.Status != nil {
+		e.Status = payroll.EmployeeStatus(in.Status.ToValue())
+	}
+	if in.DefaultJobGroup != nil {
+		group := payroll.JobGroup(*in.DefaultJobGroup)
+		e.DefaultJobGroup = &group
+	}
+	return e
+}
+
+// ConvertEmployee func converts internal employee object to openapi object
+func ConvertEmployee(e payroll.Employee) Employee {
+	var status EmployeeStatus
+	if err := status.FromValue(string(e.Status)); err != nil {
+		status = UnknownEmployeeStatus
+	}
+
+	res := Employee{
+		ID:         e.Id,
+		Name:       e.Name,
+		Status:     status,
+		CreatedAt:  e.CreatedTs,
+		UpdatedAt:  e.UpdatedTs,
+		Department: e.Department,
+		CostCenter: e.CostCenter,
+	}
+	if e.HireDate != nil {
+		res.HireDate = ConvertDate(*e.HireDate)
+	}
+	if e.TerminationDate != nil {
+		res.TerminationDate = ConvertDate(*e.TerminationDate)
+	}
+	if e.DefaultJobGroup != nil {
+		group := string(*e.DefaultJobGroup)
+		res.DefaultJobGroup = &group
+	}
+	return res
+}
+
+// ConvertEmployeeIssues func converts internal employee issue objects to openapi objects
+func ConvertEmployeeIssues(issues []payroll.EmployeeIssue) []EmployeeIssue {
+	res := make([]EmployeeIssue, 0, len(issues))
+	for _, i := range issues {
+		var reason EmployeeIssueReason
+		if err := reason.FromValue(string(i.Reason)); err != nil {
+			reason = UnknownEmployeeIssueReason
+		}
+		var action EmployeeIssueAction
+		if err := action.FromValue(string(i.Action)); err != nil {
+			action = UnknownEmployeeIssueAction
+		}
+
+		res = append(res, EmployeeIssue{
+			Reason:     reason,
+			Action:     action,
+			Line:       i.Line,
+			EmployeeID: i.EmployeeId,
+			Date:       *ConvertDate(i.Date),
+		})
+	}
+	return res
+}
+
+// ConvertRowErrors func converts internal row error objects to openapi objects
+func ConvertRowErrors(errs []payroll.RowError) []RowError {
+	res := make([]RowError, 0, len(errs))
+	for _, e := range errs {
+		res = append(res, RowError{
+			Line:   e.Line,
+			Column: e.Column,
+			Value:  e.Value,
+			Reason: e.Reason,
+		})
+	}
+	return res
+}
+
+// ParseReportId func returns the report id captured by the first group of the pattern
+func ParseReportId(pattern *regexp.Regexp, filename string) (int, error) {
+	match := pattern.FindStringSubmatch(filename)
+	if len(match) < 2 {
+		return 0, fmt.Errorf("filename %s doesn't match report id pattern", filename)
+	}
+
+	id, err := strconv.Atoi(match[1])
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid report id in filename %s", filename)
+	}
+	return id, nil
+}
+
+// ConvertDate func converts internal time object to openapi object
+func ConvertDate(t time.Time) *openapi_types.Date {
+	return &openapi_types.Date{
+		Time: t,
+	}
+}
+
+// ParseTime func converts dd/mm/yyyy time string to time object, rejecting days the month doesn't have
+func ParseTime(s string) (*time.Time, error) {
+	t, err := time.ParseInLocation(namedDateLayouts[DateDayMonthYear], s, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date specified")
+	}
 	return &t, nil
 }
 
@@ -2,21 +2,41 @@ package handler_test
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
 	openapi_types "github.com/discord-gophers/goapi-gen/types"
 	"github.com/joshinjohnson/wave-exercise/handler"
 	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestConvertReport(t *testing.T) {
 	mockReport := payroll.PayrollReport{
 		EmployeeReports: []payroll.EmployeeReport{
 			{
-				AmountPaid: 100.0,
+				AmountPaid: decimal.NewFromInt(100),
 				EmployeeId: 1,
 				PayPeriod:  payroll.PayPeriod{StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, 14)},
+				LineItems: []payroll.LineItem{
+					{JobGroup: "A", Source: payroll.SourceJobGroup, RateVersion: 2, Rate: decimal.NewFromInt(25), Bucket: payroll.BucketRegular,
+						Multiplier: decimal.NewFromInt(1), Hours: decimal.NewFromInt(4), Amount: decimal.NewFromInt(100)},
+				},
+				Buckets: []payroll.BucketTotal{
+					{Bucket: payroll.BucketRegular, Hours: decimal.NewFromInt(4), Amount: decimal.NewFromInt(100)},
+				},
+				Adjustments: []payroll.Adjustment{
+					{
+						PayPeriod: payroll.PayPeriod{StartDate: time.Now().AddDate(0, 0, -15), EndDate: time.Now().AddDate(0, 0, -1)},
+						LineItems: []payroll.LineItem{
+							{JobGroup: "A", Source: payroll.SourceJobGroup, RateVersion: 1, Rate: decimal.NewFromInt(20), Bucket: payroll.BucketRegular,
+								Multiplier: decimal.NewFromInt(1), Hours: decimal.NewFromInt(2), Amount: decimal.NewFromInt(40)},
+						},
+						Amount: decimal.NewFromInt(40),
+					},
+				},
 			},
 		},
 	}
@@ -33,6 +53,26 @@ func TestConvertReport(t *testing.T) {
 					StartDate: handler.ConvertDate(mockReport.EmployeeReports[0].PayPeriod.StartDate),
 					EndDate:   handler.ConvertDate(mockReport.EmployeeReports[0].PayPeriod.EndDate),
 				},
+				LineItems: []handler.LineItem{
+					{JobGroup: "A", RateSource: handler.LineItemRateSourceJobGroup, RateVersion: 2, Rate: "25.00", Bucket: handler.LineItemBucketRegular, Multiplier: "1", Hours: "4", Amount: "$100.00"},
+				},
+				Buckets: []handler.BucketTotal{
+					{Bucket: handler.BucketTotalBucketRegular, Hours: "4", Amount: "$100.00"},
+				},
+				Premiums: []handler.PremiumLine{},
+				Adjustments: []handler.Adjustment{
+					{
+						OriginalPayPeriod: handler.PayPeriod{
+							StartDate: handler.ConvertDate(mockReport.EmployeeReports[0].Adjustments[0].PayPeriod.StartDate),
+							EndDate:   handler.ConvertDate(mockReport.EmployeeReports[0].Adjustments[0].PayPeriod.EndDate),
+						},
+						LineItems: []handler.LineItem{
+							{JobGroup: "A", RateSource: handler.LineItemRateSourceJobGroup, RateVersion: 1, Rate: "20.00", Bucket: handler.LineItemBucketRegular, Multiplier: "1", Hours: "2", Amount: "$40.00"},
+						},
+						Premiums: []handler.PremiumLine{},
+						Amount:   "$40.00",
+					},
+				},
 			},
 		},
 	}
@@ -44,6 +84,37 @@ func TestConvertReport(t *testing.T) {
 	}
 }
 
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "$10.50", handler.FormatAmount(decimal.RequireFromString("10.5")))
+	assert.Equal(t, "-$3.25", handler.FormatAmount(decimal.RequireFromString("-3.25")))
+	assert.Equal(t, "$0.00", handler.FormatAmount(decimal.Zero))
+	assert.Equal(t, "$0.125", handler.FormatAmount(decimal.RequireFromString("0.125")))
+}
+
+func TestFormatRate(t *testing.T) {
+	assert.Equal(t, "20.00", handler.FormatRate(decimal.RequireFromString("20")))
+	assert.Equal(t, "20.50", handler.FormatRate(decimal.RequireFromString("20.5")))
+	assert.Equal(t, "20.005", handler.FormatRate(decimal.RequireFromString("20.005")))
+}
+
+func TestParseReportId(t *testing.T) {
+	pattern := regexp.MustCompile(`time-report-(\d+)\.csv$`)
+
+	id, err := handler.ParseReportId(pattern, "time-report-42.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	_, err = handler.ParseReportId(pattern, "report.csv")
+	assert.Error(t, err)
+
+	_, err = handler.ParseReportId(pattern, "time-report-0.csv")
+	assert.Error(t, err)
+
+	id, err = handler.ParseReportId(regexp.MustCompile(`^(\d+)_hours`), "17_hours_november.csv")
+	assert.NoError(t, err)
+	assert.Equal(t, 17, id)
+}
+
 func TestConvertDate(t *testing.T) {
 	mockTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.Local)
 
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+)
+
+var ErrHolidayFile = errors.New("invalid holiday file")
+
+// ParseHolidays func reads the holidays of an ical file, or a csv file with `date,name` columns and
+// yyyy-mm-dd dates. The format is detected from the content
+func ParseHolidays(data []byte) ([]payroll.Holiday, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("BEGIN:VCALENDAR")) {
+		return parseICal(data)
+	}
+	return parseHolidayCSV(data)
+}
+
+func parseHolidayCSV(data []byte) ([]payroll.Holiday, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHolidayFile, err)
+	}
+	if len(header) < 2 || !strings.EqualFold(strings.TrimSpace(header[0]), "date") || !strings.EqualFold(strings.TrimSpace(header[1]), "name") {
+		return nil, fmt.Errorf("%w: expected a date,name header", ErrHolidayFile)
+	}
+
+	holidays := make([]payroll.Holiday, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrHolidayFile, line, err)
+		}
+
+		date, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(record[0]), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid date %s, expected yyyy-mm-dd", ErrHolidayFile, line, record[0])
+		}
+		holidays = append(holidays, payroll.Holiday{Date: date, Name: strings.TrimSpace(record[1])})
+	}
+
+	return holidays, nil
+}
+
+// parseICal func reads the all-day events of an ical file, an event spanning several days is a holiday
+// on each of them. Recurring events aren't expanded, and are rejected
+func parseICal(data []byte) ([]payroll.Holiday, error) {
+	holidays := make([]payroll.Holiday, 0)
+
+	var inEvent bool
+	var name string
+	var start, end *time.Time
+	for _, line := range unfoldICal(data) {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ := strings.Cut(key, ";")
+
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, name, start, end = true, "", nil, nil
+			}
+		case "SUMMARY":
+			name = unescapeICal(value)
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			date, err := parseICalDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrHolidayFile, err)
+			}
+			if strings.EqualFold(prop, "DTSTART") {
+				start = &date
+			} else {
+				end = &date
+			}
+		case "RRULE":
+			if inEvent {
+				return nil, fmt.Errorf("%w: recurring events aren't supported, export the calendar with each occurrence", ErrHolidayFile)
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start == nil {
+				return nil, fmt.Errorf("%w: event %q has no start date", ErrHolidayFile, name)
+			}
+
+			// the end date of an all-day event is exclusive
+			holidays = append(holidays, payroll.Holiday{Date: *start, Name: name})
+			if end != nil {
+				for d := start.AddDate(0, 0, 1); d.Before(*end); d = d.AddDate(0, 0, 1) {
+					holidays = append(holidays, payroll.Holiday{Date: d, Name: name})
+				}
+			}
+		}
+	}
+
+	return holidays, nil
+}
+
+// unfoldICal func joins the continuation lines of an ical file, which start with a space or tab
+func unfoldICal(data []byte) []string {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICalDate func reads the date of a DATE or DATE-TIME value, eg. `20231225` or `20231225T000000Z`
+func parseICalDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %s", s)
+	}
+	date, err := time.ParseInLocation("20060102", s[:8], time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %s", s)
+	}
+	return date, nil
+}
+
+func unescapeICal(s string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(strings.TrimSpace(s))
+}
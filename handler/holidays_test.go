@@ -0,0 +1,66 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHolidays_ICal(t *testing.T) {
+	ical := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20231225\r\n" +
+		"DTEND;VALUE=DATE:20231227\r\n" +
+		"SUMMARY:Christmas\\, Boxing\r\n" +
+		"  Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20240101T000000Z\r\n" +
+		"SUMMARY:New Year's Day\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	holidays, err := handler.ParseHolidays([]byte(ical))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []payroll.Holiday{
+		{Date: time.Date(2023, 12, 25, 0, 0, 0, 0, time.Local), Name: "Christmas, Boxing Day"},
+		{Date: time.Date(2023, 12, 26, 0, 0, 0, 0, time.Local), Name: "Christmas, Boxing Day"},
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), Name: "New Year's Day"},
+	}, holidays)
+}
+
+func TestParseHolidays_ICalRecurring(t *testing.T) {
+	ical := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\n" +
+		"DTSTART;VALUE=DATE:20231225\n" +
+		"RRULE:FREQ=YEARLY\n" +
+		"SUMMARY:Christmas Day\n" +
+		"END:VEVENT\n" +
+		"END:VCALENDAR\n"
+
+	_, err := handler.ParseHolidays([]byte(ical))
+
+	assert.ErrorIs(t, err, handler.ErrHolidayFile)
+}
+
+func TestParseHolidays_CSV(t *testing.T) {
+	csv := "date,name\n" +
+		"2023-12-25,Christmas Day\n" +
+		"2023-12-26, Boxing Day\n"
+
+	holidays, err := handler.ParseHolidays([]byte(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []payroll.Holiday{
+		{Date: time.Date(2023, 12, 25, 0, 0, 0, 0, time.Local), Name: "Christmas Day"},
+		{Date: time.Date(2023, 12, 26, 0, 0, 0, 0, time.Local), Name: "Boxing Day"},
+	}, holidays)
+
+	_, err = handler.ParseHolidays([]byte("date,name\n25/12/2023,Christmas Day\n"))
+	assert.ErrorIs(t, err, handler.ErrHolidayFile)
+}
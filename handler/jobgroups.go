@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+)
+
+// JobGroupConfig holds the explicit normalization applied to csv job group values
+type JobGroupConfig struct {
+	// CaseInsensitive matches job groups and aliases regardless of case, eg. `b` is group `B`
+	CaseInsensitive bool
+	// Aliases maps csv values to job groups, eg. `grp-a` to `A`
+	Aliases map[string]payroll.JobGroup
+}
+
+// JobGroupResolver validates csv job group values against the job groups that have a rate
+type JobGroupResolver struct {
+	known map[payroll.JobGroup]bool
+	cfg   JobGroupConfig
+}
+
+func NewJobGroupResolver(known []payroll.JobGroup, cfg JobGroupConfig) JobGroupResolver {
+	r := JobGroupResolver{
+		known: make(map[payroll.JobGroup]bool, len(known)),
+		cfg:   cfg,
+	}
+	for _, g := range known {
+		r.known[g] = true
+	}
+	return r
+}
+
+// Resolve func returns the job group of a csv value, applying the configured aliases and case
+// normalization only. Values that don't resolve to a known job group are an error
+func (r JobGroupResolver) Resolve(s string) (payroll.JobGroup, error) {
+	if s == "" {
+		return "", fmt.Errorf("job group is missing")
+	}
+
+	if g, ok := r.alias(s); ok && r.known[g] {
+		return g, nil
+	}
+
+	if r.known[payroll.JobGroup(s)] {
+		return payroll.JobGroup(s), nil
+	}
+	if r.cfg.CaseInsensitive {
+		for g := range r.known {
+			if strings.EqualFold(string(g), s) {
+				return g, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unknown job group")
+}
+
+func (r JobGroupResolver) alias(s string) (payroll.JobGroup, bool) {
+	if g, ok := r.cfg.Aliases[s]; ok {
+		return g, true
+	}
+	if r.cfg.CaseInsensitive {
+		for alias, g := range r.cfg.Aliases {
+			if strings.EqualFold(alias, s) {
+				return g, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobGroupResolver_Strict(t *testing.T) {
+	resolver := handler.NewJobGroupResolver([]payroll.JobGroup{"A", "B"}, handler.JobGroupConfig{})
+
+	group, err := resolver.Resolve("B")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.JobGroup("B"), group)
+
+	for _, s := range []string{"b", "C", ""} {
+		_, err := resolver.Resolve(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestJobGroupResolver_Configured(t *testing.T) {
+	resolver := handler.NewJobGroupResolver([]payroll.JobGroup{"A", "B"}, handler.JobGroupConfig{
+		CaseInsensitive: true,
+		Aliases: map[string]payroll.JobGroup{
+			"grp-a": "A",
+			"grp-c": "C",
+		},
+	})
+
+	group, err := resolver.Resolve("b")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.JobGroup("B"), group)
+
+	group, err = resolver.Resolve("GRP-A")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.JobGroup("A"), group)
+
+	// aliases of groups without a rate aren't accepted
+	_, err = resolver.Resolve("grp-c")
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// work log fields a csv column can be mapped to
+const (
+	FieldDate       = "date"
+	FieldHours      = "hours"
+	FieldEmployeeId = "employee_id"
+	FieldJobGroup   = "job_group"
+	// FieldCostCenter is optional, a file without the column is allocated to the employees' cost centers
+	FieldCostCenter = "cost_center"
+)
+
+// DefaultProfileName is the name of the profile matching the original time report format
+const DefaultProfileName = "default"
+
+var workLogFields = []string{FieldDate, FieldHours, FieldEmployeeId, FieldJobGroup}
+
+var optionalWorkLogFields = []string{FieldCostCenter}
+
+// SchemaProfile describes the layout of a time report csv
+type SchemaProfile struct {
+	Name       string
+	Delimiter  rune
+	LazyQuotes bool
+	// DateFormats are the accepted date formats, none uses ParseTime
+	DateFormats []DateFormat
+	// Columns maps work log fields to csv header names
+	Columns map[string]string
+}
+
+// DefaultSchemaProfile func returns the profile for `date,hours worked,employee id,job group` files
+func DefaultSchemaProfile() SchemaProfile {
+	return SchemaProfile{
+		Name:      DefaultProfileName,
+		Delimiter: ',',
+		Columns: map[string]string{
+			FieldDate:       "date",
+			FieldHours:      "hours worked",
+			FieldEmployeeId: "employee id",
+			FieldJobGroup:   "job group",
+			FieldCostCenter: "cost center",
+		},
+	}
+}
+
+// NewSchemaProfile func validates profile config values and creates a schema profile
+func NewSchemaProfile(name, delimiter string, lazyQuotes bool, dateFormats []string, columns map[string]string) (SchemaProfile, error) {
+	profile := SchemaProfile{
+		Name:        name,
+		Delimiter:   ',',
+		LazyQuotes:  lazyQuotes,
+		DateFormats: make([]DateFormat, 0, len(dateFormats)),
+		Columns:     make(map[string]string),
+	}
+
+	for _, s := range dateFormats {
+		format, err := NewDateFormat(s)
+		if err != nil {
+			return profile, fmt.Errorf("profile %s: %v", name, err)
+		}
+		profile.DateFormats = append(profile.DateFormats, format)
+	}
+
+	if delimiter != "" {
+		r, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) || r == '"' || r == '\r' || r == '\n' {
+			return profile, fmt.Errorf("profile %s: invalid delimiter %q", name, delimiter)
+		}
+		profile.Delimiter = r
+	}
+
+	for _, field := range workLogFields {
+		header := strings.TrimSpace(columns[field])
+		if header == "" {
+			return profile, fmt.Errorf("profile %s: no column mapped to %s", name, field)
+		}
+		profile.Columns[field] = header
+	}
+	for _, field := range optionalWorkLogFields {
+		if header := strings.TrimSpace(columns[field]); header != "" {
+			profile.Columns[field] = header
+		}
+	}
+
+	return profile, nil
+}
+
+// SchemaProfiles holds the configured profiles sorted by name, so detection is deterministic
+type SchemaProfiles []SchemaProfile
+
+// NewSchemaProfiles func sorts the profiles, adding the default profile if it isn't overridden
+func NewSchemaProfiles(profiles ...SchemaProfile) SchemaProfiles {
+	res := SchemaProfiles(profiles)
+	if _, ok := res.Get(DefaultProfileName); !ok {
+		res = append(res, DefaultSchemaProfile())
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// Get func returns the profile with the given name
+func (p SchemaProfiles) Get(name string) (SchemaProfile, bool) {
+	for _, profile := range p {
+		if strings.EqualFold(profile.Name, name) {
+			return profile, true
+		}
+	}
+	return SchemaProfile{}, false
+}
+
+// Detect func returns the first profile whose columns are all present in the header line
+func (p SchemaProfiles) Detect(headerLine string) (SchemaProfile, bool) {
+	for _, profile := range p {
+		header, err := profile.ReadHeader(headerLine)
+		if err != nil {
+			continue
+		}
+		if _, missing := profile.ColumnIndex(header); len(missing) == 0 {
+			return profile, true
+		}
+	}
+	return SchemaProfile{}, false
+}
+
+// NewReader func creates a csv reader configured with the profile's delimiter and quoting
+func (p SchemaProfile) NewReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = p.Delimiter
+	reader.LazyQuotes = p.LazyQuotes
+	// row lengths are validated per row, so a short row doesn't abort the file
+	reader.FieldsPerRecord = -1
+	return reader
+}
+
+// ReadHeader func splits the header line using the profile's delimiter
+func (p SchemaProfile) ReadHeader(headerLine string) ([]string, error) {
+	return p.NewReader(strings.NewReader(headerLine)).Read()
+}
+
+// ColumnIndex func maps every work log field to its position in the header,
+// returning the header names the profile expects but the file is missing. Optional fields
+// are only mapped if the file has their column
+func (p SchemaProfile) ColumnIndex(header []string) (map[string]int, []string) {
+	index := make(map[string]int, len(p.Columns))
+	missing := make([]string, 0)
+
+	find := func(field string) {
+		name := p.Columns[field]
+		for i, column := range header {
+			column = strings.TrimPrefix(column, "\ufeff")
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				index[field] = i
+				return
+			}
+		}
+	}
+
+	for _, field := range workLogFields {
+		if find(field); !hasField(index, field) {
+			missing = append(missing, p.Columns[field])
+		}
+	}
+	for _, field := range optionalWorkLogFields {
+		if p.Columns[field] != "" {
+			find(field)
+		}
+	}
+
+	return index, missing
+}
+
+func hasField(index map[string]int, field string) bool {
+	_, ok := index[field]
+	return ok
+}
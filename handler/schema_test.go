@@ -0,0 +1,56 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/joshinjohnson/wave-exercise/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSchemaProfile_MissingColumn(t *testing.T) {
+	_, err := handler.NewSchemaProfile("partial", ",", false, nil, map[string]string{
+		handler.FieldDate:  "date",
+		handler.FieldHours: "hours",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestNewSchemaProfile_InvalidDelimiter(t *testing.T) {
+	_, err := handler.NewSchemaProfile("bad", ";;", false, nil, handler.DefaultSchemaProfile().Columns)
+
+	assert.Error(t, err)
+}
+
+func TestSchemaProfiles_Detect(t *testing.T) {
+	pipe, err := handler.NewSchemaProfile("pipe", "|", false, nil, map[string]string{
+		handler.FieldDate:       "day",
+		handler.FieldHours:      "hours",
+		handler.FieldEmployeeId: "employee",
+		handler.FieldJobGroup:   "group",
+	})
+	assert.NoError(t, err)
+
+	profiles := handler.NewSchemaProfiles(pipe)
+
+	profile, ok := profiles.Detect("date,hours worked,employee id,job group")
+	assert.True(t, ok)
+	assert.Equal(t, handler.DefaultProfileName, profile.Name)
+
+	profile, ok = profiles.Detect("Employee|Group|Day|Hours|Comment")
+	assert.True(t, ok)
+	assert.Equal(t, "pipe", profile.Name)
+
+	_, ok = profiles.Detect("a,b,c")
+	assert.False(t, ok)
+}
+
+func TestSchemaProfiles_Get(t *testing.T) {
+	profiles := handler.NewSchemaProfiles()
+
+	_, ok := profiles.Get("DEFAULT")
+	assert.True(t, ok)
+
+	_, ok = profiles.Get("unknown")
+	assert.False(t, ok)
+}
@@ -7,18 +7,122 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/discord-gophers/goapi-gen/runtime"
+	openapi_types "github.com/discord-gophers/goapi-gen/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Retrieve the registered employees
+	// (GET /employees)
+	GetEmployees(w http.ResponseWriter, r *http.Request, params GetEmployeesParams) *Response
+	// Register an employee under the employee id of the time reports
+	// (POST /employees)
+	PostEmployees(w http.ResponseWriter, r *http.Request) *Response
+	// Create or update employees from a csv file, eg. an HR system export
+	// (POST /employees/import)
+	PostEmployeesImport(w http.ResponseWriter, r *http.Request) *Response
+	// Delete an employee, their uploaded work logs are kept
+	// (DELETE /employees/{employeeId})
+	DeleteEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response
+	// Retrieve an employee
+	// (GET /employees/{employeeId})
+	GetEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response
+	// Replace the details of an employee, already uploaded work logs aren't checked again
+	// (PUT /employees/{employeeId})
+	PutEmployeesEmployeeID(w http.ResponseWriter, r *http.Request, employeeID int) *Response
+	// Retrieve every holiday calendar and its number of holidays
+	// (GET /holiday-calendars)
+	GetHolidayCalendars(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a calendar with all its holidays
+	// (DELETE /holiday-calendars/{calendar})
+	DeleteHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response
+	// Retrieve the holidays of a calendar
+	// (GET /holiday-calendars/{calendar})
+	GetHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response
+	// Import a calendar from an ical file, or a csv file with `date,name` columns, replacing its holidays
+	// (PUT /holiday-calendars/{calendar})
+	PutHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request, calendar string) *Response
+	// Add a holiday to a calendar, or rename the holiday on the same date
+	// (POST /holiday-calendars/{calendar}/holidays)
+	PostHolidayCalendarsCalendarHolidays(w http.ResponseWriter, r *http.Request, calendar string) *Response
+	// Delete a holiday of a calendar
+	// (DELETE /holiday-calendars/{calendar}/holidays/{date})
+	DeleteHolidayCalendarsCalendarHolidaysDate(w http.ResponseWriter, r *http.Request, calendar string, date openapi_types.Date) *Response
+	// Retrieve every job group and its hourly rate
+	// (GET /job-groups)
+	GetJobGroups(w http.ResponseWriter, r *http.Request) *Response
+	// Create a job group, uploaded work logs of the group are accepted from then on
+	// (POST /job-groups)
+	PostJobGroups(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a job group that no work log belongs to
+	// (DELETE /job-groups/{jobGroup})
+	DeleteJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response
+	// Retrieve a job group and its hourly rate
+	// (GET /job-groups/{jobGroup})
+	GetJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response
+	// Change the hourly rate of a job group from its effective date
+	// (PUT /job-groups/{jobGroup})
+	PutJobGroupsJobGroup(w http.ResponseWriter, r *http.Request, jobGroup string) *Response
+	// Retrieve every rate version of a job group
+	// (GET /job-groups/{jobGroup}/rates)
+	GetJobGroupsJobGroupRates(w http.ResponseWriter, r *http.Request, jobGroup string) *Response
+	// Retrieve the pay periods
+	// (GET /pay-periods)
+	GetPayPeriods(w http.ResponseWriter, r *http.Request) *Response
+	// Add an open pay period
+	// (POST /pay-periods)
+	PostPayPeriods(w http.ResponseWriter, r *http.Request) *Response
+	// Delete an open pay period
+	// (DELETE /pay-periods/{periodId})
+	DeletePayPeriodsPeriodID(w http.ResponseWriter, r *http.Request, periodID int) *Response
+	// Retrieve a pay period along with the report snapshot taken when it was closed
+	// (GET /pay-periods/{periodId})
+	GetPayPeriodsPeriodID(w http.ResponseWriter, r *http.Request, periodID int) *Response
+	// Close, pay or reopen a pay period
+	// (PUT /pay-periods/{periodId}/state)
+	PutPayPeriodsPeriodIDState(w http.ResponseWriter, r *http.Request, periodID int) *Response
+	// Retrieve the employees' pay schedule assignments
+	// (GET /pay-schedule-assignments)
+	GetPayScheduleAssignments(w http.ResponseWriter, r *http.Request, params GetPayScheduleAssignmentsParams) *Response
+	// Switch an employee to a pay schedule of the config from the effective date
+	// (POST /pay-schedule-assignments)
+	PostPayScheduleAssignments(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a pay schedule assignment, the employee stays on their previous schedule
+	// (DELETE /pay-schedule-assignments/{assignmentId})
+	DeletePayScheduleAssignmentsAssignmentID(w http.ResponseWriter, r *http.Request, assignmentID int) *Response
+	// Retrieve the employee rate overrides
+	// (GET /rate-overrides)
+	GetRateOverrides(w http.ResponseWriter, r *http.Request, params GetRateOverridesParams) *Response
+	// Override the rate of an employee, for one job group or every group, between the effective dates
+	// (POST /rate-overrides)
+	PostRateOverrides(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a rate override, the employee's work logs are priced at the job group rate again
+	// (DELETE /rate-overrides/{overrideId})
+	DeleteRateOverridesOverrideID(w http.ResponseWriter, r *http.Request, overrideID int) *Response
 	// Retrieve a payroll report for employees
 	// (GET /report)
-	GetReport(w http.ResponseWriter, r *http.Request) *Response
+	GetReport(w http.ResponseWriter, r *http.Request, params GetReportParams) *Response
+	// Retrieve the labor cost by cost center, department, job group and pay period
+	// (GET /report/cost-centers)
+	GetReportCostCenters(w http.ResponseWriter, r *http.Request, params GetReportCostCentersParams) *Response
 	// Upload a CSV file with employee work hours data
 	// (POST /upload)
-	PostUpload(w http.ResponseWriter, r *http.Request) *Response
+	PostUpload(w http.ResponseWriter, r *http.Request, params PostUploadParams) *Response
+	// Retrieve the processing status of an uploaded file
+	// (GET /uploads/{jobId})
+	GetUploadsJobID(w http.ResponseWriter, r *http.Request, jobID string) *Response
+	// Roll back an upload, deleting every version of the time report and all its work logs
+	// (DELETE /uploads/{reportId})
+	DeleteUploadsReportID(w http.ResponseWriter, r *http.Request, reportID int, params DeleteUploadsReportIDParams) *Response
+	// Retrieve every processed version of a time report, including superseded ones
+	// (GET /uploads/{reportId}/versions)
+	GetUploadsReportIDVersions(w http.ResponseWriter, r *http.Request, reportID int) *Response
+	// Retrieve work logs along with the time report and csv line they were imported from
+	// (GET /worklogs)
+	GetWorklogs(w http.ResponseWriter, r *http.Request, params GetWorklogsParams) *Response
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -27,12 +131,767 @@ type ServerInterfaceWrapper struct {
 	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
+// GetEmployees operation middleware
+func (siw *ServerInterfaceWrapper) GetEmployees(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEmployeesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status); err != nil {
+		err = fmt.Errorf("invalid format for parameter status: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "status"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetEmployees(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostEmployees operation middleware
+func (siw *ServerInterfaceWrapper) PostEmployees(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostEmployees(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostEmployeesImport operation middleware
+func (siw *ServerInterfaceWrapper) PostEmployeesImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostEmployeesImport(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteEmployeesEmployeeID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEmployeesEmployeeID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "employeeId" -------------
+	var employeeID int
+
+	if err := runtime.BindStyledParameter("simple", false, "employeeId", chi.URLParam(r, "employeeId"), &employeeID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employeeId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteEmployeesEmployeeID(w, r, employeeID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetEmployeesEmployeeID operation middleware
+func (siw *ServerInterfaceWrapper) GetEmployeesEmployeeID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "employeeId" -------------
+	var employeeID int
+
+	if err := runtime.BindStyledParameter("simple", false, "employeeId", chi.URLParam(r, "employeeId"), &employeeID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employeeId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetEmployeesEmployeeID(w, r, employeeID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutEmployeesEmployeeID operation middleware
+func (siw *ServerInterfaceWrapper) PutEmployeesEmployeeID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "employeeId" -------------
+	var employeeID int
+
+	if err := runtime.BindStyledParameter("simple", false, "employeeId", chi.URLParam(r, "employeeId"), &employeeID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employeeId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutEmployeesEmployeeID(w, r, employeeID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetHolidayCalendars operation middleware
+func (siw *ServerInterfaceWrapper) GetHolidayCalendars(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetHolidayCalendars(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteHolidayCalendarsCalendar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "calendar" -------------
+	var calendar string
+
+	if err := runtime.BindStyledParameter("simple", false, "calendar", chi.URLParam(r, "calendar"), &calendar); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "calendar"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteHolidayCalendarsCalendar(w, r, calendar)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetHolidayCalendarsCalendar operation middleware
+func (siw *ServerInterfaceWrapper) GetHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "calendar" -------------
+	var calendar string
+
+	if err := runtime.BindStyledParameter("simple", false, "calendar", chi.URLParam(r, "calendar"), &calendar); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "calendar"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetHolidayCalendarsCalendar(w, r, calendar)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutHolidayCalendarsCalendar operation middleware
+func (siw *ServerInterfaceWrapper) PutHolidayCalendarsCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "calendar" -------------
+	var calendar string
+
+	if err := runtime.BindStyledParameter("simple", false, "calendar", chi.URLParam(r, "calendar"), &calendar); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "calendar"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutHolidayCalendarsCalendar(w, r, calendar)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostHolidayCalendarsCalendarHolidays operation middleware
+func (siw *ServerInterfaceWrapper) PostHolidayCalendarsCalendarHolidays(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "calendar" -------------
+	var calendar string
+
+	if err := runtime.BindStyledParameter("simple", false, "calendar", chi.URLParam(r, "calendar"), &calendar); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "calendar"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostHolidayCalendarsCalendarHolidays(w, r, calendar)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteHolidayCalendarsCalendarHolidaysDate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHolidayCalendarsCalendarHolidaysDate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "calendar" -------------
+	var calendar string
+
+	if err := runtime.BindStyledParameter("simple", false, "calendar", chi.URLParam(r, "calendar"), &calendar); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "calendar"})
+		return
+	}
+
+	// ------------- Path parameter "date" -------------
+	var date openapi_types.Date
+
+	if err := runtime.BindStyledParameter("simple", false, "date", chi.URLParam(r, "date"), &date); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "date"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteHolidayCalendarsCalendarHolidaysDate(w, r, calendar, date)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetJobGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetJobGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetJobGroups(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostJobGroups operation middleware
+func (siw *ServerInterfaceWrapper) PostJobGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostJobGroups(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteJobGroupsJobGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteJobGroupsJobGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "jobGroup" -------------
+	var jobGroup string
+
+	if err := runtime.BindStyledParameter("simple", false, "jobGroup", chi.URLParam(r, "jobGroup"), &jobGroup); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "jobGroup"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteJobGroupsJobGroup(w, r, jobGroup)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetJobGroupsJobGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetJobGroupsJobGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "jobGroup" -------------
+	var jobGroup string
+
+	if err := runtime.BindStyledParameter("simple", false, "jobGroup", chi.URLParam(r, "jobGroup"), &jobGroup); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "jobGroup"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetJobGroupsJobGroup(w, r, jobGroup)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutJobGroupsJobGroup operation middleware
+func (siw *ServerInterfaceWrapper) PutJobGroupsJobGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "jobGroup" -------------
+	var jobGroup string
+
+	if err := runtime.BindStyledParameter("simple", false, "jobGroup", chi.URLParam(r, "jobGroup"), &jobGroup); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "jobGroup"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutJobGroupsJobGroup(w, r, jobGroup)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetJobGroupsJobGroupRates operation middleware
+func (siw *ServerInterfaceWrapper) GetJobGroupsJobGroupRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "jobGroup" -------------
+	var jobGroup string
+
+	if err := runtime.BindStyledParameter("simple", false, "jobGroup", chi.URLParam(r, "jobGroup"), &jobGroup); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "jobGroup"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetJobGroupsJobGroupRates(w, r, jobGroup)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPayPeriods operation middleware
+func (siw *ServerInterfaceWrapper) GetPayPeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetPayPeriods(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostPayPeriods operation middleware
+func (siw *ServerInterfaceWrapper) PostPayPeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostPayPeriods(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePayPeriodsPeriodID operation middleware
+func (siw *ServerInterfaceWrapper) DeletePayPeriodsPeriodID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "periodId" -------------
+	var periodID int
+
+	if err := runtime.BindStyledParameter("simple", false, "periodId", chi.URLParam(r, "periodId"), &periodID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "periodId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeletePayPeriodsPeriodID(w, r, periodID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPayPeriodsPeriodID operation middleware
+func (siw *ServerInterfaceWrapper) GetPayPeriodsPeriodID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "periodId" -------------
+	var periodID int
+
+	if err := runtime.BindStyledParameter("simple", false, "periodId", chi.URLParam(r, "periodId"), &periodID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "periodId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetPayPeriodsPeriodID(w, r, periodID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutPayPeriodsPeriodIDState operation middleware
+func (siw *ServerInterfaceWrapper) PutPayPeriodsPeriodIDState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "periodId" -------------
+	var periodID int
+
+	if err := runtime.BindStyledParameter("simple", false, "periodId", chi.URLParam(r, "periodId"), &periodID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "periodId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutPayPeriodsPeriodIDState(w, r, periodID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPayScheduleAssignments operation middleware
+func (siw *ServerInterfaceWrapper) GetPayScheduleAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPayScheduleAssignmentsParams
+
+	// ------------- Optional query parameter "employee_id" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "employee_id", r.URL.Query(), &params.EmployeeID); err != nil {
+		err = fmt.Errorf("invalid format for parameter employee_id: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employee_id"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetPayScheduleAssignments(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostPayScheduleAssignments operation middleware
+func (siw *ServerInterfaceWrapper) PostPayScheduleAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostPayScheduleAssignments(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeletePayScheduleAssignmentsAssignmentID operation middleware
+func (siw *ServerInterfaceWrapper) DeletePayScheduleAssignmentsAssignmentID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "assignmentId" -------------
+	var assignmentID int
+
+	if err := runtime.BindStyledParameter("simple", false, "assignmentId", chi.URLParam(r, "assignmentId"), &assignmentID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "assignmentId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeletePayScheduleAssignmentsAssignmentID(w, r, assignmentID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRateOverrides operation middleware
+func (siw *ServerInterfaceWrapper) GetRateOverrides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRateOverridesParams
+
+	// ------------- Optional query parameter "employee_id" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "employee_id", r.URL.Query(), &params.EmployeeID); err != nil {
+		err = fmt.Errorf("invalid format for parameter employee_id: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employee_id"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetRateOverrides(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostRateOverrides operation middleware
+func (siw *ServerInterfaceWrapper) PostRateOverrides(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostRateOverrides(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteRateOverridesOverrideID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRateOverridesOverrideID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "overrideId" -------------
+	var overrideID int
+
+	if err := runtime.BindStyledParameter("simple", false, "overrideId", chi.URLParam(r, "overrideId"), &overrideID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "overrideId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteRateOverridesOverrideID(w, r, overrideID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetReport operation middleware
 func (siw *ServerInterfaceWrapper) GetReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset); err != nil {
+		err = fmt.Errorf("invalid format for parameter offset: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "offset"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetReport(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetReportCostCenters operation middleware
+func (siw *ServerInterfaceWrapper) GetReportCostCenters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportCostCentersParams
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "start_date", r.URL.Query(), &params.StartDate); err != nil {
+		err = fmt.Errorf("invalid format for parameter start_date: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "start_date"})
+		return
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate); err != nil {
+		err = fmt.Errorf("invalid format for parameter end_date: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "end_date"})
+		return
+	}
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetReport(w, r)
+		resp := siw.Handler.GetReportCostCenters(w, r, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -49,8 +908,212 @@ func (siw *ServerInterfaceWrapper) GetReport(w http.ResponseWriter, r *http.Requ
 func (siw *ServerInterfaceWrapper) PostUpload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUploadParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Report-Id" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Report-Id")]; found {
+		var XReportID int
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "X-Report-Id"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "X-Report-Id", runtime.ParamLocationHeader, valueList[0], &XReportID); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "X-Report-Id"})
+			return
+		}
+
+		params.XReportID = &XReportID
+
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostUpload(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUploadsJobID operation middleware
+func (siw *ServerInterfaceWrapper) GetUploadsJobID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "jobId" -------------
+	var jobID string
+
+	if err := runtime.BindStyledParameter("simple", false, "jobId", chi.URLParam(r, "jobId"), &jobID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "jobId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetUploadsJobID(w, r, jobID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteUploadsReportID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUploadsReportID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "reportId" -------------
+	var reportID int
+
+	if err := runtime.BindStyledParameter("simple", false, "reportId", chi.URLParam(r, "reportId"), &reportID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "reportId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUploadsReportIDParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Actor")]; found {
+		var XActor string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{n, "X-Actor"})
+			return
+		}
+
+		if err := runtime.BindStyledParameterWithLocation("simple", false, "X-Actor", runtime.ParamLocationHeader, valueList[0], &XActor); err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "X-Actor"})
+			return
+		}
+
+		params.XActor = XActor
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{"X-Actor"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteUploadsReportID(w, r, reportID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetUploadsReportIDVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetUploadsReportIDVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "reportId" -------------
+	var reportID int
+
+	if err := runtime.BindStyledParameter("simple", false, "reportId", chi.URLParam(r, "reportId"), &reportID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "reportId"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetUploadsReportIDVersions(w, r, reportID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetWorklogs operation middleware
+func (siw *ServerInterfaceWrapper) GetWorklogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWorklogsParams
+
+	// ------------- Optional query parameter "employee_id" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "employee_id", r.URL.Query(), &params.EmployeeID); err != nil {
+		err = fmt.Errorf("invalid format for parameter employee_id: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "employee_id"})
+		return
+	}
+
+	// ------------- Optional query parameter "report_id" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "report_id", r.URL.Query(), &params.ReportID); err != nil {
+		err = fmt.Errorf("invalid format for parameter report_id: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "report_id"})
+		return
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "start_date", r.URL.Query(), &params.StartDate); err != nil {
+		err = fmt.Errorf("invalid format for parameter start_date: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "start_date"})
+		return
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "end_date", r.URL.Query(), &params.EndDate); err != nil {
+		err = fmt.Errorf("invalid format for parameter end_date: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "end_date"})
+		return
+	}
+
+	// ------------- Optional query parameter "include_retired" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "include_retired", r.URL.Query(), &params.IncludeRetired); err != nil {
+		err = fmt.Errorf("invalid format for parameter include_retired: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "include_retired"})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit); err != nil {
+		err = fmt.Errorf("invalid format for parameter limit: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "limit"})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset); err != nil {
+		err = fmt.Errorf("invalid format for parameter offset: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "offset"})
+		return
+	}
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostUpload(w, r)
+		resp := siw.Handler.GetWorklogs(w, r, params)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -178,8 +1241,42 @@ func Handler(si ServerInterface, opts ...ServerOption) http.Handler {
 	}
 
 	r.Route(options.BaseURL, func(r chi.Router) {
+		r.Get("/employees", wrapper.GetEmployees)
+		r.Post("/employees", wrapper.PostEmployees)
+		r.Post("/employees/import", wrapper.PostEmployeesImport)
+		r.Delete("/employees/{employeeId}", wrapper.DeleteEmployeesEmployeeID)
+		r.Get("/employees/{employeeId}", wrapper.GetEmployeesEmployeeID)
+		r.Put("/employees/{employeeId}", wrapper.PutEmployeesEmployeeID)
+		r.Get("/holiday-calendars", wrapper.GetHolidayCalendars)
+		r.Delete("/holiday-calendars/{calendar}", wrapper.DeleteHolidayCalendarsCalendar)
+		r.Get("/holiday-calendars/{calendar}", wrapper.GetHolidayCalendarsCalendar)
+		r.Put("/holiday-calendars/{calendar}", wrapper.PutHolidayCalendarsCalendar)
+		r.Post("/holiday-calendars/{calendar}/holidays", wrapper.PostHolidayCalendarsCalendarHolidays)
+		r.Delete("/holiday-calendars/{calendar}/holidays/{date}", wrapper.DeleteHolidayCalendarsCalendarHolidaysDate)
+		r.Get("/job-groups", wrapper.GetJobGroups)
+		r.Post("/job-groups", wrapper.PostJobGroups)
+		r.Delete("/job-groups/{jobGroup}", wrapper.DeleteJobGroupsJobGroup)
+		r.Get("/job-groups/{jobGroup}", wrapper.GetJobGroupsJobGroup)
+		r.Put("/job-groups/{jobGroup}", wrapper.PutJobGroupsJobGroup)
+		r.Get("/job-groups/{jobGroup}/rates", wrapper.GetJobGroupsJobGroupRates)
+		r.Get("/pay-periods", wrapper.GetPayPeriods)
+		r.Post("/pay-periods", wrapper.PostPayPeriods)
+		r.Delete("/pay-periods/{periodId}", wrapper.DeletePayPeriodsPeriodID)
+		r.Get("/pay-periods/{periodId}", wrapper.GetPayPeriodsPeriodID)
+		r.Put("/pay-periods/{periodId}/state", wrapper.PutPayPeriodsPeriodIDState)
+		r.Get("/pay-schedule-assignments", wrapper.GetPayScheduleAssignments)
+		r.Post("/pay-schedule-assignments", wrapper.PostPayScheduleAssignments)
+		r.Delete("/pay-schedule-assignments/{assignmentId}", wrapper.DeletePayScheduleAssignmentsAssignmentID)
+		r.Get("/rate-overrides", wrapper.GetRateOverrides)
+		r.Post("/rate-overrides", wrapper.PostRateOverrides)
+		r.Delete("/rate-overrides/{overrideId}", wrapper.DeleteRateOverridesOverrideID)
 		r.Get("/report", wrapper.GetReport)
+		r.Get("/report/cost-centers", wrapper.GetReportCostCenters)
 		r.Post("/upload", wrapper.PostUpload)
+		r.Get("/uploads/{jobId}", wrapper.GetUploadsJobID)
+		r.Delete("/uploads/{reportId}", wrapper.DeleteUploadsReportID)
+		r.Get("/uploads/{reportId}/versions", wrapper.GetUploadsReportIDVersions)
+		r.Get("/worklogs", wrapper.GetWorklogs)
 	})
 	return r
 }
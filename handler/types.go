@@ -6,85 +6,2413 @@ package handler
 import (
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"net/http"
+	"time"
 
 	openapi_types "github.com/discord-gophers/goapi-gen/types"
 	"github.com/go-chi/render"
 )
 
+// Defines values for BucketTotalBucket.
+var (
+	UnknownBucketTotalBucket = BucketTotalBucket{}
+
+	BucketTotalBucketDoubleTime = BucketTotalBucket{"double_time"}
+
+	BucketTotalBucketOvertime = BucketTotalBucket{"overtime"}
+
+	BucketTotalBucketRegular = BucketTotalBucket{"regular"}
+)
+
+// Defines values for DuplicateAction.
+var (
+	UnknownDuplicateAction = DuplicateAction{}
+
+	DuplicateActionMerge = DuplicateAction{"merge"}
+
+	DuplicateActionReject = DuplicateAction{"reject"}
+
+	DuplicateActionWarn = DuplicateAction{"warn"}
+)
+
+// Defines values for DuplicateRule.
+var (
+	UnknownDuplicateRule = DuplicateRule{}
+
+	DuplicateRuleExact = DuplicateRule{"exact"}
+
+	DuplicateRuleOverlap = DuplicateRule{"overlap"}
+)
+
+// Defines values for EmployeeStatus.
+var (
+	UnknownEmployeeStatus = EmployeeStatus{}
+
+	EmployeeStatusActive = EmployeeStatus{"active"}
+
+	EmployeeStatusTerminated = EmployeeStatus{"terminated"}
+)
+
+// Defines values for EmployeeInputStatus.
+var (
+	UnknownEmployeeInputStatus = EmployeeInputStatus{}
+
+	EmployeeInputStatusActive = EmployeeInputStatus{"active"}
+
+	EmployeeInputStatusTerminated = EmployeeInputStatus{"terminated"}
+)
+
+// Defines values for EmployeeIssueAction.
+var (
+	UnknownEmployeeIssueAction = EmployeeIssueAction{}
+
+	EmployeeIssueActionReject = EmployeeIssueAction{"reject"}
+
+	EmployeeIssueActionWarn = EmployeeIssueAction{"warn"}
+)
+
+// Defines values for EmployeeIssueReason.
+var (
+	UnknownEmployeeIssueReason = EmployeeIssueReason{}
+
+	EmployeeIssueReasonNotHired = EmployeeIssueReason{"not_hired"}
+
+	EmployeeIssueReasonTerminated = EmployeeIssueReason{"terminated"}
+
+	EmployeeIssueReasonUnknownEmployee = EmployeeIssueReason{"unknown_employee"}
+)
+
+// Defines values for LineItemBucket.
+var (
+	UnknownLineItemBucket = LineItemBucket{}
+
+	LineItemBucketDoubleTime = LineItemBucket{"double_time"}
+
+	LineItemBucketOvertime = LineItemBucket{"overtime"}
+
+	LineItemBucketRegular = LineItemBucket{"regular"}
+)
+
+// Defines values for LineItemRateSource.
+var (
+	UnknownLineItemRateSource = LineItemRateSource{}
+
+	LineItemRateSourceEmployee = LineItemRateSource{"employee"}
+
+	LineItemRateSourceEmployeeJobGroup = LineItemRateSource{"employee_job_group"}
+
+	LineItemRateSourceJobGroup = LineItemRateSource{"job_group"}
+
+	LineItemRateSourceNone = LineItemRateSource{"none"}
+)
+
+// Defines values for NewEmployeeStatus.
+var (
+	UnknownNewEmployeeStatus = NewEmployeeStatus{}
+
+	NewEmployeeStatusActive = NewEmployeeStatus{"active"}
+
+	NewEmployeeStatusTerminated = NewEmployeeStatus{"terminated"}
+)
+
+// Defines values for PayrollPeriodState.
+var (
+	UnknownPayrollPeriodState = PayrollPeriodState{}
+
+	PayrollPeriodStateClosed = PayrollPeriodState{"closed"}
+
+	PayrollPeriodStateOpen = PayrollPeriodState{"open"}
+
+	PayrollPeriodStatePaid = PayrollPeriodState{"paid"}
+)
+
+// Defines values for PayrollPeriodStateInputState.
+var (
+	UnknownPayrollPeriodStateInputState = PayrollPeriodStateInputState{}
+
+	PayrollPeriodStateInputStateClosed = PayrollPeriodStateInputState{"closed"}
+
+	PayrollPeriodStateInputStateOpen = PayrollPeriodStateInputState{"open"}
+
+	PayrollPeriodStateInputStatePaid = PayrollPeriodStateInputState{"paid"}
+)
+
+// Defines values for PremiumLineKind.
+var (
+	UnknownPremiumLineKind = PremiumLineKind{}
+
+	PremiumLineKindHoliday = PremiumLineKind{"holiday"}
+
+	PremiumLineKindWeekend = PremiumLineKind{"weekend"}
+)
+
+// Defines values for UploadJobStatus.
+var (
+	UnknownUploadJobStatus = UploadJobStatus{}
+
+	UploadJobStatusFailed = UploadJobStatus{"failed"}
+
+	UploadJobStatusProcessing = UploadJobStatus{"processing"}
+
+	UploadJobStatusQueued = UploadJobStatus{"queued"}
+
+	UploadJobStatusSucceeded = UploadJobStatus{"succeeded"}
+)
+
+// Adjustment defines model for Adjustment.
+type Adjustment struct {
+	Amount string `json:"amount"`
+
+	// Hours priced at the rates in force on the days they were worked
+	LineItems         []LineItem    `json:"line_items"`
+	OriginalPayPeriod PayPeriod     `json:"original_pay_period"`
+	Premiums          []PremiumLine `json:"premiums"`
+}
+
+// BucketTotal defines model for BucketTotal.
+type BucketTotal struct {
+	Amount string            `json:"amount"`
+	Bucket BucketTotalBucket `json:"bucket"`
+
+	// Hours as a decimal string, eg. `7.5`
+	Hours string `json:"hours"`
+}
+
+// CostLine defines model for CostLine.
+type CostLine struct {
+	AmountPaid string `json:"amount_paid"`
+
+	// Empty for hours not allocated to a cost center
+	CostCenter string `json:"cost_center"`
+
+	// Empty for hours of employees without a department
+	Department string `json:"department"`
+
+	// Number of employees paid for the hours
+	Employees int `json:"employees"`
+
+	// Hours as a decimal string, eg. `7.5`
+	Hours     string    `json:"hours"`
+	JobGroup  string    `json:"job_group"`
+	PayPeriod PayPeriod `json:"pay_period"`
+}
+
+// CostReport defines model for CostReport.
+type CostReport struct {
+	CostLines []CostLine `json:"cost_lines"`
+}
+
+// Duplicate defines model for Duplicate.
+type Duplicate struct {
+	Action     DuplicateAction    `json:"action"`
+	Date       openapi_types.Date `json:"date"`
+	EmployeeID int                `json:"employee_id"`
+
+	// Hours as a decimal string, eg. `7.5`
+	Hours    string `json:"hours"`
+	JobGroup string `json:"job_group"`
+	Line     int    `json:"line"`
+
+	// Line of the first occurrence of the work log in its csv file
+	MatchedLine int `json:"matched_line"`
+
+	// Report of the first occurrence of the work log, the uploaded report for a duplicate within the file
+	MatchedReportID int           `json:"matched_report_id"`
+	Rule            DuplicateRule `json:"rule"`
+}
+
+// Employee defines model for Employee.
+type Employee struct {
+	// Cost center the work logs of the employee are allocated to when the time report doesn't set one
+	CostCenter      *string   `json:"cost_center,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	DefaultJobGroup *string   `json:"default_job_group,omitempty"`
+
+	// Home department of the employee
+	Department *string `json:"department,omitempty"`
+
+	// First day the employee can be paid for, unbounded when not set
+	HireDate *openapi_types.Date `json:"hire_date,omitempty"`
+	ID       int                 `json:"id"`
+	Name     string              `json:"name"`
+
+	// Status of the employee, active when not set
+	Status EmployeeStatus `json:"status"`
+
+	// Last day the employee can be paid for, unbounded when not set
+	TerminationDate *openapi_types.Date `json:"termination_date,omitempty"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+// EmployeeImport defines model for EmployeeImport.
+type EmployeeImport struct {
+	// Ids of the employees created
+	Created      []int      `json:"created"`
+	Errors       []RowError `json:"errors"`
+	Message      string     `json:"message"`
+	RowsImported int        `json:"rows_imported"`
+	RowsRejected int        `json:"rows_rejected"`
+	RowsTotal    int        `json:"rows_total"`
+
+	// Ids of the employees whose details were already up to date
+	Unchanged []int `json:"unchanged"`
+
+	// Ids of the employees whose details changed
+	Updated []int `json:"updated"`
+}
+
+// EmployeeInput defines model for EmployeeInput.
+type EmployeeInput struct {
+	// Cost center the work logs of the employee are allocated to when the time report doesn't set one
+	CostCenter      *string `json:"cost_center,omitempty"`
+	DefaultJobGroup *string `json:"default_job_group,omitempty"`
+
+	// Home department of the employee
+	Department *string `json:"department,omitempty"`
+
+	// First day the employee can be paid for, unbounded when not set
+	HireDate *openapi_types.Date `json:"hire_date,omitempty"`
+	Name     string              `json:"name"`
+
+	// Status of the employee, active when not set
+	Status *EmployeeInputStatus `json:"status,omitempty"`
+
+	// Last day the employee can be paid for, unbounded when not set
+	TerminationDate *openapi_types.Date `json:"termination_date,omitempty"`
+}
+
+// EmployeeIssue defines model for EmployeeIssue.
+type EmployeeIssue struct {
+	Action     EmployeeIssueAction `json:"action"`
+	Date       openapi_types.Date  `json:"date"`
+	EmployeeID int                 `json:"employee_id"`
+	Line       int                 `json:"line"`
+	Reason     EmployeeIssueReason `json:"reason"`
+}
+
+// Employees defines model for Employees.
+type Employees struct {
+	Employees []Employee `json:"employees"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
 }
 
+// Holiday defines model for Holiday.
+type Holiday struct {
+	Date openapi_types.Date `json:"date"`
+	Name string             `json:"name"`
+}
+
+// HolidayCalendar defines model for HolidayCalendar.
+type HolidayCalendar struct {
+	Holidays []Holiday `json:"holidays"`
+	Name     string    `json:"name"`
+}
+
+// HolidayCalendarSummary defines model for HolidayCalendarSummary.
+type HolidayCalendarSummary struct {
+	// Number of holidays in the calendar
+	Holidays int    `json:"holidays"`
+	Name     string `json:"name"`
+}
+
+// HolidayCalendars defines model for HolidayCalendars.
+type HolidayCalendars struct {
+	Calendars []HolidayCalendarSummary `json:"calendars"`
+}
+
+// JobGroup defines model for JobGroup.
+type JobGroup struct {
+	// First day the rate is in force, unbounded when not set
+	EffectiveFrom *openapi_types.Date `json:"effective_from,omitempty"`
+
+	// Last day the rate is in force, unbounded when not set
+	EffectiveTo *openapi_types.Date `json:"effective_to,omitempty"`
+	JobGroup    string              `json:"job_group"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+
+	// Rate version, increased with every rate change
+	Version int `json:"version"`
+}
+
+// JobGroupInput defines model for JobGroupInput.
+type JobGroupInput struct {
+	// First day the rate is in force, the rate applies to all past work logs when not set
+	EffectiveFrom *openapi_types.Date `json:"effective_from,omitempty"`
+	JobGroup      string              `json:"job_group"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+}
+
+// JobGroupRateInput defines model for JobGroupRateInput.
+type JobGroupRateInput struct {
+	// First day the rate is in force, defaults to today. Must be after the start of the current rate
+	EffectiveFrom *openapi_types.Date `json:"effective_from,omitempty"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+}
+
+// JobGroupRates defines model for JobGroupRates.
+type JobGroupRates struct {
+	JobGroup string     `json:"job_group"`
+	Rates    []JobGroup `json:"rates"`
+}
+
+// JobGroups defines model for JobGroups.
+type JobGroups struct {
+	JobGroups []JobGroup `json:"job_groups"`
+}
+
+// LineItem defines model for LineItem.
+type LineItem struct {
+	Amount string `json:"amount"`
+
+	// Overtime category of the hours
+	Bucket LineItemBucket `json:"bucket"`
+
+	// Hours as a decimal string, eg. `7.5`
+	Hours    string `json:"hours"`
+	JobGroup string `json:"job_group"`
+
+	// Multiplier of the rate the hours of the bucket are paid at, as a decimal string
+	Multiplier string `json:"multiplier"`
+
+	// Rate override the hours were priced at
+	OverrideID *int `json:"override_id,omitempty"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+
+	// Whether the hours were priced at the job group rate or an employee override, none when no rate was in force
+	RateSource LineItemRateSource `json:"rate_source"`
+
+	// Version of the job group rate the hours were priced at, 0 when priced at an override
+	RateVersion int `json:"rate_version"`
+}
+
+// NewEmployee defines model for NewEmployee.
+type NewEmployee struct {
+	// Cost center the work logs of the employee are allocated to when the time report doesn't set one
+	CostCenter      *string `json:"cost_center,omitempty"`
+	DefaultJobGroup *string `json:"default_job_group,omitempty"`
+
+	// Home department of the employee
+	Department *string `json:"department,omitempty"`
+
+	// First day the employee can be paid for, unbounded when not set
+	HireDate *openapi_types.Date `json:"hire_date,omitempty"`
+
+	// Employee id of the time reports
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+
+	// Status of the employee, active when not set
+	Status *NewEmployeeStatus `json:"status,omitempty"`
+
+	// Last day the employee can be paid for, unbounded when not set
+	TerminationDate *openapi_types.Date `json:"termination_date,omitempty"`
+}
+
 // Ok defines model for Ok.
 type Ok struct {
 	Message string `json:"message"`
 }
 
+// PayPeriod defines model for PayPeriod.
+type PayPeriod struct {
+	EndDate   *openapi_types.Date `json:"end_date,omitempty"`
+	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+}
+
+// PayrollPeriod defines model for PayrollPeriod.
+type PayrollPeriod struct {
+	ClosedAt  *time.Time         `json:"closed_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	EndDate   openapi_types.Date `json:"end_date"`
+	ID        int                `json:"id"`
+	PaidAt    *time.Time         `json:"paid_at,omitempty"`
+
+	// Report of the pay periods within the period when it was closed, empty while it's open
+	Snapshot  []WorkerPayrollBiWeek `json:"snapshot"`
+	StartDate openapi_types.Date    `json:"start_date"`
+	State     PayrollPeriodState    `json:"state"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// PayrollPeriodInput defines model for PayrollPeriodInput.
+type PayrollPeriodInput struct {
+	// Last day of the period, on or after start_date
+	EndDate   openapi_types.Date `json:"end_date"`
+	StartDate openapi_types.Date `json:"start_date"`
+}
+
+// PayrollPeriodStateInput defines model for PayrollPeriodStateInput.
+type PayrollPeriodStateInput struct {
+	State PayrollPeriodStateInputState `json:"state"`
+}
+
+// PayrollPeriods defines model for PayrollPeriods.
+type PayrollPeriods struct {
+	PayPeriods []PayrollPeriod `json:"pay_periods"`
+}
+
 // PayrollReport defines model for PayrollReport.
 type PayrollReport struct {
 	EmployeeReports []WorkerPayrollBiWeek `json:"employee_reports"`
 }
 
-// WorkerPayrollBiWeek defines model for WorkerPayrollBiWeek.
-type WorkerPayrollBiWeek struct {
-	AmountPaid string `json:"amount_paid"`
-	EmployeeID uint64 `json:"employee_id"`
-	PayPeriod  struct {
-		EndDate   *openapi_types.Date `json:"end_date,omitempty"`
-		StartDate *openapi_types.Date `json:"start_date,omitempty"`
-	} `json:"pay_period"`
+// PremiumLine defines model for PremiumLine.
+type PremiumLine struct {
+	Amount string `json:"amount"`
+
+	// Hours as a decimal string, eg. `7.5`
+	Hours    string          `json:"hours"`
+	JobGroup string          `json:"job_group"`
+	Kind     PremiumLineKind `json:"kind"`
+
+	// The premium is the hours times the rate times the multiplier less one, as a decimal string
+	Multiplier string `json:"multiplier"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+}
+
+// RateOverride defines model for RateOverride.
+type RateOverride struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// First day the override is in force, unbounded when not set
+	EffectiveFrom *openapi_types.Date `json:"effective_from,omitempty"`
+
+	// Last day the override is in force, unbounded when not set
+	EffectiveTo *openapi_types.Date `json:"effective_to,omitempty"`
+	EmployeeID  int                 `json:"employee_id"`
+	ID          int                 `json:"id"`
+
+	// Job group the override applies to, every group when not set
+	JobGroup *string `json:"job_group,omitempty"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+}
+
+// RateOverrideInput defines model for RateOverrideInput.
+type RateOverrideInput struct {
+	EffectiveFrom *openapi_types.Date `json:"effective_from,omitempty"`
+	EffectiveTo   *openapi_types.Date `json:"effective_to,omitempty"`
+	EmployeeID    int                 `json:"employee_id"`
+
+	// Job group the override applies to, every group when not set
+	JobGroup *string `json:"job_group,omitempty"`
+
+	// Hourly rate in dollars as a decimal string, eg. `20.50`
+	Rate string `json:"rate"`
+}
+
+// RateOverrides defines model for RateOverrides.
+type RateOverrides struct {
+	RateOverrides []RateOverride `json:"rate_overrides"`
+}
+
+// ReportChange defines model for ReportChange.
+type ReportChange struct {
+	AmountAfter  string    `json:"amount_after"`
+	AmountBefore string    `json:"amount_before"`
+	Difference   string    `json:"difference"`
+	EmployeeID   uint64    `json:"employee_id"`
+	PayPeriod    PayPeriod `json:"pay_period"`
+}
+
+// ReportDeletion defines model for ReportDeletion.
+type ReportDeletion struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	DeletedBy string    `json:"deleted_by"`
+	ReportID  int       `json:"report_id"`
+
+	// Number of report versions deleted, including superseded ones
+	Versions     int `json:"versions"`
+	WorklogCount int `json:"worklog_count"`
+}
+
+// ReportVersion defines model for ReportVersion.
+type ReportVersion struct {
+	CreatedAt    time.Time  `json:"created_at"`
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
+	Version      int        `json:"version"`
+	WorklogCount int        `json:"worklog_count"`
+}
+
+// ReportVersions defines model for ReportVersions.
+type ReportVersions struct {
+	ReportID int             `json:"report_id"`
+	Versions []ReportVersion `json:"versions"`
+}
+
+// RowError defines model for RowError.
+type RowError struct {
+	Column string `json:"column"`
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+	Value  string `json:"value"`
+}
+
+// ScheduleAssignment defines model for ScheduleAssignment.
+type ScheduleAssignment struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// First day the employee is paid on the schedule, until their next assignment
+	EffectiveFrom openapi_types.Date `json:"effective_from"`
+	EmployeeID    int                `json:"employee_id"`
+	ID            int                `json:"id"`
+
+	// Name of a pay schedule of the config
+	Schedule string `json:"schedule"`
+}
+
+// ScheduleAssignmentInput defines model for ScheduleAssignmentInput.
+type ScheduleAssignmentInput struct {
+	EffectiveFrom openapi_types.Date `json:"effective_from"`
+	EmployeeID    int                `json:"employee_id"`
+
+	// Name of a pay schedule of the config
+	Schedule string `json:"schedule"`
+}
+
+// ScheduleAssignments defines model for ScheduleAssignments.
+type ScheduleAssignments struct {
+	ScheduleAssignments []ScheduleAssignment `json:"schedule_assignments"`
+}
+
+// UploadJob defines model for UploadJob.
+type UploadJob struct {
+	Adjustment bool        `json:"adjustment"`
+	Amend      bool        `json:"amend"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Duplicates []Duplicate `json:"duplicates"`
+
+	// Work logs of unknown employees, or dated outside the employee's employment
+	EmployeeIssues []EmployeeIssue `json:"employee_issues"`
+	Errors         []RowError      `json:"errors"`
+	Filename       string          `json:"filename"`
+	ID             string          `json:"id"`
+	Message        string          `json:"message"`
+	ReportID       int             `json:"report_id"`
+
+	// Version of the report created by the upload, set once it succeeded
+	ReportVersion int             `json:"report_version"`
+	RowsImported  int             `json:"rows_imported"`
+	RowsRejected  int             `json:"rows_rejected"`
+	RowsTotal     int             `json:"rows_total"`
+	Status        UploadJobStatus `json:"status"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// UploadPreview defines model for UploadPreview.
+type UploadPreview struct {
+	Changes    []ReportChange `json:"changes"`
+	Duplicates []Duplicate    `json:"duplicates"`
+
+	// Work logs of unknown employees, or dated outside the employee's employment
+	EmployeeIssues []EmployeeIssue `json:"employee_issues"`
+	Errors         []RowError      `json:"errors"`
+	Message        string          `json:"message"`
+
+	// Version of the report the upload would create
+	ReportVersion *int `json:"report_version,omitempty"`
+	RowsRejected  int  `json:"rows_rejected"`
+	RowsTotal     int  `json:"rows_total"`
+	RowsValid     int  `json:"rows_valid"`
+}
+
+// WorkLog defines model for WorkLog.
+type WorkLog struct {
+	// Set when the work log is dated in a closed pay period and was posted as an adjustment
+	Adjustment bool `json:"adjustment"`
+
+	// Cost center of the time report, or of the employee when the report didn't set one
+	CostCenter *string            `json:"cost_center,omitempty"`
+	Date       openapi_types.Date `json:"date"`
+
+	// Department of the employee when the work log was uploaded
+	Department *string `json:"department,omitempty"`
+	EmployeeID int     `json:"employee_id"`
+
+	// Raw hours of the time report, as a decimal string
+	Hours    string `json:"hours"`
+	ID       uint64 `json:"id"`
+	JobGroup string `json:"job_group"`
+
+	// Line of the row in the uploaded csv file, including the header line
+	Line int `json:"line"`
+
+	// Time report the work log was imported from
+	ReportID      int `json:"report_id"`
+	ReportVersion int `json:"report_version"`
+
+	// Set when the report version was superseded by an amendment
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+
+	// Hours once the hours rounding policy was applied on upload as a decimal string, unset for logs uploaded before hours were rounded
+	RoundedHours *string   `json:"rounded_hours,omitempty"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+}
+
+// WorkLogs defines model for WorkLogs.
+type WorkLogs struct {
+	Worklogs []WorkLog `json:"worklogs"`
+}
+
+// WorkerPayrollBiWeek defines model for WorkerPayrollBiWeek.
+type WorkerPayrollBiWeek struct {
+	// Retro pay of hours worked in closed pay periods and posted late, paid in this pay period on top of the line items and premiums
+	Adjustments []Adjustment `json:"adjustments"`
+	AmountPaid  string       `json:"amount_paid"`
+
+	// Hours and pay of each overtime category in the pay period
+	Buckets    []BucketTotal `json:"buckets"`
+	EmployeeID uint64        `json:"employee_id"`
+	LineItems  []LineItem    `json:"line_items"`
+	PayPeriod  struct {
+		EndDate   *openapi_types.Date `json:"end_date,omitempty"`
+		StartDate *openapi_types.Date `json:"start_date,omitempty"`
+	} `json:"pay_period"`
+
+	// Premiums paid on top of the line items for hours worked on holidays and weekends
+	Premiums []PremiumLine `json:"premiums"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest Error
+
+// Conflict defines model for Conflict.
+type Conflict Error
+
+// InvalidCSV defines model for InvalidCSV.
+type InvalidCSV Error
+
+// NotFound defines model for NotFound.
+type NotFound Error
+
+// ServerError defines model for ServerError.
+type ServerError Error
+
+// Success defines model for Success.
+type Success Ok
+
+// UploadAccepted defines model for UploadAccepted.
+type UploadAccepted UploadJob
+
+// BucketTotalBucket defines model for BucketTotal.Bucket.
+type BucketTotalBucket struct {
+	value string
+}
+
+func (t *BucketTotalBucket) ToValue() string {
+	return t.value
+}
+func (t BucketTotalBucket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *BucketTotalBucket) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *BucketTotalBucket) FromValue(value string) error {
+	switch value {
+
+	case BucketTotalBucketDoubleTime.value:
+		t.value = value
+		return nil
+
+	case BucketTotalBucketOvertime.value:
+		t.value = value
+		return nil
+
+	case BucketTotalBucketRegular.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// DuplicateAction defines model for Duplicate.Action.
+type DuplicateAction struct {
+	value string
+}
+
+func (t *DuplicateAction) ToValue() string {
+	return t.value
+}
+func (t DuplicateAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *DuplicateAction) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *DuplicateAction) FromValue(value string) error {
+	switch value {
+
+	case DuplicateActionMerge.value:
+		t.value = value
+		return nil
+
+	case DuplicateActionReject.value:
+		t.value = value
+		return nil
+
+	case DuplicateActionWarn.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// DuplicateRule defines model for Duplicate.Rule.
+type DuplicateRule struct {
+	value string
+}
+
+func (t *DuplicateRule) ToValue() string {
+	return t.value
+}
+func (t DuplicateRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *DuplicateRule) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *DuplicateRule) FromValue(value string) error {
+	switch value {
+
+	case DuplicateRuleExact.value:
+		t.value = value
+		return nil
+
+	case DuplicateRuleOverlap.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Status of the employee, active when not set
+type EmployeeStatus struct {
+	value string
+}
+
+func (t *EmployeeStatus) ToValue() string {
+	return t.value
+}
+func (t EmployeeStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmployeeStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmployeeStatus) FromValue(value string) error {
+	switch value {
+
+	case EmployeeStatusActive.value:
+		t.value = value
+		return nil
+
+	case EmployeeStatusTerminated.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Status of the employee, active when not set
+type EmployeeInputStatus struct {
+	value string
+}
+
+func (t *EmployeeInputStatus) ToValue() string {
+	return t.value
+}
+func (t EmployeeInputStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmployeeInputStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmployeeInputStatus) FromValue(value string) error {
+	switch value {
+
+	case EmployeeInputStatusActive.value:
+		t.value = value
+		return nil
+
+	case EmployeeInputStatusTerminated.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// EmployeeIssueAction defines model for EmployeeIssue.Action.
+type EmployeeIssueAction struct {
+	value string
+}
+
+func (t *EmployeeIssueAction) ToValue() string {
+	return t.value
+}
+func (t EmployeeIssueAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmployeeIssueAction) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmployeeIssueAction) FromValue(value string) error {
+	switch value {
+
+	case EmployeeIssueActionReject.value:
+		t.value = value
+		return nil
+
+	case EmployeeIssueActionWarn.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// EmployeeIssueReason defines model for EmployeeIssue.Reason.
+type EmployeeIssueReason struct {
+	value string
+}
+
+func (t *EmployeeIssueReason) ToValue() string {
+	return t.value
+}
+func (t EmployeeIssueReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmployeeIssueReason) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmployeeIssueReason) FromValue(value string) error {
+	switch value {
+
+	case EmployeeIssueReasonNotHired.value:
+		t.value = value
+		return nil
+
+	case EmployeeIssueReasonTerminated.value:
+		t.value = value
+		return nil
+
+	case EmployeeIssueReasonUnknownEmployee.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Overtime category of the hours
+type LineItemBucket struct {
+	value string
+}
+
+func (t *LineItemBucket) ToValue() string {
+	return t.value
+}
+func (t LineItemBucket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *LineItemBucket) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *LineItemBucket) FromValue(value string) error {
+	switch value {
+
+	case LineItemBucketDoubleTime.value:
+		t.value = value
+		return nil
+
+	case LineItemBucketOvertime.value:
+		t.value = value
+		return nil
+
+	case LineItemBucketRegular.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Whether the hours were priced at the job group rate or an employee override, none when no rate was in force
+type LineItemRateSource struct {
+	value string
+}
+
+func (t *LineItemRateSource) ToValue() string {
+	return t.value
+}
+func (t LineItemRateSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *LineItemRateSource) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *LineItemRateSource) FromValue(value string) error {
+	switch value {
+
+	case LineItemRateSourceEmployee.value:
+		t.value = value
+		return nil
+
+	case LineItemRateSourceEmployeeJobGroup.value:
+		t.value = value
+		return nil
+
+	case LineItemRateSourceJobGroup.value:
+		t.value = value
+		return nil
+
+	case LineItemRateSourceNone.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// Status of the employee, active when not set
+type NewEmployeeStatus struct {
+	value string
+}
+
+func (t *NewEmployeeStatus) ToValue() string {
+	return t.value
+}
+func (t NewEmployeeStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *NewEmployeeStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *NewEmployeeStatus) FromValue(value string) error {
+	switch value {
+
+	case NewEmployeeStatusActive.value:
+		t.value = value
+		return nil
+
+	case NewEmployeeStatusTerminated.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// PayrollPeriodState defines model for PayrollPeriod.State.
+type PayrollPeriodState struct {
+	value string
+}
+
+func (t *PayrollPeriodState) ToValue() string {
+	return t.value
+}
+func (t PayrollPeriodState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *PayrollPeriodState) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *PayrollPeriodState) FromValue(value string) error {
+	switch value {
+
+	case PayrollPeriodStateClosed.value:
+		t.value = value
+		return nil
+
+	case PayrollPeriodStateOpen.value:
+		t.value = value
+		return nil
+
+	case PayrollPeriodStatePaid.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// PayrollPeriodStateInputState defines model for PayrollPeriodStateInput.State.
+type PayrollPeriodStateInputState struct {
+	value string
+}
+
+func (t *PayrollPeriodStateInputState) ToValue() string {
+	return t.value
+}
+func (t PayrollPeriodStateInputState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *PayrollPeriodStateInputState) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *PayrollPeriodStateInputState) FromValue(value string) error {
+	switch value {
+
+	case PayrollPeriodStateInputStateClosed.value:
+		t.value = value
+		return nil
+
+	case PayrollPeriodStateInputStateOpen.value:
+		t.value = value
+		return nil
+
+	case PayrollPeriodStateInputStatePaid.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// PremiumLineKind defines model for PremiumLine.Kind.
+type PremiumLineKind struct {
+	value string
+}
+
+func (t *PremiumLineKind) ToValue() string {
+	return t.value
+}
+func (t PremiumLineKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *PremiumLineKind) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *PremiumLineKind) FromValue(value string) error {
+	switch value {
+
+	case PremiumLineKindHoliday.value:
+		t.value = value
+		return nil
+
+	case PremiumLineKindWeekend.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// UploadJobStatus defines model for UploadJob.Status.
+type UploadJobStatus struct {
+	value string
+}
+
+func (t *UploadJobStatus) ToValue() string {
+	return t.value
+}
+func (t UploadJobStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *UploadJobStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *UploadJobStatus) FromValue(value string) error {
+	switch value {
+
+	case UploadJobStatusFailed.value:
+		t.value = value
+		return nil
+
+	case UploadJobStatusProcessing.value:
+		t.value = value
+		return nil
+
+	case UploadJobStatusQueued.value:
+		t.value = value
+		return nil
+
+	case UploadJobStatusSucceeded.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// GetEmployeesParams defines parameters for GetEmployees.
+type GetEmployeesParams struct {
+	Status *GetEmployeesParamsStatus `json:"status,omitempty"`
+}
+
+// GetEmployeesParamsStatus defines parameters for GetEmployees.
+type GetEmployeesParamsStatus string
+
+// PostEmployeesJSONBody defines parameters for PostEmployees.
+type PostEmployeesJSONBody NewEmployee
+
+// PutEmployeesEmployeeIDJSONBody defines parameters for PutEmployeesEmployeeID.
+type PutEmployeesEmployeeIDJSONBody EmployeeInput
+
+// PostHolidayCalendarsCalendarHolidaysJSONBody defines parameters for PostHolidayCalendarsCalendarHolidays.
+type PostHolidayCalendarsCalendarHolidaysJSONBody Holiday
+
+// PostJobGroupsJSONBody defines parameters for PostJobGroups.
+type PostJobGroupsJSONBody JobGroupInput
+
+// PutJobGroupsJobGroupJSONBody defines parameters for PutJobGroupsJobGroup.
+type PutJobGroupsJobGroupJSONBody JobGroupRateInput
+
+// PostPayPeriodsJSONBody defines parameters for PostPayPeriods.
+type PostPayPeriodsJSONBody PayrollPeriodInput
+
+// PutPayPeriodsPeriodIDStateJSONBody defines parameters for PutPayPeriodsPeriodIDState.
+type PutPayPeriodsPeriodIDStateJSONBody PayrollPeriodStateInput
+
+// GetPayScheduleAssignmentsParams defines parameters for GetPayScheduleAssignments.
+type GetPayScheduleAssignmentsParams struct {
+	EmployeeID *int `json:"employee_id,omitempty"`
+}
+
+// PostPayScheduleAssignmentsJSONBody defines parameters for PostPayScheduleAssignments.
+type PostPayScheduleAssignmentsJSONBody ScheduleAssignmentInput
+
+// GetRateOverridesParams defines parameters for GetRateOverrides.
+type GetRateOverridesParams struct {
+	EmployeeID *int `json:"employee_id,omitempty"`
+}
+
+// PostRateOverridesJSONBody defines parameters for PostRateOverrides.
+type PostRateOverridesJSONBody RateOverrideInput
+
+// GetReportParams defines parameters for GetReport.
+type GetReportParams struct {
+	// Number of employee reports returned, sorted by employee id and pay period
+	Limit  *int `json:"limit,omitempty"`
+	Offset *int `json:"offset,omitempty"`
+}
+
+// GetReportCostCentersParams defines parameters for GetReportCostCenters.
+type GetReportCostCentersParams struct {
+	// Only lines of pay periods ending on or after this date
+	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+
+	// Only lines of pay periods starting on or before this date
+	EndDate *openapi_types.Date `json:"end_date,omitempty"`
+}
+
+// PostUploadParams defines parameters for PostUpload.
+type PostUploadParams struct {
+	// Id of the time report, the `report_id` form field takes precedence over it
+	XReportID *int `json:"X-Report-Id,omitempty"`
+}
+
+// DeleteUploadsReportIDParams defines parameters for DeleteUploadsReportID.
+type DeleteUploadsReportIDParams struct {
+	// Name of the person deleting the report, recorded in the audit log
+	XActor string `json:"X-Actor"`
+}
+
+// GetWorklogsParams defines parameters for GetWorklogs.
+type GetWorklogsParams struct {
+	EmployeeID *int `json:"employee_id,omitempty"`
+	ReportID   *int `json:"report_id,omitempty"`
+
+	// Only work logs on or after this date
+	StartDate *openapi_types.Date `json:"start_date,omitempty"`
+
+	// Only work logs on or before this date
+	EndDate *openapi_types.Date `json:"end_date,omitempty"`
+
+	// Include work logs of superseded report versions
+	IncludeRetired *bool `json:"include_retired,omitempty"`
+	Limit          *int  `json:"limit,omitempty"`
+	Offset         *int  `json:"offset,omitempty"`
+}
+
+// PostEmployeesJSONRequestBody defines body for PostEmployees for application/json ContentType.
+type PostEmployeesJSONRequestBody PostEmployeesJSONBody
+
+// Bind implements render.Binder.
+func (PostEmployeesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutEmployeesEmployeeIDJSONRequestBody defines body for PutEmployeesEmployeeID for application/json ContentType.
+type PutEmployeesEmployeeIDJSONRequestBody PutEmployeesEmployeeIDJSONBody
+
+// Bind implements render.Binder.
+func (PutEmployeesEmployeeIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostHolidayCalendarsCalendarHolidaysJSONRequestBody defines body for PostHolidayCalendarsCalendarHolidays for application/json ContentType.
+type PostHolidayCalendarsCalendarHolidaysJSONRequestBody PostHolidayCalendarsCalendarHolidaysJSONBody
+
+// Bind implements render.Binder.
+func (PostHolidayCalendarsCalendarHolidaysJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostJobGroupsJSONRequestBody defines body for PostJobGroups for application/json ContentType.
+type PostJobGroupsJSONRequestBody PostJobGroupsJSONBody
+
+// Bind implements render.Binder.
+func (PostJobGroupsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutJobGroupsJobGroupJSONRequestBody defines body for PutJobGroupsJobGroup for application/json ContentType.
+type PutJobGroupsJobGroupJSONRequestBody PutJobGroupsJobGroupJSONBody
+
+// Bind implements render.Binder.
+func (PutJobGroupsJobGroupJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostPayPeriodsJSONRequestBody defines body for PostPayPeriods for application/json ContentType.
+type PostPayPeriodsJSONRequestBody PostPayPeriodsJSONBody
+
+// Bind implements render.Binder.
+func (PostPayPeriodsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutPayPeriodsPeriodIDStateJSONRequestBody defines body for PutPayPeriodsPeriodIDState for application/json ContentType.
+type PutPayPeriodsPeriodIDStateJSONRequestBody PutPayPeriodsPeriodIDStateJSONBody
+
+// Bind implements render.Binder.
+func (PutPayPeriodsPeriodIDStateJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostPayScheduleAssignmentsJSONRequestBody defines body for PostPayScheduleAssignments for application/json ContentType.
+type PostPayScheduleAssignmentsJSONRequestBody PostPayScheduleAssignmentsJSONBody
+
+// Bind implements render.Binder.
+func (PostPayScheduleAssignmentsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostRateOverridesJSONRequestBody defines body for PostRateOverrides for application/json ContentType.
+type PostRateOverridesJSONRequestBody PostRateOverridesJSONBody
+
+// Bind implements render.Binder.
+func (PostRateOverridesJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// Response is a common response struct for all the API calls.
+// A Response object may be instantiated via functions for specific operation responses.
+// It may also be instantiated directly, for the purpose of responding with a single status code.
+type Response struct {
+	body        interface{}
+	Code        int
+	contentType string
+}
+
+// Render implements the render.Renderer interface. It sets the Content-Type header
+// and status code based on the response definition.
+func (resp *Response) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Content-Type", resp.contentType)
+	render.Status(r, resp.Code)
+	return nil
+}
+
+// Status is a builder method to override the default status code for a response.
+func (resp *Response) Status(code int) *Response {
+	resp.Code = code
+	return resp
+}
+
+// ContentType is a builder method to override the default content type for a response.
+func (resp *Response) ContentType(contentType string) *Response {
+	resp.contentType = contentType
+	return resp
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// This is used to only marshal the body of the response.
+func (resp *Response) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resp.body)
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+// This is used to only marshal the body of the response.
+func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.Encode(resp.body)
+}
+
+// GetEmployeesJSON200Response is a constructor method for a GetEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesJSON200Response(body Employees) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetEmployeesJSON400Response is a constructor method for a GetEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetEmployeesJSON500Response is a constructor method for a GetEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesJSON201Response is a constructor method for a PostEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesJSON201Response(body Employee) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesJSON400Response is a constructor method for a PostEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesJSON404Response is a constructor method for a PostEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesJSON409Response is a constructor method for a PostEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesJSON500Response is a constructor method for a PostEmployees response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesImportJSON200Response is a constructor method for a PostEmployeesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesImportJSON200Response(body EmployeeImport) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesImportJSON400Response is a constructor method for a PostEmployeesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesImportJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesImportJSON422Response is a constructor method for a PostEmployeesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesImportJSON422Response(body EmployeeImport) *Response {
+	return &Response{
+		body:        body,
+		Code:        422,
+		contentType: "application/json",
+	}
+}
+
+// PostEmployeesImportJSON500Response is a constructor method for a PostEmployeesImport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostEmployeesImportJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeleteEmployeesEmployeeIDJSON200Response is a constructor method for a DeleteEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteEmployeesEmployeeIDJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteEmployeesEmployeeIDJSON404Response is a constructor method for a DeleteEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteEmployeesEmployeeIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteEmployeesEmployeeIDJSON500Response is a constructor method for a DeleteEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteEmployeesEmployeeIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetEmployeesEmployeeIDJSON200Response is a constructor method for a GetEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesEmployeeIDJSON200Response(body Employee) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetEmployeesEmployeeIDJSON404Response is a constructor method for a GetEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesEmployeeIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetEmployeesEmployeeIDJSON500Response is a constructor method for a GetEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetEmployeesEmployeeIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PutEmployeesEmployeeIDJSON200Response is a constructor method for a PutEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutEmployeesEmployeeIDJSON200Response(body Employee) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutEmployeesEmployeeIDJSON400Response is a constructor method for a PutEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutEmployeesEmployeeIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutEmployeesEmployeeIDJSON404Response is a constructor method for a PutEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutEmployeesEmployeeIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PutEmployeesEmployeeIDJSON500Response is a constructor method for a PutEmployeesEmployeeID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutEmployeesEmployeeIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetHolidayCalendarsJSON200Response is a constructor method for a GetHolidayCalendars response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetHolidayCalendarsJSON200Response(body HolidayCalendars) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetHolidayCalendarsJSON500Response is a constructor method for a GetHolidayCalendars response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetHolidayCalendarsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarJSON200Response is a constructor method for a DeleteHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarJSON404Response is a constructor method for a DeleteHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarJSON500Response is a constructor method for a DeleteHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetHolidayCalendarsCalendarJSON200Response is a constructor method for a GetHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetHolidayCalendarsCalendarJSON200Response(body HolidayCalendar) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetHolidayCalendarsCalendarJSON404Response is a constructor method for a GetHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetHolidayCalendarsCalendarJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetHolidayCalendarsCalendarJSON500Response is a constructor method for a GetHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetHolidayCalendarsCalendarJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PutHolidayCalendarsCalendarJSON200Response is a constructor method for a PutHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutHolidayCalendarsCalendarJSON200Response(body HolidayCalendar) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutHolidayCalendarsCalendarJSON400Response is a constructor method for a PutHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutHolidayCalendarsCalendarJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutHolidayCalendarsCalendarJSON500Response is a constructor method for a PutHolidayCalendarsCalendar response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutHolidayCalendarsCalendarJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostHolidayCalendarsCalendarHolidaysJSON201Response is a constructor method for a PostHolidayCalendarsCalendarHolidays response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostHolidayCalendarsCalendarHolidaysJSON201Response(body Holiday) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostHolidayCalendarsCalendarHolidaysJSON400Response is a constructor method for a PostHolidayCalendarsCalendarHolidays response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostHolidayCalendarsCalendarHolidaysJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostHolidayCalendarsCalendarHolidaysJSON500Response is a constructor method for a PostHolidayCalendarsCalendarHolidays response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostHolidayCalendarsCalendarHolidaysJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarHolidaysDateJSON200Response is a constructor method for a DeleteHolidayCalendarsCalendarHolidaysDate response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarHolidaysDateJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarHolidaysDateJSON404Response is a constructor method for a DeleteHolidayCalendarsCalendarHolidaysDate response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarHolidaysDateJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteHolidayCalendarsCalendarHolidaysDateJSON500Response is a constructor method for a DeleteHolidayCalendarsCalendarHolidaysDate response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteHolidayCalendarsCalendarHolidaysDateJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJSON200Response is a constructor method for a GetJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJSON200Response(body JobGroups) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJSON500Response is a constructor method for a GetJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostJobGroupsJSON201Response is a constructor method for a PostJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostJobGroupsJSON201Response(body JobGroup) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostJobGroupsJSON400Response is a constructor method for a PostJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostJobGroupsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostJobGroupsJSON409Response is a constructor method for a PostJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostJobGroupsJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PostJobGroupsJSON500Response is a constructor method for a PostJobGroups response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostJobGroupsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeleteJobGroupsJobGroupJSON200Response is a constructor method for a DeleteJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteJobGroupsJobGroupJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteJobGroupsJobGroupJSON404Response is a constructor method for a DeleteJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteJobGroupsJobGroupJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteJobGroupsJobGroupJSON409Response is a constructor method for a DeleteJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteJobGroupsJobGroupJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// DeleteJobGroupsJobGroupJSON500Response is a constructor method for a DeleteJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteJobGroupsJobGroupJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupJSON200Response is a constructor method for a GetJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupJSON200Response(body JobGroup) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupJSON404Response is a constructor method for a GetJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupJSON500Response is a constructor method for a GetJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PutJobGroupsJobGroupJSON200Response is a constructor method for a PutJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutJobGroupsJobGroupJSON200Response(body JobGroup) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutJobGroupsJobGroupJSON400Response is a constructor method for a PutJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutJobGroupsJobGroupJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutJobGroupsJobGroupJSON404Response is a constructor method for a PutJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutJobGroupsJobGroupJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PutJobGroupsJobGroupJSON409Response is a constructor method for a PutJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutJobGroupsJobGroupJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PutJobGroupsJobGroupJSON500Response is a constructor method for a PutJobGroupsJobGroup response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutJobGroupsJobGroupJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupRatesJSON200Response is a constructor method for a GetJobGroupsJobGroupRates response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupRatesJSON200Response(body JobGroupRates) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupRatesJSON404Response is a constructor method for a GetJobGroupsJobGroupRates response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupRatesJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetJobGroupsJobGroupRatesJSON500Response is a constructor method for a GetJobGroupsJobGroupRates response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetJobGroupsJobGroupRatesJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetPayPeriodsJSON200Response is a constructor method for a GetPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayPeriodsJSON200Response(body PayrollPeriods) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetPayPeriodsJSON500Response is a constructor method for a GetPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayPeriodsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostPayPeriodsJSON201Response is a constructor method for a PostPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayPeriodsJSON201Response(body PayrollPeriod) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostPayPeriodsJSON400Response is a constructor method for a PostPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayPeriodsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostPayPeriodsJSON409Response is a constructor method for a PostPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayPeriodsJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PostPayPeriodsJSON500Response is a constructor method for a PostPayPeriods response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayPeriodsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayPeriodsPeriodIDJSON200Response is a constructor method for a DeletePayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayPeriodsPeriodIDJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayPeriodsPeriodIDJSON404Response is a constructor method for a DeletePayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayPeriodsPeriodIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayPeriodsPeriodIDJSON409Response is a constructor method for a DeletePayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayPeriodsPeriodIDJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayPeriodsPeriodIDJSON500Response is a constructor method for a DeletePayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayPeriodsPeriodIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetPayPeriodsPeriodIDJSON200Response is a constructor method for a GetPayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayPeriodsPeriodIDJSON200Response(body PayrollPeriod) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetPayPeriodsPeriodIDJSON404Response is a constructor method for a GetPayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayPeriodsPeriodIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetPayPeriodsPeriodIDJSON500Response is a constructor method for a GetPayPeriodsPeriodID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayPeriodsPeriodIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PutPayPeriodsPeriodIDStateJSON200Response is a constructor method for a PutPayPeriodsPeriodIDState response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutPayPeriodsPeriodIDStateJSON200Response(body PayrollPeriod) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutPayPeriodsPeriodIDStateJSON400Response is a constructor method for a PutPayPeriodsPeriodIDState response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutPayPeriodsPeriodIDStateJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PutPayPeriodsPeriodIDStateJSON404Response is a constructor method for a PutPayPeriodsPeriodIDState response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutPayPeriodsPeriodIDStateJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PutPayPeriodsPeriodIDStateJSON409Response is a constructor method for a PutPayPeriodsPeriodIDState response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutPayPeriodsPeriodIDStateJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PutPayPeriodsPeriodIDStateJSON500Response is a constructor method for a PutPayPeriodsPeriodIDState response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutPayPeriodsPeriodIDStateJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetPayScheduleAssignmentsJSON200Response is a constructor method for a GetPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayScheduleAssignmentsJSON200Response(body ScheduleAssignments) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetPayScheduleAssignmentsJSON400Response is a constructor method for a GetPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayScheduleAssignmentsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetPayScheduleAssignmentsJSON500Response is a constructor method for a GetPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetPayScheduleAssignmentsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// PostPayScheduleAssignmentsJSON201Response is a constructor method for a PostPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayScheduleAssignmentsJSON201Response(body ScheduleAssignment) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// PostPayScheduleAssignmentsJSON400Response is a constructor method for a PostPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayScheduleAssignmentsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// PostPayScheduleAssignmentsJSON404Response is a constructor method for a PostPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayScheduleAssignmentsJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// PostPayScheduleAssignmentsJSON409Response is a constructor method for a PostPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayScheduleAssignmentsJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PostPayScheduleAssignmentsJSON500Response is a constructor method for a PostPayScheduleAssignments response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostPayScheduleAssignmentsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayScheduleAssignmentsAssignmentIDJSON200Response is a constructor method for a DeletePayScheduleAssignmentsAssignmentID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayScheduleAssignmentsAssignmentIDJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayScheduleAssignmentsAssignmentIDJSON404Response is a constructor method for a DeletePayScheduleAssignmentsAssignmentID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayScheduleAssignmentsAssignmentIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeletePayScheduleAssignmentsAssignmentIDJSON500Response is a constructor method for a DeletePayScheduleAssignmentsAssignmentID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeletePayScheduleAssignmentsAssignmentIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetRateOverridesJSON200Response is a constructor method for a GetRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetRateOverridesJSON200Response(body RateOverrides) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetRateOverridesJSON400Response is a constructor method for a GetRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetRateOverridesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetRateOverridesJSON500Response is a constructor method for a GetRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetRateOverridesJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
 }
 
-// InvalidCSV defines model for InvalidCSV.
-type InvalidCSV Error
-
-// ServerError defines model for ServerError.
-type ServerError Error
+// PostRateOverridesJSON201Response is a constructor method for a PostRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRateOverridesJSON201Response(body RateOverride) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
 
-// Success defines model for Success.
-type Success Ok
+// PostRateOverridesJSON400Response is a constructor method for a PostRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRateOverridesJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
 
-// Response is a common response struct for all the API calls.
-// A Response object may be instantiated via functions for specific operation responses.
-// It may also be instantiated directly, for the purpose of responding with a single status code.
-type Response struct {
-	body        interface{}
-	Code        int
-	contentType string
+// PostRateOverridesJSON404Response is a constructor method for a PostRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRateOverridesJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
 }
 
-// Render implements the render.Renderer interface. It sets the Content-Type header
-// and status code based on the response definition.
-func (resp *Response) Render(w http.ResponseWriter, r *http.Request) error {
-	w.Header().Set("Content-Type", resp.contentType)
-	render.Status(r, resp.Code)
-	return nil
+// PostRateOverridesJSON409Response is a constructor method for a PostRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRateOverridesJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
 }
 
-// Status is a builder method to override the default status code for a response.
-func (resp *Response) Status(code int) *Response {
-	resp.Code = code
-	return resp
+// PostRateOverridesJSON500Response is a constructor method for a PostRateOverrides response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostRateOverridesJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
 }
 
-// ContentType is a builder method to override the default content type for a response.
-func (resp *Response) ContentType(contentType string) *Response {
-	resp.contentType = contentType
-	return resp
+// DeleteRateOverridesOverrideIDJSON200Response is a constructor method for a DeleteRateOverridesOverrideID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteRateOverridesOverrideIDJSON200Response(body Ok) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
 }
 
-// MarshalJSON implements the json.Marshaler interface.
-// This is used to only marshal the body of the response.
-func (resp *Response) MarshalJSON() ([]byte, error) {
-	return json.Marshal(resp.body)
+// DeleteRateOverridesOverrideIDJSON404Response is a constructor method for a DeleteRateOverridesOverrideID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteRateOverridesOverrideIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
 }
 
-// MarshalXML implements the xml.Marshaler interface.
-// This is used to only marshal the body of the response.
-func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return e.Encode(resp.body)
+// DeleteRateOverridesOverrideIDJSON500Response is a constructor method for a DeleteRateOverridesOverrideID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteRateOverridesOverrideIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
 }
 
 // GetReportJSON200Response is a constructor method for a GetReport response.
@@ -97,6 +2425,16 @@ func GetReportJSON200Response(body PayrollReport) *Response {
 	}
 }
 
+// GetReportJSON400Response is a constructor method for a GetReport response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetReportJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
 // GetReportJSON500Response is a constructor method for a GetReport response.
 // A *Response is returned with the configured status code and content type from the spec.
 func GetReportJSON500Response(body Error) *Response {
@@ -107,9 +2445,39 @@ func GetReportJSON500Response(body Error) *Response {
 	}
 }
 
+// GetReportCostCentersJSON200Response is a constructor method for a GetReportCostCenters response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetReportCostCentersJSON200Response(body CostReport) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetReportCostCentersJSON400Response is a constructor method for a GetReportCostCenters response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetReportCostCentersJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetReportCostCentersJSON500Response is a constructor method for a GetReportCostCenters response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetReportCostCentersJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
 // PostUploadJSON200Response is a constructor method for a PostUpload response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostUploadJSON200Response(body Ok) *Response {
+func PostUploadJSON200Response(body UploadPreview) *Response {
 	return &Response{
 		body:        body,
 		Code:        200,
@@ -117,6 +2485,16 @@ func PostUploadJSON200Response(body Ok) *Response {
 	}
 }
 
+// PostUploadJSON202Response is a constructor method for a PostUpload response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostUploadJSON202Response(body UploadJob) *Response {
+	return &Response{
+		body:        body,
+		Code:        202,
+		contentType: "application/json",
+	}
+}
+
 // PostUploadJSON400Response is a constructor method for a PostUpload response.
 // A *Response is returned with the configured status code and content type from the spec.
 func PostUploadJSON400Response(body Error) *Response {
@@ -127,6 +2505,26 @@ func PostUploadJSON400Response(body Error) *Response {
 	}
 }
 
+// PostUploadJSON409Response is a constructor method for a PostUpload response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostUploadJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// PostUploadJSON422Response is a constructor method for a PostUpload response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostUploadJSON422Response(body UploadPreview) *Response {
+	return &Response{
+		body:        body,
+		Code:        422,
+		contentType: "application/json",
+	}
+}
+
 // PostUploadJSON500Response is a constructor method for a PostUpload response.
 // A *Response is returned with the configured status code and content type from the spec.
 func PostUploadJSON500Response(body Error) *Response {
@@ -136,3 +2534,143 @@ func PostUploadJSON500Response(body Error) *Response {
 		contentType: "application/json",
 	}
 }
+
+// GetUploadsJobIDJSON200Response is a constructor method for a GetUploadsJobID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsJobIDJSON200Response(body UploadJob) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetUploadsJobIDJSON404Response is a constructor method for a GetUploadsJobID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsJobIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetUploadsJobIDJSON500Response is a constructor method for a GetUploadsJobID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsJobIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// DeleteUploadsReportIDJSON200Response is a constructor method for a DeleteUploadsReportID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteUploadsReportIDJSON200Response(body ReportDeletion) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteUploadsReportIDJSON400Response is a constructor method for a DeleteUploadsReportID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteUploadsReportIDJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// DeleteUploadsReportIDJSON404Response is a constructor method for a DeleteUploadsReportID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteUploadsReportIDJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// DeleteUploadsReportIDJSON409Response is a constructor method for a DeleteUploadsReportID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteUploadsReportIDJSON409Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        409,
+		contentType: "application/json",
+	}
+}
+
+// DeleteUploadsReportIDJSON500Response is a constructor method for a DeleteUploadsReportID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteUploadsReportIDJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetUploadsReportIDVersionsJSON200Response is a constructor method for a GetUploadsReportIDVersions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsReportIDVersionsJSON200Response(body ReportVersions) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetUploadsReportIDVersionsJSON404Response is a constructor method for a GetUploadsReportIDVersions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsReportIDVersionsJSON404Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        404,
+		contentType: "application/json",
+	}
+}
+
+// GetUploadsReportIDVersionsJSON500Response is a constructor method for a GetUploadsReportIDVersions response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetUploadsReportIDVersionsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
+
+// GetWorklogsJSON200Response is a constructor method for a GetWorklogs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorklogsJSON200Response(body WorkLogs) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetWorklogsJSON400Response is a constructor method for a GetWorklogs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorklogsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
+
+// GetWorklogsJSON500Response is a constructor method for a GetWorklogs response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetWorklogsJSON500Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        500,
+		contentType: "application/json",
+	}
+}
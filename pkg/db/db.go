@@ -48,6 +48,32 @@ func NewDbWrapper(ctx context.Context, config map[string]string) (*DbWrapper, er
 	}, nil
 }
 
+// WithTx func returns a copy of the wrapper bound to the given transaction, so concurrent
+// callers don't share the same Tx
+func (w *DbWrapper) WithTx(tx *sql.Tx) *DbWrapper {
+	return &DbWrapper{
+		DB:     w.DB,
+		Tx:     tx,
+		ctx:    w.ctx,
+		logger: logrus.Logger{},
+	}
+}
+
+// Querier is implemented by both *sql.DB and *sql.Tx
+type Querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// Querier func returns the running transaction if there's one, otherwise the db
+func (w *DbWrapper) Querier() Querier {
+	if w.Tx != nil {
+		return w.Tx
+	}
+	return w.DB
+}
+
 func newPostgresDb(config map[string]string) (*sql.DB, error) {
 	var user, pass, host, port, dbName, sslmode string
 	var ok bool
@@ -2,24 +2,161 @@ CREATE DATABASE payroll;
 
 \connect payroll;
 
-CREATE TYPE jobgroup AS ENUM ('A', 'B');
+-- job groups are managed through the /job-groups api and `payroll job-groups` cli
+CREATE TABLE IF NOT EXISTS job_groups (
+    job_group TEXT PRIMARY KEY,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
 
+-- every rate change of a job group is a new version, in force between its inclusive effective dates.
+-- null dates are unbounded, so version 1 without effective_from applies to all past work logs.
+-- rates and hours are exact NUMERIC values, amounts are only rounded when a report is priced
 CREATE TABLE IF NOT EXISTS jobgroup_rate (
-    job_group jobgroup PRIMARY KEY,
-    rate FLOAT NOT NULL
+    job_group TEXT NOT NULL REFERENCES job_groups (job_group),
+    version INTEGER NOT NULL,
+    rate NUMERIC NOT NULL,
+    effective_from DATE,
+    effective_to DATE,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (job_group, version)
+);
+
+-- an employee's rate replacing the job group rate, for one job group or every group when job_group is null.
+-- overrides of the same employee and job group aren't in force on the same day
+CREATE TABLE IF NOT EXISTS rate_overrides (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL,
+    job_group TEXT REFERENCES job_groups (job_group),
+    rate NUMERIC NOT NULL,
+    effective_from DATE,
+    effective_to DATE,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS rate_overrides_employee_idx ON rate_overrides (employee_id);
+
+-- public holidays of each holiday calendar, imported from ical or csv files or managed through the api
+CREATE TABLE IF NOT EXISTS holidays (
+    calendar TEXT NOT NULL,
+    holiday_date DATE NOT NULL,
+    name TEXT NOT NULL,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (calendar, holiday_date)
+);
+
+-- employees work logs are uploaded for, the id is the employee id of the time reports. Dates are inclusive,
+-- work logs outside of them, or of employees missing here, are rejected or flagged by the employee check
+CREATE TABLE IF NOT EXISTS employees (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    hire_date DATE,
+    termination_date DATE,
+    default_job_group TEXT REFERENCES job_groups (job_group),
+    -- home department, and the cost center work logs are allocated to when the time report doesn't set one
+    department TEXT,
+    cost_center TEXT,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- employees' switches to a named pay schedule of the config, in force until their next switch
+CREATE TABLE IF NOT EXISTS pay_schedule_assignments (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees (id) ON DELETE CASCADE,
+    schedule TEXT NOT NULL,
+    effective_from DATE NOT NULL,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (employee_id, effective_from)
+);
+
+-- pay periods are open, closed once their report is final and paid once the pay is out. Work logs dated in
+-- closed or paid periods are only accepted as adjustments, the snapshot is the report when the period was closed
+CREATE TABLE IF NOT EXISTS pay_periods (
+    id SERIAL PRIMARY KEY,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    state TEXT NOT NULL DEFAULT 'open',
+    snapshot JSONB,
+    closed_ts TIMESTAMP WITH TIME ZONE,
+    paid_ts TIMESTAMP WITH TIME ZONE,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (end_date >= start_date),
+    -- concurrent inserts both pass the insert's overlap check, the constraint refuses the second one
+    CONSTRAINT pay_periods_no_overlap EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&)
 );
 
+-- every amendment of a report is a new version, superseded versions are kept for audit
 CREATE TABLE IF NOT EXISTS processed_files (
-    id INTEGER UNIQUE NOT NULL
+    id INTEGER NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    superseded_ts TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (id, version)
 );
 
 CREATE TABLE IF NOT EXISTS worklog (
     id BIGSERIAL PRIMARY KEY,
     employee_id INTEGER NOT NULL,
     log_date TIMESTAMP NOT NULL,
-    log_hours FLOAT DEFAULT 0.0,
-    job_group jobgroup NOT NULL,
+    log_hours NUMERIC DEFAULT 0,
+    -- hours paid once the hours rounding policy is applied, kept along with the raw hours for audits
+    rounded_hours NUMERIC,
+    job_group TEXT NOT NULL REFERENCES job_groups (job_group),
+    updated_ts TIMESTAMP WITH TIME ZONE NOT NULL,
+    report_id INTEGER,
+    report_version INTEGER,
+    -- line of the row in the uploaded csv file, including the header line
+    line INTEGER,
+    uploaded_ts TIMESTAMP WITH TIME ZONE,
+    -- set when the report version the log was inserted from is superseded
+    retired_ts TIMESTAMP WITH TIME ZONE,
+    -- cost center from the time report or the employee, and the employee's department when the log was inserted
+    cost_center TEXT,
+    department TEXT,
+    -- set on logs dated in a closed pay period, paid as an adjustment instead of changing the closed period
+    adjustment BOOLEAN NOT NULL DEFAULT false,
+    FOREIGN KEY (report_id, report_version) REFERENCES processed_files (id, version)
+);
+
+CREATE INDEX IF NOT EXISTS worklog_report_idx ON worklog (report_id, report_version);
+CREATE INDEX IF NOT EXISTS worklog_employee_idx ON worklog (employee_id, log_date);
+
+-- audit log of reports deleted to roll back an upload
+CREATE TABLE IF NOT EXISTS report_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    report_id INTEGER NOT NULL,
+    versions INTEGER NOT NULL,
+    worklog_count INTEGER NOT NULL,
+    deleted_by TEXT NOT NULL,
+    deleted_ts TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS upload_jobs (
+    id UUID PRIMARY KEY,
+    report_id INTEGER NOT NULL,
+    report_version INTEGER,
+    amend BOOLEAN NOT NULL DEFAULT false,
+    adjustment BOOLEAN NOT NULL DEFAULT false,
+    filename TEXT NOT NULL,
+    profile TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    status TEXT NOT NULL,
+    message TEXT,
+    rows_total INTEGER NOT NULL DEFAULT 0,
+    rows_imported INTEGER NOT NULL DEFAULT 0,
+    rows_rejected INTEGER NOT NULL DEFAULT 0,
+    errors JSONB,
+    duplicates JSONB,
+    employee_issues JSONB,
+    -- times the job was claimed, workers finish the job only while holding the latest claim
+    attempt INTEGER NOT NULL DEFAULT 0,
+    created_ts TIMESTAMP WITH TIME ZONE NOT NULL,
     updated_ts TIMESTAMP WITH TIME ZONE NOT NULL
 );
 
-INSERT INTO jobgroup_rate(job_group, rate) VALUES ('A', 20), ('B', 30);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS upload_jobs_status_idx ON upload_jobs (status, created_ts);
+
+INSERT INTO job_groups(job_group) VALUES ('A'), ('B');
+INSERT INTO jobgroup_rate(job_group, version, rate) VALUES ('A', 1, 20), ('B', 1, 30);
\ No newline at end of file
@@ -0,0 +1,79 @@
+package payroll
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Adjustment is the pay of hours worked in a pay period that was closed when they were posted, paid in a later
+// pay period instead of changing the closed one
+type Adjustment struct {
+	// PayPeriod is the pay period the hours were worked in
+	PayPeriod PayPeriod
+	LineItems []LineItem
+	Premiums  []PremiumLine
+	Amount    decimal.Decimal
+}
+
+// paidPeriod func returns the pay period the work log is paid in, adjustments are paid in the pay period of their
+// upload date and other logs in the pay period of their date
+func (cfg ReportConfig) paidPeriod(log WorkLog) PayPeriod {
+	if log.Adjustment && !log.UploadedTs.IsZero() {
+		log.Date = dateOf(log.UploadedTs)
+	}
+	return cfg.payPeriod(log)
+}
+
+// countedBefore func orders the logs their hours are counted in by the daily cap and overtime rules. Logs are counted
+// in date order, and in file order within a day, and adjustments after every other log in upload order, so hours
+// posted late never change how the hours of a closed pay period, or of earlier adjustments, were paid
+func countedBefore(a, b WorkLog) bool {
+	if a.Adjustment != b.Adjustment {
+		return b.Adjustment
+	}
+	if a.Adjustment && !a.UploadedTs.Equal(b.UploadedTs) {
+		return a.UploadedTs.Before(b.UploadedTs)
+	}
+	da, db := a.Date.Format(time.DateOnly), b.Date.Format(time.DateOnly)
+	if da != db {
+		return da < db
+	}
+	return a.Line < b.Line
+}
+
+// priceAdjustments func prices the adjustment hours at the rates in force on their dates, grouped by the pay period
+// they were worked in and sorted by it
+func priceAdjustments(cfg ReportConfig, rates Rates, hours []BucketHours) []Adjustment {
+	periods := make(map[PayPeriod][]BucketHours)
+	for _, h := range hours {
+		p := cfg.payPeriod(h.WorkLog)
+		periods[p] = append(periods[p], h)
+	}
+
+	res := make([]Adjustment, 0, len(periods))
+	for p, periodHours := range periods {
+		lineItems := priceHours(cfg, rates, periodHours)
+		premiums := pricePremiums(cfg, rates, periodHours)
+		res = append(res, Adjustment{
+			PayPeriod: p,
+			LineItems: lineItems,
+			Premiums:  premiums,
+			Amount:    sumLineItems(lineItems).Add(sumPremiums(premiums)),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].PayPeriod.StartDate.Before(res[j].PayPeriod.StartDate)
+	})
+	return res
+}
+
+func sumAdjustments(adjustments []Adjustment) decimal.Decimal {
+	total := decimal.Zero
+	for _, a := range adjustments {
+		total = total.Add(a.Amount)
+	}
+	return total
+}
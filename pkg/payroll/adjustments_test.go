@@ -0,0 +1,64 @@
+package payroll_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateReport_Adjustments(t *testing.T) {
+	// the rate of group A went up on the 16th, after the adjusted hours were worked
+	before, changed := day(15), day(16)
+	rates := payroll.NewRates([]payroll.JobGroupRate{
+		{JobGroup: "A", Version: 1, Rate: dec("20"), EffectiveTo: &before},
+		{JobGroup: "A", Version: 2, Rate: dec("30"), EffectiveFrom: &changed},
+	}, nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(7), HoursLogged: dec("8"), JobGroup: "A", UploadedTs: day(8)},
+		{EmployeeId: 1, Date: day(20), HoursLogged: dec("4"), JobGroup: "A", UploadedTs: day(21)},
+		{EmployeeId: 1, Date: day(9), HoursLogged: dec("2"), JobGroup: "A", UploadedTs: day(21), Adjustment: true},
+	}
+
+	report := payroll.GenerateReport(payroll.ReportConfig{}, rates, logs)
+	sort.Slice(report.EmployeeReports, func(i, j int) bool {
+		return report.EmployeeReports[i].PayPeriod.StartDate.Before(report.EmployeeReports[j].PayPeriod.StartDate)
+	})
+
+	assert.Len(t, report.EmployeeReports, 2)
+	first, second := report.EmployeeReports[0], report.EmployeeReports[1]
+	// the closed period is left as it was paid
+	assert.Equal(t, period(day(1), day(15)), first.PayPeriod)
+	assertEqualDecimals(t, dec("160"), first.AmountPaid)
+	assert.Empty(t, first.Adjustments)
+
+	assert.Equal(t, period(day(16), day(30)), second.PayPeriod)
+	assertEqualDecimals(t, dec("160"), second.AmountPaid)
+	assertEqualDecimals(t, dec("120"), second.LineItems[0].Amount)
+	assert.Len(t, second.Adjustments, 1)
+	assert.Equal(t, period(day(1), day(15)), second.Adjustments[0].PayPeriod)
+	assert.Equal(t, 1, second.Adjustments[0].LineItems[0].RateVersion)
+	assertEqualDecimals(t, dec("40"), second.Adjustments[0].Amount)
+}
+
+func TestGenerateReport_AdjustmentOvertime(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("3"), JobGroup: "A", UploadedTs: day(20), Line: 2, Adjustment: true},
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("8"), JobGroup: "A", UploadedTs: day(7), Line: 5},
+	}
+
+	report := payroll.GenerateReport(payroll.ReportConfig{Overtime: overtimeRules}, rates, logs)
+	sort.Slice(report.EmployeeReports, func(i, j int) bool {
+		return report.EmployeeReports[i].PayPeriod.StartDate.Before(report.EmployeeReports[j].PayPeriod.StartDate)
+	})
+
+	// hours posted late are counted after the hours already paid, so they're the overtime hours of the day
+	assert.Len(t, report.EmployeeReports, 2)
+	assertEqualDecimals(t, []payroll.BucketTotal{
+		{Bucket: payroll.BucketRegular, Hours: dec("8"), Amount: dec("160")},
+	}, report.EmployeeReports[0].Buckets)
+	assert.Equal(t, payroll.BucketOvertime, report.EmployeeReports[1].Adjustments[0].LineItems[0].Bucket)
+	assertEqualDecimals(t, dec("90"), report.EmployeeReports[1].AmountPaid)
+}
@@ -0,0 +1,109 @@
+package payroll
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// CostReport is the labor cost of the work logs grouped by cost center, department, job group and pay period
+type CostReport struct {
+	CostLines []CostLine
+}
+
+// CostLine is the pay of the hours allocated to a cost center and department in a job group within a pay period.
+// Empty cost center and department are unallocated hours
+type CostLine struct {
+	CostCenter string
+	Department string
+	JobGroup   JobGroup
+	PayPeriod  PayPeriod
+	// Employees is the number of employees paid for the hours
+	Employees  int
+	Hours      decimal.Decimal
+	AmountPaid decimal.Decimal
+}
+
+// AllocateLogs func sets the department of each log to its employee's department, and the cost center of the
+// logs without one to their employee's cost center. Logs of unknown employees are left as they are
+func AllocateLogs(employees []Employee, logs []WorkLog) []WorkLog {
+	byId := make(map[int]Employee, len(employees))
+	for _, e := range employees {
+		byId[e.Id] = e
+	}
+
+	res := make([]WorkLog, 0, len(logs))
+	for _, log := range logs {
+		if e, ok := byId[log.EmployeeId]; ok {
+			log.Department = e.Department
+			if log.CostCenter == nil {
+				log.CostCenter = e.CostCenter
+			}
+		}
+		res = append(res, log)
+	}
+	return res
+}
+
+// GenerateCostReport func groups the pay of the work logs by cost center, department, job group and pay period of
+// the pay schedule, sorted in that order. Hours are split into overtime buckets and paid premiums per employee
+// first, the same as GenerateReport, so the pay of a line adds up to the pay of its hours in the employee report.
+// Adjustments are reported in the pay period they're paid in, and the amount of each line is rounded as configured
+func GenerateCostReport(cfg ReportConfig, rates Rates, worklogs []WorkLog) CostReport {
+	type key struct {
+		costCenter string
+		department string
+		jobGroup   JobGroup
+		payPeriod  PayPeriod
+	}
+
+	groups := make(map[key][]BucketHours)
+	for _, h := range cfg.classifyHours(worklogs) {
+		k := key{deref(h.WorkLog.CostCenter), deref(h.WorkLog.Department), h.WorkLog.JobGroup, cfg.paidPeriod(h.WorkLog)}
+		groups[k] = append(groups[k], h)
+	}
+
+	lines := make([]CostLine, 0, len(groups))
+	for k, hours := range groups {
+		line := CostLine{
+			CostCenter: k.costCenter,
+			Department: k.department,
+			JobGroup:   k.jobGroup,
+			PayPeriod:  k.payPeriod,
+		}
+
+		employees := make(map[int]bool)
+		for _, h := range hours {
+			employees[h.WorkLog.EmployeeId] = true
+			line.Hours = line.Hours.Add(h.Hours)
+		}
+		line.Employees = len(employees)
+		line.AmountPaid = cfg.Rounding.Round(sumLineItems(priceHours(cfg, rates, hours)).Add(sumPremiums(pricePremiums(cfg, rates, hours))))
+
+		lines = append(lines, line)
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].CostCenter != lines[j].CostCenter {
+			return lines[i].CostCenter < lines[j].CostCenter
+		}
+		if lines[i].Department != lines[j].Department {
+			return lines[i].Department < lines[j].Department
+		}
+		if lines[i].JobGroup != lines[j].JobGroup {
+			return lines[i].JobGroup < lines[j].JobGroup
+		}
+		return lines[i].PayPeriod.StartDate.Before(lines[j].PayPeriod.StartDate)
+	})
+
+	return CostReport{
+		CostLines: lines,
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,51 @@
+package payroll_test
+
+import (
+	"testing"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateLogs(t *testing.T) {
+	rd, cc100, cc200 := "R&D", "CC-100", "CC-200"
+	employees := []payroll.Employee{
+		{Id: 1, Department: &rd, CostCenter: &cc100},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Line: 2},
+		{EmployeeId: 1, Line: 3, CostCenter: &cc200},
+		{EmployeeId: 2, Line: 4},
+	}
+
+	allocated := payroll.AllocateLogs(employees, logs)
+
+	assert.Equal(t, []payroll.WorkLog{
+		{EmployeeId: 1, Line: 2, Department: &rd, CostCenter: &cc100},
+		{EmployeeId: 1, Line: 3, Department: &rd, CostCenter: &cc200},
+		{EmployeeId: 2, Line: 4},
+	}, allocated)
+}
+
+func TestGenerateCostReport(t *testing.T) {
+	rd, ops, cc100, cc200 := "R&D", "Ops", "CC-100", "CC-200"
+	rates := payroll.NewRates([]payroll.JobGroupRate{
+		{JobGroup: "A", Version: 1, Rate: dec("20")},
+		{JobGroup: "B", Version: 1, Rate: dec("30")},
+	}, nil)
+	worklogs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("8"), JobGroup: "A", CostCenter: &cc100, Department: &rd},
+		{EmployeeId: 2, Date: day(7), HoursLogged: dec("4"), JobGroup: "A", CostCenter: &cc100, Department: &rd},
+		{EmployeeId: 2, Date: day(8), HoursLogged: dec("2"), JobGroup: "B", CostCenter: &cc200, Department: &ops},
+		{EmployeeId: 3, Date: day(20), HoursLogged: dec("5"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateCostReport(payroll.ReportConfig{}, rates, worklogs)
+
+	firstHalf, secondHalf := payroll.ParsePayPeriodString("1-11-2023"), payroll.ParsePayPeriodString("16-11-2023")
+	assertEqualDecimals(t, []payroll.CostLine{
+		{JobGroup: "A", PayPeriod: secondHalf, Employees: 1, Hours: dec("5"), AmountPaid: dec("100")},
+		{CostCenter: cc100, Department: rd, JobGroup: "A", PayPeriod: firstHalf, Employees: 2, Hours: dec("12"), AmountPaid: dec("240")},
+		{CostCenter: cc200, Department: ops, JobGroup: "B", PayPeriod: firstHalf, Employees: 1, Hours: dec("2"), AmountPaid: dec("60")},
+	}, report.CostLines)
+}
@@ -0,0 +1,142 @@
+package payroll
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DuplicateAction decides what happens to an uploaded work log matched by a duplicate rule
+type DuplicateAction string
+
+const (
+	// DuplicateOff disables the rule
+	DuplicateOff DuplicateAction = "off"
+	// DuplicateReject fails the whole upload
+	DuplicateReject DuplicateAction = "reject"
+	// DuplicateWarn imports the work log and reports it
+	DuplicateWarn DuplicateAction = "warn"
+	// DuplicateMerge keeps the first occurrence, the duplicate isn't imported
+	DuplicateMerge DuplicateAction = "merge"
+)
+
+// DuplicateRule is the rule a duplicate work log was matched by
+type DuplicateRule string
+
+const (
+	// RuleExact matches work logs with the same employee, date, hours and job group
+	RuleExact DuplicateRule = "exact"
+	// RuleOverlap matches work logs of the same employee on the same date
+	RuleOverlap DuplicateRule = "overlap"
+)
+
+// DuplicateRules holds the action of each duplicate rule
+type DuplicateRules struct {
+	Exact   DuplicateAction
+	Overlap DuplicateAction
+}
+
+// Duplicate is an uploaded work log matching one in the same file, or an active one of another report
+type Duplicate struct {
+	Rule        DuplicateRule   `json:"rule"`
+	Action      DuplicateAction `json:"action"`
+	Line        int             `json:"line"`
+	EmployeeId  int             `json:"employee_id"`
+	Date        time.Time       `json:"date"`
+	HoursLogged decimal.Decimal `json:"hours"`
+	JobGroup    JobGroup        `json:"job_group"`
+	// MatchedReportId and MatchedLine point to the first occurrence of the work log
+	MatchedReportId int `json:"matched_report_id"`
+	MatchedLine     int `json:"matched_line"`
+}
+
+// ParseDuplicateAction func converts config value to a duplicate action, empty value disables the rule
+func ParseDuplicateAction(s string) (DuplicateAction, error) {
+	switch DuplicateAction(s) {
+	case "", DuplicateOff:
+		return DuplicateOff, nil
+	case DuplicateReject, DuplicateWarn, DuplicateMerge:
+		return DuplicateAction(s), nil
+	}
+	return "", fmt.Errorf("unknown duplicate action: %s", s)
+}
+
+// FindDuplicates func matches the uploaded logs against the existing ones and each other, in file order.
+// It returns the duplicates found and the logs to insert, which leaves out the merged duplicates
+func FindDuplicates(rules DuplicateRules, existing, logs []WorkLog) ([]Duplicate, []WorkLog) {
+	type exactKey struct {
+		employeeId int
+		date       string
+		// hours is the canonical string of the hours, so 8 and 8.00 are the same
+		hours    string
+		jobGroup JobGroup
+	}
+	type dayKey struct {
+		employeeId int
+		date       string
+	}
+
+	exact := make(map[exactKey]WorkLog)
+	days := make(map[dayKey]WorkLog)
+	add := func(log WorkLog) {
+		date := log.Date.Format(time.DateOnly)
+		if _, ok := exact[exactKey{log.EmployeeId, date, log.HoursLogged.String(), log.JobGroup}]; !ok {
+			exact[exactKey{log.EmployeeId, date, log.HoursLogged.String(), log.JobGroup}] = log
+		}
+		if _, ok := days[dayKey{log.EmployeeId, date}]; !ok {
+			days[dayKey{log.EmployeeId, date}] = log
+		}
+	}
+
+	for _, log := range existing {
+		add(log)
+	}
+
+	duplicates := make([]Duplicate, 0)
+	keep := make([]WorkLog, 0, len(logs))
+	for _, log := range logs {
+		date := log.Date.Format(time.DateOnly)
+
+		var rule DuplicateRule
+		var action DuplicateAction
+		var matched WorkLog
+		if m, ok := exact[exactKey{log.EmployeeId, date, log.HoursLogged.String(), log.JobGroup}]; ok && rules.Exact != DuplicateOff {
+			rule, action, matched = RuleExact, rules.Exact, m
+		} else if m, ok := days[dayKey{log.EmployeeId, date}]; ok && rules.Overlap != DuplicateOff {
+			rule, action, matched = RuleOverlap, rules.Overlap, m
+		}
+
+		if rule != "" {
+			duplicates = append(duplicates, Duplicate{
+				Rule:            rule,
+				Action:          action,
+				Line:            log.Line,
+				EmployeeId:      log.EmployeeId,
+				Date:            log.Date,
+				HoursLogged:     log.HoursLogged,
+				JobGroup:        log.JobGroup,
+				MatchedReportId: matched.ReportId,
+				MatchedLine:     matched.Line,
+			})
+			if action == DuplicateMerge {
+				continue
+			}
+		}
+
+		add(log)
+		keep = append(keep, log)
+	}
+
+	return duplicates, keep
+}
+
+// rejected func returns true if any of the duplicates fails the upload
+func rejected(duplicates []Duplicate) bool {
+	for _, d := range duplicates {
+		if d.Action == DuplicateReject {
+			return true
+		}
+	}
+	return false
+}
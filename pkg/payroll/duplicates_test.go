@@ -0,0 +1,87 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	nov14 = time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local)
+	nov15 = time.Date(2023, 11, 15, 0, 0, 0, 0, time.Local)
+)
+
+func TestFindDuplicates_Exact(t *testing.T) {
+	existing := []payroll.WorkLog{
+		{EmployeeId: 1, Date: nov14, HoursLogged: dec("8"), JobGroup: "A", ReportId: 41, Line: 3},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: nov14, HoursLogged: dec("8"), JobGroup: "A", ReportId: 42, Line: 2},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", ReportId: 42, Line: 3},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", ReportId: 42, Line: 4},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("2"), JobGroup: "B", ReportId: 42, Line: 5},
+	}
+
+	duplicates, keep := payroll.FindDuplicates(payroll.DuplicateRules{
+		Exact:   payroll.DuplicateWarn,
+		Overlap: payroll.DuplicateOff,
+	}, existing, logs)
+
+	assert.Equal(t, []payroll.Duplicate{
+		{Rule: payroll.RuleExact, Action: payroll.DuplicateWarn, Line: 2, EmployeeId: 1, Date: nov14, HoursLogged: dec("8"),
+			JobGroup: "A", MatchedReportId: 41, MatchedLine: 3},
+		{Rule: payroll.RuleExact, Action: payroll.DuplicateWarn, Line: 4, EmployeeId: 2, Date: nov15, HoursLogged: dec("4"),
+			JobGroup: "B", MatchedReportId: 42, MatchedLine: 3},
+	}, duplicates)
+	assert.Equal(t, logs, keep)
+}
+
+func TestFindDuplicates_MergeKeepsFirstOccurrence(t *testing.T) {
+	logs := []payroll.WorkLog{
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", ReportId: 42, Line: 2},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", ReportId: 42, Line: 3},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("2"), JobGroup: "A", ReportId: 42, Line: 4},
+	}
+
+	duplicates, keep := payroll.FindDuplicates(payroll.DuplicateRules{
+		Exact:   payroll.DuplicateMerge,
+		Overlap: payroll.DuplicateReject,
+	}, nil, logs)
+
+	assert.Len(t, duplicates, 2)
+	assert.Equal(t, payroll.RuleExact, duplicates[0].Rule)
+	assert.Equal(t, payroll.RuleOverlap, duplicates[1].Rule)
+	assert.Equal(t, payroll.DuplicateReject, duplicates[1].Action)
+	assert.Equal(t, 2, duplicates[1].MatchedLine)
+	assert.Equal(t, []payroll.WorkLog{logs[0], logs[2]}, keep)
+}
+
+func TestFindDuplicates_Off(t *testing.T) {
+	logs := []payroll.WorkLog{
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", Line: 2},
+		{EmployeeId: 2, Date: nov15, HoursLogged: dec("4"), JobGroup: "B", Line: 3},
+	}
+
+	duplicates, keep := payroll.FindDuplicates(payroll.DuplicateRules{
+		Exact:   payroll.DuplicateOff,
+		Overlap: payroll.DuplicateOff,
+	}, nil, logs)
+
+	assert.Empty(t, duplicates)
+	assert.Equal(t, logs, keep)
+}
+
+func TestParseDuplicateAction(t *testing.T) {
+	action, err := payroll.ParseDuplicateAction("")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.DuplicateOff, action)
+
+	action, err = payroll.ParseDuplicateAction("merge")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.DuplicateMerge, action)
+
+	_, err = payroll.ParseDuplicateAction("skip")
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package payroll
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	employeeCols             = "id, name, status, hire_date, termination_date, default_job_group, department, cost_center, created_ts, updated_ts"
+	selectEmployeesQuery     = "select " + employeeCols + " from " + employeeTable + " order by id;"
+	selectEmployeeQuery      = "select " + employeeCols + " from " + employeeTable + " where id = $1;"
+	selectEmployeesByIdQuery = "select " + employeeCols + " from " + employeeTable + " where id = any($1) order by id;"
+	insertEmployeeQuery      = "insert into " + employeeTable + " (id, name, status, hire_date, termination_date, default_job_group, department, cost_center, created_ts, updated_ts) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9);"
+	updateEmployeeQuery      = "update " + employeeTable + " set name = $2, status = $3, hire_date = $4, termination_date = $5, default_job_group = $6, department = $7, cost_center = $8, updated_ts = $9 where id = $1 returning created_ts;"
+	deleteEmployeeQuery      = "delete from " + employeeTable + " where id = $1;"
+)
+
+func scanEmployee(row rowScanner) (Employee, error) {
+	var e Employee
+	var hired, terminated sql.NullTime
+	var group, department, costCenter sql.NullString
+
+	if err := row.Scan(&e.Id, &e.Name, &e.Status, &hired, &terminated, &group, &department, &costCenter, &e.CreatedTs, &e.UpdatedTs); err != nil {
+		return e, err
+	}
+	e.Department, e.CostCenter = nullStringPtr(department), nullStringPtr(costCenter)
+	if hired.Valid {
+		e.HireDate = &hired.Time
+	}
+	if terminated.Valid {
+		e.TerminationDate = &terminated.Time
+	}
+	if group.Valid {
+		g := JobGroup(group.String)
+		e.DefaultJobGroup = &g
+	}
+
+	return e, nil
+}
+
+func queryEmployees(rows *sql.Rows) ([]Employee, error) {
+	defer rows.Close()
+
+	employees := make([]Employee, 0)
+	for rows.Next() {
+		e, err := scanEmployee(rows)
+		if err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return employees, err
+		}
+
+		employees = append(employees, e)
+	}
+
+	return employees, nil
+}
+
+func (r payrollRepository) GetEmployees() ([]Employee, error) {
+	rows, err := r.dbW.DB.Query(selectEmployeesQuery)
+	if err != nil {
+		logrus.Errorf("error while fetching employees: %v", err)
+		return nil, err
+	}
+
+	return queryEmployees(rows)
+}
+
+// GetEmployeesById func returns the registered employees among the ids, reading through the running tx if there's one
+func (r payrollRepository) GetEmployeesById(ids []int) ([]Employee, error) {
+	rows, err := r.dbW.Querier().Query(selectEmployeesByIdQuery, pq.Array(ids))
+	if err != nil {
+		logrus.Errorf("error while fetching employees: %v", err)
+		return nil, err
+	}
+
+	return queryEmployees(rows)
+}
+
+func (r payrollRepository) GetEmployee(id int) (Employee, error) {
+	e, err := scanEmployee(r.dbW.DB.QueryRow(selectEmployeeQuery, id))
+	if err == sql.ErrNoRows {
+		return Employee{}, ErrEmployeeNotFound
+	} else if err != nil {
+		logrus.Errorf("error while fetching employee: %v", err)
+		return Employee{}, err
+	}
+
+	return e, nil
+}
+
+// InsertEmployee func registers an employee, writing through the running tx if there's one
+func (r payrollRepository) InsertEmployee(e Employee) (Employee, error) {
+	e.CreatedTs = time.Now()
+	e.UpdatedTs = e.CreatedTs
+
+	_, err := r.dbW.Querier().Exec(insertEmployeeQuery, e.Id, e.Name, e.Status, e.HireDate, e.TerminationDate, e.DefaultJobGroup, e.Department, e.CostCenter, e.CreatedTs)
+	if isPqError(err, uniqueViolation) {
+		return Employee{}, ErrEmployeeExists
+	} else if isPqError(err, foreignKeyViolation) {
+		return Employee{}, ErrJobGroupNotFound
+	} else if err != nil {
+		logrus.Errorf("error while inserting employee: %v", err)
+		return Employee{}, err
+	}
+
+	return e, nil
+}
+
+// UpdateEmployee func replaces the details of an employee, writing through the running tx if there's one
+func (r payrollRepository) UpdateEmployee(e Employee) (Employee, error) {
+	e.UpdatedTs = time.Now()
+
+	err := r.dbW.Querier().QueryRow(updateEmployeeQuery, e.Id, e.Name, e.Status, e.HireDate, e.TerminationDate, e.DefaultJobGroup, e.Department, e.CostCenter, e.UpdatedTs).
+		Scan(&e.CreatedTs)
+	if err == sql.ErrNoRows {
+		return Employee{}, ErrEmployeeNotFound
+	} else if isPqError(err, foreignKeyViolation) {
+		return Employee{}, ErrJobGroupNotFound
+	} else if err != nil {
+		logrus.Errorf("error while updating employee: %v", err)
+		return Employee{}, err
+	}
+
+	return e, nil
+}
+
+func (r payrollRepository) DeleteEmployee(id int) error {
+	res, err := r.dbW.DB.Exec(deleteEmployeeQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting employee: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
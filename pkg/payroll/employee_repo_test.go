@@ -0,0 +1,127 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var employeeRows = []string{"id", "name", "status", "hire_date", "termination_date", "default_job_group", "department", "cost_center", "created_ts", "updated_ts"}
+
+func TestGetEmployeesById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	group, department, costCenter := payroll.JobGroup("A"), "R&D", "CC-100"
+	hired := time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)
+	mock.ExpectQuery("select (.+) from employees where id = any(.+) order by id;").
+		WithArgs(pq.Array([]int{1, 2, 3})).
+		WillReturnRows(sqlmock.NewRows(employeeRows).
+			AddRow(1, "Ada", "active", hired, nil, "A", "R&D", "CC-100", timeVal, timeVal).
+			AddRow(2, "Bob", "terminated", nil, nil, nil, nil, nil, timeVal, timeVal))
+
+	employees, err := repo.GetEmployeesById([]int{1, 2, 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []payroll.Employee{
+		{Id: 1, Name: "Ada", Status: payroll.EmployeeActive, HireDate: &hired, DefaultJobGroup: &group, Department: &department,
+			CostCenter: &costCenter, CreatedTs: timeVal, UpdatedTs: timeVal},
+		{Id: 2, Name: "Bob", Status: payroll.EmployeeTerminated, CreatedTs: timeVal, UpdatedTs: timeVal},
+	}, employees)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEmployee_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from employees where id = (.+);").WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(employeeRows))
+
+	_, err = repo.GetEmployee(4)
+
+	assert.ErrorIs(t, err, payroll.ErrEmployeeNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertEmployee_Exists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectExec("insert into employees").
+		WithArgs(1, "Ada", payroll.EmployeeActive, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.InsertEmployee(payroll.Employee{Id: 1, Name: "Ada", Status: payroll.EmployeeActive})
+
+	assert.ErrorIs(t, err, payroll.ErrEmployeeExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateEmployee_UnknownJobGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	group := payroll.JobGroup("Z")
+	mock.ExpectQuery("update employees set (.+) where id = (.+) returning created_ts;").
+		WithArgs(1, "Ada", payroll.EmployeeActive, nil, nil, &group, nil, nil, sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	_, err = repo.UpdateEmployee(payroll.Employee{Id: 1, Name: "Ada", Status: payroll.EmployeeActive, DefaultJobGroup: &group})
+
+	assert.ErrorIs(t, err, payroll.ErrJobGroupNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteEmployee_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectExec("delete from employees where id = (.+);").WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteEmployee(4)
+
+	assert.ErrorIs(t, err, payroll.ErrEmployeeNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,117 @@
+package payroll
+
+import (
+	"fmt"
+	"time"
+)
+
+// EmployeeCheck decides what happens to uploaded work logs of unknown, not yet hired or terminated employees
+type EmployeeCheck string
+
+const (
+	// EmployeeCheckOff doesn't check the employees of the work logs
+	EmployeeCheckOff EmployeeCheck = "off"
+	// EmployeeCheckReject fails the upload
+	EmployeeCheckReject EmployeeCheck = "reject"
+	// EmployeeCheckWarn imports the work log and lists it in the upload result
+	EmployeeCheckWarn EmployeeCheck = "warn"
+)
+
+// EmployeeIssueReason is why a work log's employee can't be paid for it
+type EmployeeIssueReason string
+
+const (
+	IssueUnknownEmployee EmployeeIssueReason = "unknown_employee"
+	IssueNotHired        EmployeeIssueReason = "not_hired"
+	IssueTerminated      EmployeeIssueReason = "terminated"
+)
+
+// EmployeeIssue is an uploaded work log of an unknown employee, or dated outside the employee's employment
+type EmployeeIssue struct {
+	Reason     EmployeeIssueReason `json:"reason"`
+	Action     EmployeeCheck       `json:"action"`
+	Line       int                 `json:"line"`
+	EmployeeId int                 `json:"employee_id"`
+	Date       time.Time           `json:"date"`
+}
+
+// ParseEmployeeCheck func converts config value to an employee check, empty value disables the check
+func ParseEmployeeCheck(s string) (EmployeeCheck, error) {
+	switch EmployeeCheck(s) {
+	case "", EmployeeCheckOff:
+		return EmployeeCheckOff, nil
+	case EmployeeCheckReject, EmployeeCheckWarn:
+		return EmployeeCheck(s), nil
+	}
+	return "", fmt.Errorf("unknown employee check: %s", s)
+}
+
+// Employed func returns why the employee can't be paid for work on the date, if they can't. Only the date
+// part is compared, and a terminated employee without a termination date can't be paid for any date
+func (e Employee) Employed(date time.Time) (EmployeeIssueReason, bool) {
+	day := date.Format(time.DateOnly)
+	if e.HireDate != nil && day < e.HireDate.Format(time.DateOnly) {
+		return IssueNotHired, false
+	}
+	if e.TerminationDate != nil && day > e.TerminationDate.Format(time.DateOnly) {
+		return IssueTerminated, false
+	}
+	if e.Status == EmployeeTerminated && e.TerminationDate == nil {
+		return IssueTerminated, false
+	}
+	return "", true
+}
+
+// sameDetails func reports whether the employees have the same details, dates are compared by day
+func (e Employee) sameDetails(o Employee) bool {
+	sameDate := func(a, b *time.Time) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Format(time.DateOnly) == b.Format(time.DateOnly)
+	}
+	sameGroup := e.DefaultJobGroup == nil && o.DefaultJobGroup == nil ||
+		e.DefaultJobGroup != nil && o.DefaultJobGroup != nil && *e.DefaultJobGroup == *o.DefaultJobGroup
+
+	sameString := func(a, b *string) bool {
+		return a == nil && b == nil || a != nil && b != nil && *a == *b
+	}
+
+	return e.Id == o.Id && e.Name == o.Name && e.Status == o.Status && sameGroup &&
+		sameString(e.Department, o.Department) && sameString(e.CostCenter, o.CostCenter) &&
+		sameDate(e.HireDate, o.HireDate) && sameDate(e.TerminationDate, o.TerminationDate)
+}
+
+// CheckEmployees func returns the work logs of unknown employees, and of employees not employed on the
+// log's date, in file order. Nothing is returned unless the check rejects or warns
+func CheckEmployees(check EmployeeCheck, employees []Employee, logs []WorkLog) []EmployeeIssue {
+	issues := make([]EmployeeIssue, 0)
+	if check != EmployeeCheckReject && check != EmployeeCheckWarn {
+		return issues
+	}
+
+	byId := make(map[int]Employee, len(employees))
+	for _, e := range employees {
+		byId[e.Id] = e
+	}
+
+	for _, log := range logs {
+		reason := IssueUnknownEmployee
+		if e, ok := byId[log.EmployeeId]; ok {
+			var employed bool
+			if reason, employed = e.Employed(log.Date); employed {
+				continue
+			}
+		}
+
+		issues = append(issues, EmployeeIssue{
+			Reason:     reason,
+			Action:     check,
+			Line:       log.Line,
+			EmployeeId: log.EmployeeId,
+			Date:       log.Date,
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,112 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmployee_Employed(t *testing.T) {
+	hired, terminated := day(7), day(9)
+	tests := []struct {
+		name     string
+		employee payroll.Employee
+		date     int
+		want     payroll.EmployeeIssueReason
+		employed bool
+	}{
+		{name: "no dates", employee: payroll.Employee{Status: payroll.EmployeeActive}, date: 6, employed: true},
+		{name: "before hire date", employee: payroll.Employee{HireDate: &hired}, date: 6, want: payroll.IssueNotHired},
+		{name: "on hire date", employee: payroll.Employee{HireDate: &hired}, date: 7, employed: true},
+		{name: "on termination date", employee: payroll.Employee{TerminationDate: &terminated}, date: 9, employed: true},
+		{name: "after termination date", employee: payroll.Employee{TerminationDate: &terminated}, date: 10, want: payroll.IssueTerminated},
+		{name: "terminated without date", employee: payroll.Employee{Status: payroll.EmployeeTerminated}, date: 6, want: payroll.IssueTerminated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, employed := tt.employee.Employed(day(tt.date).Add(15 * time.Hour))
+			assert.Equal(t, tt.employed, employed)
+			assert.Equal(t, tt.want, reason)
+		})
+	}
+}
+
+func TestCheckEmployees(t *testing.T) {
+	hired := day(7)
+	employees := []payroll.Employee{
+		{Id: 1, Status: payroll.EmployeeActive},
+		{Id: 2, Status: payroll.EmployeeActive, HireDate: &hired},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), Line: 2},
+		{EmployeeId: 2, Date: day(6), Line: 3},
+		{EmployeeId: 3, Date: day(7), Line: 4},
+		{EmployeeId: 2, Date: day(7), Line: 5},
+	}
+
+	issues := payroll.CheckEmployees(payroll.EmployeeCheckWarn, employees, logs)
+
+	assert.Equal(t, []payroll.EmployeeIssue{
+		{Reason: payroll.IssueNotHired, Action: payroll.EmployeeCheckWarn, Line: 3, EmployeeId: 2, Date: day(6)},
+		{Reason: payroll.IssueUnknownEmployee, Action: payroll.EmployeeCheckWarn, Line: 4, EmployeeId: 3, Date: day(7)},
+	}, issues)
+	assert.Empty(t, payroll.CheckEmployees(payroll.EmployeeCheckOff, nil, logs))
+}
+
+func TestParseEmployeeCheck(t *testing.T) {
+	check, err := payroll.ParseEmployeeCheck("")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.EmployeeCheckOff, check)
+
+	check, err = payroll.ParseEmployeeCheck("reject")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.EmployeeCheckReject, check)
+
+	_, err = payroll.ParseEmployeeCheck("merge")
+	assert.Error(t, err)
+}
+
+func TestImportEmployees(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	hired := time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery("select (.+) from employees where id = any(.+) order by id;").
+		WithArgs(pq.Array([]int{1, 2, 3})).
+		WillReturnRows(sqlmock.NewRows(employeeRows).
+			AddRow(1, "Ada", "active", hired, nil, nil, nil, nil, timeVal, timeVal).
+			AddRow(2, "Bob", "active", nil, nil, nil, nil, nil, timeVal, timeVal))
+	mock.ExpectQuery("update employees set (.+) where id = (.+) returning created_ts;").
+		WithArgs(2, "Bob", payroll.EmployeeTerminated, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"created_ts"}).AddRow(timeVal))
+	mock.ExpectExec("insert into employees").
+		WithArgs(3, "Cy", payroll.EmployeeActive, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// the hire date is read back from the db in utc, it's unchanged as only the day is compared
+	localHired := time.Date(2023, 1, 9, 0, 0, 0, 0, time.Local)
+	res, err := service.ImportEmployees([]payroll.Employee{
+		{Id: 1, Name: " Ada ", HireDate: &localHired},
+		{Id: 2, Name: "Bob", Status: payroll.EmployeeTerminated},
+		{Id: 3, Name: "Cy"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.EmployeeImport{Created: []int{3}, Updated: []int{2}, Unchanged: []int{1}}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
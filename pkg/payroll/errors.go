@@ -5,5 +5,47 @@ import "fmt"
 var (
 	ErrWorkLogCreate  = fmt.Errorf("error while inserting job log/s")
 	ErrFileIdExists   = fmt.Errorf("file id already processed")
+	ErrReportNotFound = fmt.Errorf("report id not processed")
+	ErrDuplicateLogs  = fmt.Errorf("duplicate work logs found")
 	ErrReportGenerate = fmt.Errorf("error while generating the report")
+	ErrReportDelete   = fmt.Errorf("error while deleting the report")
+	ErrUploadNotFound = fmt.Errorf("upload job not found")
+	ErrUploadEnqueue  = fmt.Errorf("error while queueing the upload")
+	// ErrUploadReclaimed is returned to a worker whose upload job was claimed by another worker
+	ErrUploadReclaimed = fmt.Errorf("upload job was claimed by another worker")
+
+	ErrJobGroupNotFound = fmt.Errorf("job group not found")
+	ErrJobGroupExists   = fmt.Errorf("job group already exists")
+	ErrJobGroupInUse    = fmt.Errorf("job group has work logs")
+	ErrInvalidJobGroup  = fmt.Errorf("invalid job group")
+	ErrInvalidRate      = fmt.Errorf("invalid rate")
+
+	ErrInvalidEffectiveDate = fmt.Errorf("effective date must be after the start of the latest rate")
+
+	ErrRateOverrideNotFound = fmt.Errorf("rate override not found")
+	ErrRateOverrideOverlaps = fmt.Errorf("rate override overlaps another override of the employee")
+	ErrInvalidRateOverride  = fmt.Errorf("invalid rate override")
+
+	ErrCalendarNotFound = fmt.Errorf("holiday calendar not found")
+	ErrHolidayNotFound  = fmt.Errorf("holiday not found")
+	ErrInvalidHoliday   = fmt.Errorf("invalid holiday")
+
+	ErrEmployeeNotFound = fmt.Errorf("employee not found")
+	ErrEmployeeExists   = fmt.Errorf("employee already exists")
+	ErrInvalidEmployee  = fmt.Errorf("invalid employee")
+	ErrEmployeeLogs     = fmt.Errorf("work logs of unknown or inactive employees found")
+
+	ErrPeriodNotFound          = fmt.Errorf("pay period not found")
+	ErrPeriodOverlaps          = fmt.Errorf("pay period overlaps another pay period")
+	ErrInvalidPeriod           = fmt.Errorf("invalid pay period")
+	ErrInvalidPeriodTransition = fmt.Errorf("pay period can't change to the state")
+	ErrPeriodLocked            = fmt.Errorf("pay period isn't open")
+	ErrClosedPeriodLogs        = fmt.Errorf("work logs dated in closed pay periods found")
+	ErrReportPeriodClosed      = fmt.Errorf("report has work logs in closed pay periods")
+	ErrOverlappingPeriodLogs   = fmt.Errorf("work logs paid in overlapping pay periods found")
+
+	ErrPayScheduleNotFound        = fmt.Errorf("pay schedule not found")
+	ErrScheduleAssignmentNotFound = fmt.Errorf("pay schedule assignment not found")
+	ErrScheduleAssignmentExists   = fmt.Errorf("employee already switches pay schedule on the date")
+	ErrInvalidScheduleAssignment  = fmt.Errorf("invalid pay schedule assignment")
 )
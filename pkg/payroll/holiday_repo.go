@@ -0,0 +1,129 @@
+package payroll
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	selectCalendarsQuery = "select calendar, count(*) from " + holidayTable + " group by calendar order by calendar;"
+	selectHolidaysQuery  = "select calendar, holiday_date, name from " + holidayTable + " where calendar = $1 order by holiday_date;"
+	upsertHolidayQuery   = "insert into " + holidayTable + " (calendar, holiday_date, name, created_ts) values ($1, $2, $3, $4) on conflict (calendar, holiday_date) do update set name = excluded.name;"
+	deleteHolidayQuery   = "delete from " + holidayTable + " where calendar = $1 and holiday_date = $2;"
+	deleteCalendarQuery  = "delete from " + holidayTable + " where calendar = $1;"
+)
+
+func (r payrollRepository) GetHolidayCalendars() ([]HolidayCalendar, error) {
+	calendars := make([]HolidayCalendar, 0)
+
+	rows, err := r.dbW.DB.Query(selectCalendarsQuery)
+	if err != nil {
+		logrus.Errorf("error while fetching holiday calendars: %v", err)
+		return calendars, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var c HolidayCalendar
+
+		if err := rows.Scan(&c.Name, &c.Holidays); err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return calendars, err
+		}
+
+		calendars = append(calendars, c)
+	}
+
+	return calendars, nil
+}
+
+// GetHolidays func returns the holidays of the calendar by date, an unknown calendar has no holidays
+func (r payrollRepository) GetHolidays(calendar string) ([]Holiday, error) {
+	holidays := make([]Holiday, 0)
+
+	rows, err := r.dbW.Querier().Query(selectHolidaysQuery, calendar)
+	if err != nil {
+		logrus.Errorf("error while fetching holidays: %v", err)
+		return holidays, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var h Holiday
+
+		if err := rows.Scan(&h.Calendar, &h.Date, &h.Name); err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return holidays, err
+		}
+
+		holidays = append(holidays, h)
+	}
+
+	return holidays, nil
+}
+
+// SaveHoliday func adds a holiday to its calendar, or renames the holiday on the same date
+func (r payrollRepository) SaveHoliday(h Holiday) error {
+	if _, err := r.dbW.DB.Exec(upsertHolidayQuery, h.Calendar, h.Date, h.Name, time.Now()); err != nil {
+		logrus.Errorf("error while saving holiday: %v", err)
+		return err
+	}
+	return nil
+}
+
+// ReplaceHolidays func replaces every holiday of the calendar
+func (r payrollRepository) ReplaceHolidays(calendar string, holidays []Holiday) error {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return fmt.Errorf("no tx running")
+	}
+
+	if _, err := r.dbW.Tx.Exec(deleteCalendarQuery, calendar); err != nil {
+		logrus.Errorf("error while deleting holidays: %v", err)
+		r.dbW.Tx.Rollback()
+		return err
+	}
+
+	now := time.Now()
+	for _, h := range holidays {
+		if _, err := r.dbW.Tx.Exec(upsertHolidayQuery, calendar, h.Date, h.Name, now); err != nil {
+			logrus.Errorf("error while inserting holiday: %v", err)
+			r.dbW.Tx.Rollback()
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r payrollRepository) DeleteHoliday(calendar string, date time.Time) error {
+	res, err := r.dbW.DB.Exec(deleteHolidayQuery, calendar, date)
+	if err != nil {
+		logrus.Errorf("error while deleting holiday: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrHolidayNotFound
+	}
+
+	return nil
+}
+
+func (r payrollRepository) DeleteHolidayCalendar(calendar string) error {
+	res, err := r.dbW.DB.Exec(deleteCalendarQuery, calendar)
+	if err != nil {
+		logrus.Errorf("error while deleting holiday calendar: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCalendarNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceHolidays(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	christmas := time.Date(2023, 12, 25, 0, 0, 0, 0, time.Local)
+	mock.ExpectExec("delete from holidays where calendar = (.+);").
+		WithArgs("ca").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("insert into holidays (.+) values (.+) on conflict (.+) do update set name = excluded.name;").
+		WithArgs("ca", christmas, "Christmas Day", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.ReplaceHolidays("ca", []payroll.Holiday{{Calendar: "ca", Date: christmas, Name: "Christmas Day"}})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteHoliday_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	christmas := time.Date(2023, 12, 25, 0, 0, 0, 0, time.Local)
+	mock.ExpectExec("delete from holidays where calendar = (.+) and holiday_date = (.+);").
+		WithArgs("ca", christmas).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteHoliday("ca", christmas)
+
+	assert.ErrorIs(t, err, payroll.ErrHolidayNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,122 @@
+package payroll
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HoursRoundingMode decides which way hours are rounded to the policy's increment
+type HoursRoundingMode string
+
+const (
+	// HoursNearest rounds to the nearest increment, halves are rounded up
+	HoursNearest HoursRoundingMode = "nearest"
+	HoursUp      HoursRoundingMode = "up"
+	HoursDown    HoursRoundingMode = "down"
+)
+
+// HoursPolicy rounds a work log's hours to an increment, eg. 0.25 for quarter hours or 0.1 for 6 minutes,
+// and caps the hours paid per day. A zero increment keeps the hours, and a zero cap doesn't cap them
+type HoursPolicy struct {
+	Increment decimal.Decimal
+	Mode      HoursRoundingMode
+	DailyCap  decimal.Decimal
+}
+
+// HoursRounding holds the hours policy of each job group, groups without their own policy use Default
+type HoursRounding struct {
+	Default   HoursPolicy
+	JobGroups map[JobGroup]HoursPolicy
+}
+
+// ParseHoursRoundingMode func converts config value to an hours rounding mode, empty value is nearest
+func ParseHoursRoundingMode(s string) (HoursRoundingMode, error) {
+	switch HoursRoundingMode(s) {
+	case "", HoursNearest:
+		return HoursNearest, nil
+	case HoursUp, HoursDown:
+		return HoursRoundingMode(s), nil
+	}
+	return "", fmt.Errorf("unknown hours rounding mode: %s", s)
+}
+
+// PaidHours func returns the hours the log is paid for, its rounded hours once the hours policy is applied
+func (l WorkLog) PaidHours() decimal.Decimal {
+	if l.RoundedHours != nil {
+		return *l.RoundedHours
+	}
+	return l.HoursLogged
+}
+
+// Policy func returns the hours policy of the job group
+func (r HoursRounding) Policy(group JobGroup) HoursPolicy {
+	if p, ok := r.JobGroups[group]; ok {
+		return p
+	}
+	return r.Default
+}
+
+// Round func rounds the hours to the policy's increment
+func (p HoursPolicy) Round(hours decimal.Decimal) decimal.Decimal {
+	if !p.Increment.IsPositive() {
+		return hours
+	}
+
+	increments := hours.Div(p.Increment)
+	switch p.Mode {
+	case HoursUp:
+		increments = increments.Ceil()
+	case HoursDown:
+		increments = increments.Floor()
+	default:
+		increments = increments.Round(0)
+	}
+	return increments.Mul(p.Increment)
+}
+
+// RoundHours func sets the rounded hours of each log from its raw hours, keeping the order of the logs. Logs are
+// counted in date order, and in file order within a day, so the hours worked last in a day are the ones cut
+// by the daily cap of their job group's policy. Adjustments are counted last. Logs already rounded on upload
+// keep their stored hours, so a policy change doesn't alter past pay, and count towards the daily cap as they are
+func (r HoursRounding) RoundHours(logs []WorkLog) []WorkLog {
+	order := make([]int, len(logs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return countedBefore(logs[order[i]], logs[order[j]])
+	})
+
+	type key struct {
+		employeeId int
+		date       string
+	}
+
+	dayHours := make(map[key]decimal.Decimal)
+	res := make([]WorkLog, len(logs))
+	for _, i := range order {
+		log := logs[i]
+		policy := r.Policy(log.JobGroup)
+		day := key{log.EmployeeId, log.Date.Format(time.DateOnly)}
+
+		if log.RoundedHours != nil {
+			dayHours[day] = dayHours[day].Add(*log.RoundedHours)
+			res[i] = log
+			continue
+		}
+
+		hours := policy.Round(log.HoursLogged)
+		if policy.DailyCap.IsPositive() {
+			hours = decimal.Max(decimal.Zero, decimal.Min(hours, policy.DailyCap.Sub(dayHours[day])))
+		}
+		dayHours[day] = dayHours[day].Add(hours)
+
+		log.RoundedHours = &hours
+		res[i] = log
+	}
+
+	return res
+}
@@ -0,0 +1,104 @@
+package payroll_test
+
+import (
+	"testing"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoursPolicy_Round(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy payroll.HoursPolicy
+		hours  string
+		want   string
+	}{
+		{name: "nearest quarter", policy: payroll.HoursPolicy{Increment: dec("0.25"), Mode: payroll.HoursNearest}, hours: "7.1", want: "7"},
+		{name: "nearest quarter half", policy: payroll.HoursPolicy{Increment: dec("0.25"), Mode: payroll.HoursNearest}, hours: "7.125", want: "7.25"},
+		{name: "up to 6 minutes", policy: payroll.HoursPolicy{Increment: dec("0.1"), Mode: payroll.HoursUp}, hours: "7.01", want: "7.1"},
+		{name: "down to the hour", policy: payroll.HoursPolicy{Increment: dec("1"), Mode: payroll.HoursDown}, hours: "7.9", want: "7"},
+		{name: "no increment", policy: payroll.HoursPolicy{}, hours: "7.123", want: "7.123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Round(dec(tt.hours)).String())
+		})
+	}
+}
+
+func TestHoursRounding_RoundHours(t *testing.T) {
+	rounding := payroll.HoursRounding{
+		Default: payroll.HoursPolicy{Increment: dec("0.25"), Mode: payroll.HoursNearest, DailyCap: dec("10")},
+		JobGroups: map[payroll.JobGroup]payroll.HoursPolicy{
+			"B": {Increment: dec("0.1"), Mode: payroll.HoursUp},
+		},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("4.2"), JobGroup: "A", Line: 3},
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("7.9"), JobGroup: "A", Line: 2},
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("1.01"), JobGroup: "B", Line: 4},
+		{EmployeeId: 2, Date: day(6), HoursLogged: dec("7.9"), JobGroup: "A", Line: 5},
+	}
+
+	rounded := rounding.RoundHours(logs)
+
+	// line 2 is counted first, so line 3 is cut to the 2 hours left under the cap
+	paid := make([]string, 0, len(rounded))
+	for i, log := range rounded {
+		assert.Equal(t, logs[i].HoursLogged, log.HoursLogged)
+		paid = append(paid, log.PaidHours().String())
+	}
+	assert.Equal(t, []string{"2", "8", "1.1", "8"}, paid)
+	assert.Nil(t, logs[0].RoundedHours)
+}
+
+func TestGenerateReport_HoursRounding(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	cfg := payroll.ReportConfig{
+		Hours: payroll.HoursRounding{Default: payroll.HoursPolicy{Increment: dec("0.25"), Mode: payroll.HoursUp}},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("7.8"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateReport(cfg, rates, logs)
+
+	assert.Len(t, report.EmployeeReports, 1)
+	assertEqualDecimals(t, dec("8"), report.EmployeeReports[0].LineItems[0].Hours)
+	assertEqualDecimals(t, dec("160"), report.EmployeeReports[0].AmountPaid)
+}
+
+func TestGenerateReport_StoredRoundedHours(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	// the policy changed since the first log was uploaded, it's still paid its stored hours
+	cfg := payroll.ReportConfig{
+		Hours: payroll.HoursRounding{Default: payroll.HoursPolicy{Increment: dec("1"), Mode: payroll.HoursUp, DailyCap: dec("10")}},
+	}
+	stored := dec("7.75")
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("7.8"), RoundedHours: &stored, JobGroup: "A", Line: 2},
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("3"), JobGroup: "A", Line: 3},
+	}
+
+	report := payroll.GenerateReport(cfg, rates, logs)
+
+	// the stored hours count towards the daily cap, the log without them is rounded with the current policy
+	assert.Len(t, report.EmployeeReports, 1)
+	assertEqualDecimals(t, dec("10"), report.EmployeeReports[0].LineItems[0].Hours)
+	assertEqualDecimals(t, dec("200"), report.EmployeeReports[0].AmountPaid)
+}
+
+func TestParseHoursRoundingMode(t *testing.T) {
+	mode, err := payroll.ParseHoursRoundingMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.HoursNearest, mode)
+
+	mode, err = payroll.ParseHoursRoundingMode("up")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.HoursUp, mode)
+
+	_, err = payroll.ParseHoursRoundingMode("ceil")
+	assert.Error(t, err)
+}
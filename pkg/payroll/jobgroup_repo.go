@@ -0,0 +1,209 @@
+package payroll
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// postgres error codes
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	exclusionViolation  = "23P01"
+)
+
+var (
+	selectJobGroupsQuery     = "select job_group from " + groupsTable + " order by job_group;"
+	selectJobGroupRatesQuery = "select job_group, version, rate, effective_from, effective_to from " + jobgroupTable + " where job_group = $1 order by version;"
+	selectLatestRateQuery    = "select job_group, version, rate, effective_from, effective_to from " + jobgroupTable + " where job_group = $1 order by version desc limit 1 for update;"
+	insertJobGroupQuery      = "insert into " + groupsTable + " (job_group, created_ts) values ($1, $2);"
+	insertJobGroupRateQuery  = "insert into " + jobgroupTable + " (job_group, version, rate, effective_from, effective_to, created_ts) values ($1, $2, $3, $4, $5, $6);"
+	endJobGroupRateQuery     = "update " + jobgroupTable + " set effective_to = $3 where job_group = $1 and version = $2;"
+	deleteJobGroupRatesQuery = "delete from " + jobgroupTable + " where job_group = $1;"
+	deleteJobGroupQuery      = "delete from " + groupsTable + " where job_group = $1;"
+)
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJobGroupRate(row rowScanner) (JobGroupRate, error) {
+	var j JobGroupRate
+	var from, to sql.NullTime
+
+	if err := row.Scan(&j.JobGroup, &j.Version, &j.Rate, &from, &to); err != nil {
+		return j, err
+	}
+	if from.Valid {
+		j.EffectiveFrom = &from.Time
+	}
+	if to.Valid {
+		j.EffectiveTo = &to.Time
+	}
+
+	return j, nil
+}
+
+func (r payrollRepository) GetJobGroups() ([]JobGroup, error) {
+	groups := make([]JobGroup, 0)
+
+	rows, err := r.dbW.DB.Query(selectJobGroupsQuery)
+	if err != nil {
+		logrus.Errorf("error while fetching job groups: %v", err)
+		return groups, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var g JobGroup
+
+		if err := rows.Scan(&g); err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return groups, err
+		}
+
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// GetJobGroupRateHistory func returns every rate version of a job group, oldest first
+func (r payrollRepository) GetJobGroupRateHistory(group JobGroup) ([]JobGroupRate, error) {
+	gr := make([]JobGroupRate, 0)
+
+	rows, err := r.dbW.DB.Query(selectJobGroupRatesQuery, group)
+	if err != nil {
+		logrus.Errorf("error while fetching job group rates: %v", err)
+		return gr, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		j, err := scanJobGroupRate(rows)
+		if err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return gr, err
+		}
+
+		gr = append(gr, j)
+	}
+
+	if len(gr) == 0 {
+		return gr, ErrJobGroupNotFound
+	}
+
+	return gr, nil
+}
+
+// InsertJobGroup func creates a job group with its first rate version
+func (r payrollRepository) InsertJobGroup(j JobGroupRate) error {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return fmt.Errorf("no tx running")
+	}
+
+	now := time.Now()
+	if _, err := r.dbW.Tx.Exec(insertJobGroupQuery, j.JobGroup, now); isPqError(err, uniqueViolation) {
+		r.dbW.Tx.Rollback()
+		return ErrJobGroupExists
+	} else if err != nil {
+		logrus.Errorf("error while inserting job group: %v", err)
+		r.dbW.Tx.Rollback()
+		return err
+	}
+
+	if _, err := r.dbW.Tx.Exec(insertJobGroupRateQuery, j.JobGroup, j.Version, j.Rate, j.EffectiveFrom, j.EffectiveTo, now); err != nil {
+		logrus.Errorf("error while inserting job group rate: %v", err)
+		r.dbW.Tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// AddJobGroupRate func ends the latest rate version of the group the day before the new rate's
+// effective date, and inserts the new rate as the next version
+func (r payrollRepository) AddJobGroupRate(j JobGroupRate) (JobGroupRate, error) {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return JobGroupRate{}, fmt.Errorf("no tx running")
+	}
+
+	latest, err := scanJobGroupRate(r.dbW.Tx.QueryRow(selectLatestRateQuery, j.JobGroup))
+	if err == sql.ErrNoRows {
+		r.dbW.Tx.Rollback()
+		return JobGroupRate{}, ErrJobGroupNotFound
+	} else if err != nil {
+		logrus.Errorf("error while fetching job group rate: %v", err)
+		r.dbW.Tx.Rollback()
+		return JobGroupRate{}, err
+	}
+
+	// rates can only change on a day after the latest version started, earlier periods keep their rate
+	if j.EffectiveFrom == nil || (latest.EffectiveFrom != nil && daysBetween(*latest.EffectiveFrom, *j.EffectiveFrom) <= 0) {
+		r.dbW.Tx.Rollback()
+		return JobGroupRate{}, ErrInvalidEffectiveDate
+	}
+
+	endDate := j.EffectiveFrom.AddDate(0, 0, -1)
+	if _, err := r.dbW.Tx.Exec(endJobGroupRateQuery, latest.JobGroup, latest.Version, endDate); err != nil {
+		logrus.Errorf("error while ending job group rate: %v", err)
+		r.dbW.Tx.Rollback()
+		return JobGroupRate{}, err
+	}
+
+	j.Version = latest.Version + 1
+	j.EffectiveTo = nil
+	if _, err := r.dbW.Tx.Exec(insertJobGroupRateQuery, j.JobGroup, j.Version, j.Rate, j.EffectiveFrom, nil, time.Now()); err != nil {
+		logrus.Errorf("error while inserting job group rate: %v", err)
+		r.dbW.Tx.Rollback()
+		return JobGroupRate{}, err
+	}
+
+	return j, nil
+}
+
+// DeleteJobGroup func deletes a job group with its rates, groups referenced by work logs can't be deleted
+func (r payrollRepository) DeleteJobGroup(group JobGroup) error {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return fmt.Errorf("no tx running")
+	}
+
+	if _, err := r.dbW.Tx.Exec(deleteJobGroupRatesQuery, group); err != nil {
+		logrus.Errorf("error while deleting job group rates: %v", err)
+		r.dbW.Tx.Rollback()
+		return err
+	}
+
+	res, err := r.dbW.Tx.Exec(deleteJobGroupQuery, group)
+	if isPqError(err, foreignKeyViolation) {
+		r.dbW.Tx.Rollback()
+		return ErrJobGroupInUse
+	} else if err != nil {
+		logrus.Errorf("error while deleting job group: %v", err)
+		r.dbW.Tx.Rollback()
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		r.dbW.Tx.Rollback()
+		return ErrJobGroupNotFound
+	}
+
+	return nil
+}
+
+func isPqError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
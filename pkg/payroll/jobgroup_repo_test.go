@@ -0,0 +1,178 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertJobGroup_Exists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectExec("insert into job_groups (.+) values (.+);").
+		WithArgs("C", sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	err = repo.InsertJobGroup(payroll.JobGroupRate{JobGroup: "C", Version: 1, Rate: dec("40")})
+
+	assert.ErrorIs(t, err, payroll.ErrJobGroupExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetJobGroupRateHistory_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from jobgroup_rate where job_group = (.+) order by version;").
+		WithArgs("C").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}))
+
+	_, err = repo.GetJobGroupRateHistory("C")
+
+	assert.ErrorIs(t, err, payroll.ErrJobGroupNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddJobGroupRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	from := time.Date(2023, 11, 16, 0, 0, 0, 0, time.Local)
+	mock.ExpectQuery("select (.+) from jobgroup_rate where job_group = (.+) order by version desc limit 1 for update;").
+		WithArgs("A").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).
+			AddRow("A", 1, 20, nil, nil))
+	mock.ExpectExec("update jobgroup_rate set effective_to = (.+) where job_group = (.+) and version = (.+);").
+		WithArgs("A", 1, from.AddDate(0, 0, -1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("insert into jobgroup_rate (.+) values (.+);").
+		WithArgs("A", 2, dec("25"), from, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rate, err := repo.AddJobGroupRate(payroll.JobGroupRate{JobGroup: "A", Rate: dec("25"), EffectiveFrom: &from})
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.JobGroupRate{JobGroup: "A", Version: 2, Rate: dec("25"), EffectiveFrom: &from}, rate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddJobGroupRate_InvalidEffectiveDate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	current := time.Date(2023, 11, 16, 0, 0, 0, 0, time.Local)
+	from := current.AddDate(0, 0, -1)
+	mock.ExpectQuery("select (.+) from jobgroup_rate where job_group = (.+) order by version desc limit 1 for update;").
+		WithArgs("A").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).
+			AddRow("A", 2, 25, current, nil))
+	mock.ExpectRollback()
+
+	_, err = repo.AddJobGroupRate(payroll.JobGroupRate{JobGroup: "A", Rate: dec("30"), EffectiveFrom: &from})
+
+	assert.ErrorIs(t, err, payroll.ErrInvalidEffectiveDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeJobGroupRate_SameDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	// the rate already changed today, a second change later in the day would leave two versions in force
+	today := time.Date(2023, 11, 16, 0, 0, 0, 0, time.Local)
+	later := today.Add(14 * time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery("select (.+) from jobgroup_rate where job_group = (.+) order by version desc limit 1 for update;").
+		WithArgs("A").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).
+			AddRow("A", 2, 25, today, nil))
+	mock.ExpectRollback()
+
+	_, err = service.ChangeJobGroupRate(payroll.JobGroupRate{JobGroup: "A", Rate: dec("30"), EffectiveFrom: &later})
+
+	assert.ErrorIs(t, err, payroll.ErrInvalidEffectiveDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteJobGroup_InUse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectExec("delete from jobgroup_rate where job_group = (.+);").
+		WithArgs("A").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("delete from job_groups where job_group = (.+);").
+		WithArgs("A").
+		WillReturnError(&pq.Error{Code: "23503"})
+	mock.ExpectRollback()
+
+	err = repo.DeleteJobGroup("A")
+
+	assert.ErrorIs(t, err, payroll.ErrJobGroupInUse)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
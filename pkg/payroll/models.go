@@ -1,19 +1,89 @@
 package payroll
 
-import "time"
+import (
+	"time"
 
-type JobGroup string
-
-const (
-	GroupA JobGroup = "A"
-	GroupB JobGroup = "B"
+	"github.com/shopspring/decimal"
 )
 
+// JobGroup is the name of a job group, groups and their rates are managed in jobgroup_rate
+type JobGroup string
+
 type WorkLog struct {
-	EmployeeId  int
-	JobGroup    JobGroup
-	Date        time.Time
-	HoursLogged float64
+	Id         uint64
+	EmployeeId int
+	JobGroup   JobGroup
+	Date       time.Time
+	// HoursLogged are the raw hours of the time report, RoundedHours the hours paid once the hours policy is
+	// applied. RoundedHours is nil for logs inserted before hours were rounded
+	HoursLogged  decimal.Decimal
+	RoundedHours *decimal.Decimal
+	// ReportId, ReportVersion and Line trace the log back to the csv row of the time report it was inserted from
+	ReportId      int
+	ReportVersion int
+	Line          int
+	UploadedTs    time.Time
+	RetiredTs     *time.Time
+	// CostCenter is the cost center the log's pay is allocated to, from the time report or the employee's
+	// cost center, and Department the employee's department when the log was inserted. Nil is unallocated
+	CostCenter *string
+	Department *string
+	// Adjustment is set on logs dated in a closed pay period, inserted as they were posted as adjustments. They're
+	// paid in the pay period of their upload date instead of the closed one
+	Adjustment bool
+}
+
+// WorkLogFilter selects the work logs returned by GetWorkLogs, nil fields aren't filtered on
+type WorkLogFilter struct {
+	EmployeeId     *int
+	ReportId       *int
+	StartDate      *time.Time
+	EndDate        *time.Time
+	IncludeRetired bool
+	Limit          uint64
+	Offset         uint64
+}
+
+type InsertOptions struct {
+	// Amend replaces the latest version of an already processed report, instead of failing
+	Amend bool
+	// UploadedTs is when the time report was uploaded, defaults to the insert time
+	UploadedTs time.Time
+	// Adjustment accepts work logs dated in closed pay periods, they're inserted as adjustments
+	Adjustment bool
+	// Job is the upload job the logs are inserted for, the insert is refused if another worker claimed it since
+	Job *UploadJob
+}
+
+type InsertResult struct {
+	ReportId      int
+	ReportVersion int
+	// Inserted is the number of work logs inserted, merged duplicates aren't
+	Inserted       int
+	Duplicates     []Duplicate
+	EmployeeIssues []EmployeeIssue
+	// ClosedPeriodLogs are the rows of work logs dated in closed pay periods, when not posted as adjustments
+	ClosedPeriodLogs []RowError
+	// OverlappingPeriodLogs are the rows of work logs paid in a pay period overlapping another one of the employee
+	OverlappingPeriodLogs []RowError
+}
+
+// ReportVersion is a processed version of a time report, superseded versions are kept for audit
+type ReportVersion struct {
+	ReportId     int
+	Version      int
+	CreatedTs    time.Time
+	SupersededTs *time.Time
+	WorkLogs     int
+}
+
+// ReportDeletion records who deleted a processed report, and what was deleted with it
+type ReportDeletion struct {
+	ReportId  int
+	Versions  int
+	WorkLogs  int
+	DeletedBy string
+	DeletedTs time.Time
 }
 
 type PayrollReport struct {
@@ -23,7 +93,31 @@ type PayrollReport struct {
 type EmployeeReport struct {
 	EmployeeId int
 	PayPeriod  PayPeriod
-	AmountPaid float64
+	AmountPaid decimal.Decimal
+	LineItems  []LineItem
+	Buckets    []BucketTotal
+	// Premiums are paid on top of the line items for hours worked on holidays and weekends
+	Premiums []PremiumLine
+	// Adjustments are paid for hours of closed pay periods posted late, on top of the line items and premiums
+	Adjustments []Adjustment
+}
+
+// ReportChange is the difference an upload makes to an employee's pay period
+type ReportChange struct {
+	EmployeeId   int
+	PayPeriod    PayPeriod
+	AmountBefore decimal.Decimal
+	AmountAfter  decimal.Decimal
+	Difference   decimal.Decimal
+}
+
+type ReportPreview struct {
+	ReportVersion         int
+	Changes               []ReportChange
+	Duplicates            []Duplicate
+	EmployeeIssues        []EmployeeIssue
+	ClosedPeriodLogs      []RowError
+	OverlappingPeriodLogs []RowError
 }
 
 type PayPeriod struct {
@@ -31,7 +125,151 @@ type PayPeriod struct {
 	EndDate   time.Time
 }
 
+// JobGroupRate is a version of a job group's hourly rate, in force between its effective dates
 type JobGroupRate struct {
 	JobGroup JobGroup
-	Rate     float64
+	// Version increases with every rate change of the group
+	Version int
+	Rate    decimal.Decimal
+	// EffectiveFrom and EffectiveTo are inclusive, nil is unbounded
+	EffectiveFrom *time.Time
+	EffectiveTo   *time.Time
+}
+
+// RateOverride is an employee's hourly rate replacing the job group rate between its effective dates.
+// An override without a job group applies to every group the employee works in
+type RateOverride struct {
+	Id         int
+	EmployeeId int
+	JobGroup   *JobGroup
+	Rate       decimal.Decimal
+	// EffectiveFrom and EffectiveTo are inclusive, nil is unbounded
+	EffectiveFrom *time.Time
+	EffectiveTo   *time.Time
+	CreatedTs     time.Time
+}
+
+// ScheduleAssignment moves an employee to a named pay schedule from its effective date until their next
+// assignment, in place of the schedule of their job groups
+type ScheduleAssignment struct {
+	Id            int
+	EmployeeId    int
+	Schedule      string
+	EffectiveFrom time.Time
+	CreatedTs     time.Time
+}
+
+// RateSource is where the rate a work log was priced at came from
+type RateSource string
+
+const (
+	// SourceJobGroup is the rate of the job group
+	SourceJobGroup RateSource = "job_group"
+	// SourceEmployee is an employee override for every job group
+	SourceEmployee RateSource = "employee"
+	// SourceEmployeeJobGroup is an employee override for the job group
+	SourceEmployeeJobGroup RateSource = "employee_job_group"
+	// SourceNone is used when no rate is in force on the work log's date
+	SourceNone RateSource = "none"
+)
+
+// LineItem is the pay of an employee's hours priced at the same rate within a pay period
+type LineItem struct {
+	JobGroup JobGroup
+	Source   RateSource
+	// RateVersion is the job group rate version, OverrideId the employee override the hours were priced at
+	RateVersion int
+	OverrideId  int
+	Rate        decimal.Decimal
+	// Bucket is the overtime category of the hours, paid at the rate times the multiplier
+	Bucket     HoursBucket
+	Multiplier decimal.Decimal
+	Hours      decimal.Decimal
+	Amount     decimal.Decimal
+}
+
+// EmployeeStatus is whether an employee still works for the company
+type EmployeeStatus string
+
+const (
+	EmployeeActive     EmployeeStatus = "active"
+	EmployeeTerminated EmployeeStatus = "terminated"
+)
+
+// Employee is a person work logs are uploaded for, identified by the employee id of the time reports
+type Employee struct {
+	Id     int
+	Name   string
+	Status EmployeeStatus
+	// HireDate and TerminationDate are inclusive, nil is unbounded
+	HireDate        *time.Time
+	TerminationDate *time.Time
+	DefaultJobGroup *JobGroup
+	// Department is the employee's home department, and CostCenter the cost center their work logs are
+	// allocated to when the time report doesn't set one
+	Department *string
+	CostCenter *string
+	CreatedTs  time.Time
+	UpdatedTs  time.Time
+}
+
+// EmployeeImport lists the ids of the employees an import created, updated, and left unchanged
+type EmployeeImport struct {
+	Created   []int
+	Updated   []int
+	Unchanged []int
+}
+
+// Holiday is a public holiday of a holiday calendar
+type Holiday struct {
+	Calendar string
+	Date     time.Time
+	Name     string
+}
+
+// HolidayCalendar is a named set of holidays, eg. of a country or state
+type HolidayCalendar struct {
+	Name     string
+	Holidays int
+}
+
+// RowError describes why a row of an uploaded file was rejected
+type RowError struct {
+	Line   int    `json:"line"`
+	Column string `json:"column"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+type UploadStatus string
+
+const (
+	UploadQueued     UploadStatus = "queued"
+	UploadProcessing UploadStatus = "processing"
+	UploadSucceeded  UploadStatus = "succeeded"
+	UploadFailed     UploadStatus = "failed"
+)
+
+// UploadJob is a time report waiting to be, or already, processed by the upload workers
+type UploadJob struct {
+	Id             string
+	ReportId       int
+	ReportVersion  int
+	Amend          bool
+	Adjustment     bool
+	Filename       string
+	Profile        string
+	Payload        []byte
+	Status         UploadStatus
+	Message        string
+	RowsTotal      int
+	RowsImported   int
+	RowsRejected   int
+	Errors         []RowError
+	Duplicates     []Duplicate
+	EmployeeIssues []EmployeeIssue
+	// Attempt is the number of times the job was claimed, a worker only owns the job while it holds the latest claim
+	Attempt   int
+	CreatedTs time.Time
+	UpdatedTs time.Time
 }
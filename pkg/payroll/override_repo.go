@@ -0,0 +1,129 @@
+package payroll
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	overrideCols                 = "id, employee_id, job_group, rate, effective_from, effective_to, created_ts"
+	selectRateOverridesQuery     = "select " + overrideCols + " from " + overrideTable + " order by employee_id, id;"
+	selectEmployeeOverridesQuery = "select " + overrideCols + " from " + overrideTable + " where employee_id = $1 order by id;"
+	lockEmployeeOverridesQuery   = "select " + overrideCols + " from " + overrideTable + " where employee_id = $1 order by id for update;"
+	insertRateOverrideQuery      = "insert into " + overrideTable + " (employee_id, job_group, rate, effective_from, effective_to, created_ts) values ($1, $2, $3, $4, $5, $6) returning id;"
+	deleteRateOverrideQuery      = "delete from " + overrideTable + " where id = $1;"
+)
+
+func scanRateOverride(row rowScanner) (RateOverride, error) {
+	var o RateOverride
+	var group sql.NullString
+	var from, to sql.NullTime
+
+	if err := row.Scan(&o.Id, &o.EmployeeId, &group, &o.Rate, &from, &to, &o.CreatedTs); err != nil {
+		return o, err
+	}
+	if group.Valid {
+		g := JobGroup(group.String)
+		o.JobGroup = &g
+	}
+	if from.Valid {
+		o.EffectiveFrom = &from.Time
+	}
+	if to.Valid {
+		o.EffectiveTo = &to.Time
+	}
+
+	return o, nil
+}
+
+func queryRateOverrides(rows *sql.Rows) ([]RateOverride, error) {
+	defer rows.Close()
+
+	overrides := make([]RateOverride, 0)
+	for rows.Next() {
+		o, err := scanRateOverride(rows)
+		if err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return overrides, err
+		}
+
+		overrides = append(overrides, o)
+	}
+
+	return overrides, nil
+}
+
+// GetRateOverrides func returns the rate overrides of the employee, or of every employee if not set
+func (r payrollRepository) GetRateOverrides(employeeId *int) ([]RateOverride, error) {
+	var rows *sql.Rows
+	var err error
+	if employeeId != nil {
+		rows, err = r.dbW.Querier().Query(selectEmployeeOverridesQuery, *employeeId)
+	} else {
+		rows, err = r.dbW.Querier().Query(selectRateOverridesQuery)
+	}
+	if err != nil {
+		logrus.Errorf("error while fetching rate overrides: %v", err)
+		return nil, err
+	}
+
+	return queryRateOverrides(rows)
+}
+
+// InsertRateOverride func adds a rate override, the employee's overrides are locked so overlapping
+// overrides can't be inserted concurrently
+func (r payrollRepository) InsertRateOverride(o RateOverride) (RateOverride, error) {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return RateOverride{}, fmt.Errorf("no tx running")
+	}
+
+	rows, err := r.dbW.Tx.Query(lockEmployeeOverridesQuery, o.EmployeeId)
+	if err != nil {
+		logrus.Errorf("error while fetching rate overrides: %v", err)
+		r.dbW.Tx.Rollback()
+		return RateOverride{}, err
+	}
+	existing, err := queryRateOverrides(rows)
+	if err != nil {
+		r.dbW.Tx.Rollback()
+		return RateOverride{}, err
+	}
+
+	for _, e := range existing {
+		if o.Overlaps(e) {
+			r.dbW.Tx.Rollback()
+			return RateOverride{}, ErrRateOverrideOverlaps
+		}
+	}
+
+	o.CreatedTs = time.Now()
+	err = r.dbW.Tx.QueryRow(insertRateOverrideQuery, o.EmployeeId, o.JobGroup, o.Rate, o.EffectiveFrom, o.EffectiveTo, o.CreatedTs).Scan(&o.Id)
+	if isPqError(err, foreignKeyViolation) {
+		r.dbW.Tx.Rollback()
+		return RateOverride{}, ErrJobGroupNotFound
+	} else if err != nil {
+		logrus.Errorf("error while inserting rate override: %v", err)
+		r.dbW.Tx.Rollback()
+		return RateOverride{}, err
+	}
+
+	return o, nil
+}
+
+func (r payrollRepository) DeleteRateOverride(id int) error {
+	res, err := r.dbW.DB.Exec(deleteRateOverrideQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting rate override: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrRateOverrideNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+var overrideRows = []string{"id", "employee_id", "job_group", "rate", "effective_from", "effective_to", "created_ts"}
+
+func TestGetRateOverrides(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	group := payroll.JobGroup("A")
+	from := time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)
+	mock.ExpectQuery("select (.+) from rate_overrides where employee_id = (.+) order by id;").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(overrideRows).
+			AddRow(1, 1, nil, 35, nil, nil, timeVal).
+			AddRow(2, 1, "A", 40, from, nil, timeVal))
+
+	employeeId := 1
+	overrides, err := repo.GetRateOverrides(&employeeId)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []payroll.RateOverride{
+		{Id: 1, EmployeeId: 1, Rate: dec("35"), CreatedTs: timeVal},
+		{Id: 2, EmployeeId: 1, JobGroup: &group, Rate: dec("40"), EffectiveFrom: &from, CreatedTs: timeVal},
+	}, overrides)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertRateOverride_Overlaps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectQuery("select (.+) from rate_overrides where employee_id = (.+) order by id for update;").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(overrideRows).
+			AddRow(1, 1, nil, 35, nil, nil, timeVal))
+	mock.ExpectRollback()
+
+	from := time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)
+	_, err = repo.InsertRateOverride(payroll.RateOverride{EmployeeId: 1, Rate: dec("38"), EffectiveFrom: &from})
+
+	assert.ErrorIs(t, err, payroll.ErrRateOverrideOverlaps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteRateOverride_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectExec("delete from rate_overrides where id = (.+);").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteRateOverride(3)
+
+	assert.ErrorIs(t, err, payroll.ErrRateOverrideNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,175 @@
+package payroll
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HoursBucket is the pay category of worked hours
+type HoursBucket string
+
+const (
+	BucketRegular    HoursBucket = "regular"
+	BucketOvertime   HoursBucket = "overtime"
+	BucketDoubleTime HoursBucket = "double_time"
+)
+
+// OvertimeRules decides which of an employee's hours are overtime, a threshold of 0 disables its rule
+type OvertimeRules struct {
+	// DailyOvertime and DailyDoubleTime are the hours of a day after which hours are overtime or double time
+	DailyOvertime   decimal.Decimal
+	DailyDoubleTime decimal.Decimal
+	// WeeklyOvertime is the regular hours of a week after which regular hours are overtime
+	WeeklyOvertime       decimal.Decimal
+	OvertimeMultiplier   decimal.Decimal
+	DoubleTimeMultiplier decimal.Decimal
+	WeekStart            time.Weekday
+}
+
+// ReportConfig holds the rules applied when pricing work logs
+type ReportConfig struct {
+	Overtime OvertimeRules
+	Premiums PremiumRules
+	// Holidays are the holidays of the premium rules' calendar, loaded when the report is generated
+	Holidays Holidays
+	Rounding Rounding
+	// Hours rounds the work logs' hours before they're priced
+	Hours HoursRounding
+	// Schedule splits the work logs into pay periods, semi monthly when not set
+	Schedule PaySchedule
+	// Schedules are the schedules job groups and employees are paid on instead of Schedule
+	Schedules PaySchedules
+	// Assignments are the employees' pay schedule switches, loaded when the report is generated
+	Assignments ScheduleAssignments
+}
+
+// BucketHours is the part of a work log's hours falling in one bucket
+type BucketHours struct {
+	WorkLog WorkLog
+	Bucket  HoursBucket
+	Hours   decimal.Decimal
+}
+
+// BucketTotal sums an employee's hours and pay of a bucket within a pay period
+type BucketTotal struct {
+	Bucket HoursBucket
+	Hours  decimal.Decimal
+	Amount decimal.Decimal
+}
+
+// ParseWeekday func converts config value to a week day, empty value is monday
+func ParseWeekday(s string) (time.Weekday, error) {
+	if s == "" {
+		return time.Monday, nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), s) {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown week day: %s", s)
+}
+
+// Multiplier func returns the rate multiplier of the bucket
+func (o OvertimeRules) Multiplier(bucket HoursBucket) decimal.Decimal {
+	switch bucket {
+	case BucketOvertime:
+		return o.OvertimeMultiplier
+	case BucketDoubleTime:
+		return o.DoubleTimeMultiplier
+	}
+	return decimal.NewFromInt(1)
+}
+
+// weekOf func returns the first day of the week the date is in
+func (o OvertimeRules) weekOf(date time.Time) string {
+	offset := (int(date.Weekday()) - int(o.WeekStart) + 7) % 7
+	return date.AddDate(0, 0, -offset).Format(time.DateOnly)
+}
+
+// ClassifyHours func splits each employee's work logs into hour buckets. Logs are counted in date order, and
+// in file order within a day, so the hours worked last in a day or week are the ones paid as overtime. Adjustments
+// are counted last, so they're paid as overtime rather than the hours already paid. The paid hours of each log are
+// classified, ie. its rounded hours once rounded
+func (o OvertimeRules) ClassifyHours(logs []WorkLog) []BucketHours {
+	sorted := make([]WorkLog, len(logs))
+	copy(sorted, logs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return countedBefore(sorted[i], sorted[j])
+	})
+
+	type key struct {
+		employeeId int
+		date       string
+	}
+
+	dayHours := make(map[key]decimal.Decimal)
+	weekRegular := make(map[key]decimal.Decimal)
+	res := make([]BucketHours, 0, len(sorted))
+	for _, log := range sorted {
+		day, week := key{log.EmployeeId, log.Date.Format(time.DateOnly)}, key{log.EmployeeId, o.weekOf(log.Date)}
+
+		if !log.PaidHours().IsPositive() {
+			res = append(res, BucketHours{WorkLog: log, Bucket: BucketRegular, Hours: log.PaidHours()})
+			continue
+		}
+
+		for remaining := log.PaidHours(); remaining.IsPositive(); {
+			bucket, room := o.next(dayHours[day], weekRegular[week])
+			hours := remaining
+			if room.IsPositive() && room.LessThan(hours) {
+				hours = room
+			}
+
+			res = append(res, BucketHours{WorkLog: log, Bucket: bucket, Hours: hours})
+			dayHours[day] = dayHours[day].Add(hours)
+			if bucket == BucketRegular {
+				weekRegular[week] = weekRegular[week].Add(hours)
+			}
+			remaining = remaining.Sub(hours)
+		}
+	}
+
+	return res
+}
+
+// bucketOrder func sorts regular hours first, then overtime and double time
+func bucketOrder(bucket HoursBucket) int {
+	switch bucket {
+	case BucketOvertime:
+		return 1
+	case BucketDoubleTime:
+		return 2
+	}
+	return 0
+}
+
+// next func returns the bucket of the next hour worked, and how many hours fit in it. No room limit is 0
+func (o OvertimeRules) next(dayHours, weekRegular decimal.Decimal) (HoursBucket, decimal.Decimal) {
+	reached := func(threshold, worked decimal.Decimal) bool {
+		return threshold.IsPositive() && worked.GreaterThanOrEqual(threshold)
+	}
+	if reached(o.DailyDoubleTime, dayHours) {
+		return BucketDoubleTime, decimal.Zero
+	}
+
+	room := decimal.Zero
+	limit := func(threshold, worked decimal.Decimal) {
+		if threshold.IsPositive() && (room.IsZero() || threshold.Sub(worked).LessThan(room)) {
+			room = threshold.Sub(worked)
+		}
+	}
+
+	limit(o.DailyDoubleTime, dayHours)
+	if reached(o.DailyOvertime, dayHours) || reached(o.WeeklyOvertime, weekRegular) {
+		return BucketOvertime, room
+	}
+
+	limit(o.DailyOvertime, dayHours)
+	limit(o.WeeklyOvertime, weekRegular)
+	return BucketRegular, room
+}
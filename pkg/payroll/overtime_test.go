@@ -0,0 +1,115 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var overtimeRules = payroll.OvertimeRules{
+	DailyOvertime:        dec("8"),
+	DailyDoubleTime:      dec("12"),
+	WeeklyOvertime:       dec("40"),
+	OvertimeMultiplier:   dec("1.5"),
+	DoubleTimeMultiplier: dec("2"),
+	WeekStart:            time.Monday,
+}
+
+func day(d int) time.Time {
+	// 6 November 2023 is a monday
+	return time.Date(2023, 11, d, 0, 0, 0, 0, time.Local)
+}
+
+func bucketHours(hours []payroll.BucketHours) map[payroll.HoursBucket]string {
+	totals := make(map[payroll.HoursBucket]decimal.Decimal)
+	for _, h := range hours {
+		totals[h.Bucket] = totals[h.Bucket].Add(h.Hours)
+	}
+
+	res := make(map[payroll.HoursBucket]string)
+	for bucket, total := range totals {
+		res[bucket] = total.String()
+	}
+	return res
+}
+
+func TestClassifyHours_Daily(t *testing.T) {
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("6"), JobGroup: "A", Line: 2},
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("7"), JobGroup: "B", Line: 3},
+		{EmployeeId: 2, Date: day(6), HoursLogged: dec("7"), JobGroup: "A", Line: 4},
+	}
+
+	hours := overtimeRules.ClassifyHours(logs)
+
+	assertEqualDecimals(t, []payroll.BucketHours{
+		{WorkLog: logs[0], Bucket: payroll.BucketRegular, Hours: dec("6")},
+		{WorkLog: logs[1], Bucket: payroll.BucketRegular, Hours: dec("2")},
+		{WorkLog: logs[1], Bucket: payroll.BucketOvertime, Hours: dec("4")},
+		{WorkLog: logs[1], Bucket: payroll.BucketDoubleTime, Hours: dec("1")},
+		{WorkLog: logs[2], Bucket: payroll.BucketRegular, Hours: dec("7")},
+	}, hours)
+}
+
+func TestClassifyHours_Weekly(t *testing.T) {
+	logs := make([]payroll.WorkLog, 0)
+	for d := 6; d <= 11; d++ {
+		logs = append(logs, payroll.WorkLog{EmployeeId: 1, Date: day(d), HoursLogged: dec("8"), JobGroup: "A"})
+	}
+	// next week starts over
+	logs = append(logs, payroll.WorkLog{EmployeeId: 1, Date: day(13), HoursLogged: dec("8"), JobGroup: "A"})
+
+	hours := overtimeRules.ClassifyHours(logs)
+
+	assert.Equal(t, map[payroll.HoursBucket]string{
+		payroll.BucketRegular:  "48",
+		payroll.BucketOvertime: "8",
+	}, bucketHours(hours))
+	assert.Equal(t, payroll.BucketOvertime, hours[5].Bucket)
+	assert.Equal(t, payroll.BucketRegular, hours[6].Bucket)
+}
+
+func TestClassifyHours_Disabled(t *testing.T) {
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("14"), JobGroup: "A"},
+	}
+
+	hours := payroll.OvertimeRules{}.ClassifyHours(logs)
+
+	assertEqualDecimals(t, []payroll.BucketHours{
+		{WorkLog: logs[0], Bucket: payroll.BucketRegular, Hours: dec("14")},
+	}, hours)
+}
+
+func TestGenerateReport_Overtime(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(6), HoursLogged: dec("13"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateReport(payroll.ReportConfig{Overtime: overtimeRules}, rates, logs)
+
+	assert.Len(t, report.EmployeeReports, 1)
+	assertEqualDecimals(t, dec("320"), report.EmployeeReports[0].AmountPaid)
+	assertEqualDecimals(t, []payroll.BucketTotal{
+		{Bucket: payroll.BucketRegular, Hours: dec("8"), Amount: dec("160")},
+		{Bucket: payroll.BucketOvertime, Hours: dec("4"), Amount: dec("120")},
+		{Bucket: payroll.BucketDoubleTime, Hours: dec("1"), Amount: dec("40")},
+	}, report.EmployeeReports[0].Buckets)
+}
+
+func TestParseWeekday(t *testing.T) {
+	d, err := payroll.ParseWeekday("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Monday, d)
+
+	d, err = payroll.ParseWeekday("sunday")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Sunday, d)
+
+	_, err = payroll.ParseWeekday("funday")
+	assert.Error(t, err)
+}
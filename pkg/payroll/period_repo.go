@@ -0,0 +1,207 @@
+package payroll
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	periodCols         = "id, start_date, end_date, state, snapshot, closed_ts, paid_ts, created_ts, updated_ts"
+	selectPeriodsQuery = "select " + periodCols + " from " + periodTable + " order by start_date;"
+	selectPeriodQuery  = "select " + periodCols + " from " + periodTable + " where id = $1;"
+	lockPeriodQuery    = "select " + periodCols + " from " + periodTable + " where id = $1 for update;"
+	// periods are read for share while logs are inserted, so a period can't be closed before the insert commits
+	selectPeriodsBetweenQuery = "select " + periodCols + " from " + periodTable + " where start_date <= $2 and end_date >= $1 order by start_date for share;"
+	insertPeriodQuery         = "insert into " + periodTable + " (start_date, end_date, state, created_ts, updated_ts) select $1, $2, $3, $4, $4 " +
+		"where not exists (select 1 from " + periodTable + " where start_date <= $2 and end_date >= $1) returning id;"
+	updatePeriodQuery = "update " + periodTable + " set state = $2, snapshot = $3, closed_ts = $4, paid_ts = $5, updated_ts = $6 where id = $1;"
+	deletePeriodQuery = "delete from " + periodTable + " where id = $1;"
+	// active work logs of the report paid in periods that aren't open, adjustments are paid on their upload date
+	countClosedPeriodLogsQuery = "select count(*) from " + worklogTable + " w join " + periodTable + " p " +
+		"on " + paidDate + " between p.start_date and p.end_date where w.report_id = $1 and w.retired_ts is null and p.state <> $2;"
+	selectPeriodLogsQuery = "select " + selectProvenanceCols + " from " + worklogTable + " w where retired_ts is null " +
+		"and (log_date::date between $1 and $2 or " + paidDate + " between $1 and $2) order by log_date, id;"
+	paidDate = "(case when w.adjustment then coalesce(w.uploaded_ts, w.updated_ts)::date else w.log_date::date end)"
+)
+
+func scanPeriod(row rowScanner) (PayrollPeriod, error) {
+	var p PayrollPeriod
+	var snapshot []byte
+	var closedTs, paidTs sql.NullTime
+
+	if err := row.Scan(&p.Id, &p.StartDate, &p.EndDate, &p.State, &snapshot, &closedTs, &paidTs, &p.CreatedTs, &p.UpdatedTs); err != nil {
+		return p, err
+	}
+	if closedTs.Valid {
+		p.ClosedTs = &closedTs.Time
+	}
+	if paidTs.Valid {
+		p.PaidTs = &paidTs.Time
+	}
+
+	if len(snapshot) > 0 {
+		var err error
+		if p.Snapshot, err = decodeSnapshot(snapshot); err != nil {
+			return p, fmt.Errorf("unable to decode pay period snapshot: %v", err)
+		}
+	}
+
+	return p, nil
+}
+
+func queryPeriods(rows *sql.Rows) ([]PayrollPeriod, error) {
+	defer rows.Close()
+
+	periods := make([]PayrollPeriod, 0)
+	for rows.Next() {
+		p, err := scanPeriod(rows)
+		if err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return periods, err
+		}
+
+		periods = append(periods, p)
+	}
+
+	return periods, nil
+}
+
+func (r payrollRepository) GetPeriods() ([]PayrollPeriod, error) {
+	rows, err := r.dbW.DB.Query(selectPeriodsQuery)
+	if err != nil {
+		logrus.Errorf("error while fetching pay periods: %v", err)
+		return nil, err
+	}
+
+	return queryPeriods(rows)
+}
+
+func (r payrollRepository) GetPeriod(id int) (PayrollPeriod, error) {
+	p, err := scanPeriod(r.dbW.Querier().QueryRow(selectPeriodQuery, id))
+	if err == sql.ErrNoRows {
+		return PayrollPeriod{}, ErrPeriodNotFound
+	} else if err != nil {
+		logrus.Errorf("error while fetching pay period: %v", err)
+		return PayrollPeriod{}, err
+	}
+
+	return p, nil
+}
+
+// LockPeriod func returns the pay period locked for update until the running tx ends
+func (r payrollRepository) LockPeriod(id int) (PayrollPeriod, error) {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return PayrollPeriod{}, fmt.Errorf("no tx running")
+	}
+
+	p, err := scanPeriod(r.dbW.Tx.QueryRow(lockPeriodQuery, id))
+	if err == sql.ErrNoRows {
+		return PayrollPeriod{}, ErrPeriodNotFound
+	} else if err != nil {
+		logrus.Errorf("error while locking pay period: %v", err)
+		return PayrollPeriod{}, err
+	}
+
+	return p, nil
+}
+
+// GetPeriodsBetween func returns the pay periods sharing a day with the dates
+func (r payrollRepository) GetPeriodsBetween(from, to time.Time) ([]PayrollPeriod, error) {
+	rows, err := r.dbW.Querier().Query(selectPeriodsBetweenQuery, from, to)
+	if err != nil {
+		logrus.Errorf("error while fetching pay periods: %v", err)
+		return nil, err
+	}
+
+	return queryPeriods(rows)
+}
+
+// InsertPeriod func adds an open pay period, periods can't overlap. The insert skips overlapping periods, and the
+// table's exclusion constraint refuses the ones inserted concurrently, which the insert doesn't see yet
+func (r payrollRepository) InsertPeriod(p PayrollPeriod) (PayrollPeriod, error) {
+	p.State = PeriodOpen
+	p.CreatedTs = time.Now()
+	p.UpdatedTs = p.CreatedTs
+
+	err := r.dbW.DB.QueryRow(insertPeriodQuery, p.StartDate, p.EndDate, p.State, p.CreatedTs).Scan(&p.Id)
+	if err == sql.ErrNoRows || isPqError(err, exclusionViolation) {
+		return PayrollPeriod{}, ErrPeriodOverlaps
+	} else if err != nil {
+		logrus.Errorf("error while inserting pay period: %v", err)
+		return PayrollPeriod{}, err
+	}
+
+	return p, nil
+}
+
+// UpdatePeriod func saves the state of the pay period along with its snapshot
+func (r payrollRepository) UpdatePeriod(p PayrollPeriod) error {
+	var snapshot []byte
+	if p.Snapshot != nil {
+		var err error
+		if snapshot, err = encodeSnapshot(p.Snapshot); err != nil {
+			return fmt.Errorf("unable to encode pay period snapshot: %v", err)
+		}
+	}
+
+	if _, err := r.dbW.Querier().Exec(updatePeriodQuery, p.Id, p.State, snapshot, p.ClosedTs, p.PaidTs, p.UpdatedTs); err != nil {
+		logrus.Errorf("error while updating pay period: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r payrollRepository) DeletePeriod(id int) error {
+	res, err := r.dbW.Querier().Exec(deletePeriodQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting pay period: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrPeriodNotFound
+	}
+
+	return nil
+}
+
+// CountClosedPeriodLogs func counts the active work logs of the report paid in pay periods that aren't open
+func (r payrollRepository) CountClosedPeriodLogs(reportId int) (int, error) {
+	var count int
+	if err := r.dbW.Querier().QueryRow(countClosedPeriodLogsQuery, reportId, PeriodOpen).Scan(&count); err != nil {
+		logrus.Errorf("error while counting closed pay period logs: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetPeriodLogs func returns the active work logs dated or paid between the days, inclusive
+func (r payrollRepository) GetPeriodLogs(from, to time.Time) ([]WorkLog, error) {
+	wl := make([]WorkLog, 0)
+
+	rows, err := r.dbW.Querier().Query(selectPeriodLogsQuery, from, to)
+	if err != nil {
+		logrus.Errorf("error while fetching pay period logs: %v", err)
+		return wl, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		j, err := scanProvenanceLog(rows)
+		if err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return wl, err
+		}
+
+		wl = append(wl, j)
+	}
+
+	return wl, nil
+}
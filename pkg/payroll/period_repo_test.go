@@ -0,0 +1,196 @@
+package payroll_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var periodRows = []string{"id", "start_date", "end_date", "state", "snapshot", "closed_ts", "paid_ts", "created_ts", "updated_ts"}
+
+func TestGetPeriod(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from pay_periods where id = (.+);").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(periodRows).
+			AddRow(1, day(1), day(15), "closed", []byte(`{"version":1,"employee_reports":[{"employee_id":1,`+
+				`"pay_period":{"start_date":"2023-11-01","end_date":"2023-11-15"},"amount_paid":"100","line_items":[],`+
+				`"buckets":[],"premiums":[],"adjustments":[]}]}`), timeVal, nil, timeVal, timeVal))
+
+	p, err := repo.GetPeriod(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PeriodClosed, p.State)
+	assert.Equal(t, &timeVal, p.ClosedTs)
+	assert.Nil(t, p.PaidTs)
+	assert.Len(t, p.Snapshot, 1)
+	assert.Equal(t, period(day(1), day(15)), p.Snapshot[0].PayPeriod)
+	assertEqualDecimals(t, dec("100"), p.Snapshot[0].AmountPaid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPeriod_UnsupportedSnapshot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from pay_periods where id = (.+);").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(periodRows).
+			AddRow(1, day(1), day(15), "closed", []byte(`{"version":2,"employee_reports":[]}`), timeVal, nil, timeVal, timeVal))
+
+	_, err = repo.GetPeriod(1)
+
+	assert.ErrorContains(t, err, "unsupported snapshot version 2")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePeriod_Snapshot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	p := payroll.PayrollPeriod{Id: 1, StartDate: day(1), EndDate: day(15), State: payroll.PeriodClosed, ClosedTs: &timeVal, UpdatedTs: timeVal,
+		Snapshot: []payroll.EmployeeReport{{
+			EmployeeId: 1,
+			PayPeriod:  period(day(1), day(15)),
+			AmountPaid: dec("130.5"),
+			LineItems: []payroll.LineItem{{JobGroup: "A", Source: payroll.SourceEmployee, RateVersion: 1, OverrideId: 3, Rate: dec("20.25"),
+				Bucket: payroll.BucketRegular, Multiplier: dec("1"), Hours: dec("4"), Amount: dec("81")}},
+			Buckets: []payroll.BucketTotal{{Bucket: payroll.BucketRegular, Hours: dec("4"), Amount: dec("81")}},
+			Adjustments: []payroll.Adjustment{{
+				PayPeriod: period(day(16), day(30)),
+				LineItems: []payroll.LineItem{{JobGroup: "A", Source: payroll.SourceJobGroup, RateVersion: 1, Rate: dec("16.5"),
+					Bucket: payroll.BucketRegular, Multiplier: dec("1"), Hours: dec("3"), Amount: dec("49.5")}},
+				Amount: dec("49.5"),
+			}},
+		}},
+	}
+
+	// the snapshot is stored in the versioned shape, with exact decimal strings and dates
+	mock.ExpectExec("update pay_periods set (.+) where id = (.+);").
+		WithArgs(1, payroll.PeriodClosed, []byte(`{"version":1,"employee_reports":[{"employee_id":1,`+
+			`"pay_period":{"start_date":"2023-11-01","end_date":"2023-11-15"},"amount_paid":"130.5",`+
+			`"line_items":[{"job_group":"A","rate_source":"employee","rate_version":1,"override_id":3,"rate":"20.25",`+
+			`"bucket":"regular","multiplier":"1","hours":"4","amount":"81"}],`+
+			`"buckets":[{"bucket":"regular","hours":"4","amount":"81"}],"premiums":[],`+
+			`"adjustments":[{"original_pay_period":{"start_date":"2023-11-16","end_date":"2023-11-30"},`+
+			`"line_items":[{"job_group":"A","rate_source":"job_group","rate_version":1,"rate":"16.5",`+
+			`"bucket":"regular","multiplier":"1","hours":"3","amount":"49.5"}],"premiums":[],"amount":"49.5"}]}]}`),
+			&timeVal, nil, timeVal).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.UpdatePeriod(p))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPeriod_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from pay_periods where id = (.+);").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(periodRows))
+
+	_, err = repo.GetPeriod(1)
+
+	assert.ErrorIs(t, err, payroll.ErrPeriodNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertPeriod_Overlaps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("insert into pay_periods (.+) where not exists (.+) returning id;").
+		WithArgs(day(10), day(20), payroll.PeriodOpen, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.InsertPeriod(payroll.PayrollPeriod{StartDate: day(10), EndDate: day(20)})
+
+	assert.ErrorIs(t, err, payroll.ErrPeriodOverlaps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertPeriod_ConcurrentOverlap(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	// an overlapping period committed after the insert checked for one
+	mock.ExpectQuery("insert into pay_periods (.+) where not exists (.+) returning id;").
+		WithArgs(day(10), day(20), payroll.PeriodOpen, sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23P01"})
+
+	_, err = repo.InsertPeriod(payroll.PayrollPeriod{StartDate: day(10), EndDate: day(20)})
+
+	assert.ErrorIs(t, err, payroll.ErrPeriodOverlaps)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountClosedPeriodLogs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select count(.+) from worklog w join pay_periods p (.+) where w.report_id = (.+);").
+		WithArgs(42, payroll.PeriodOpen).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountClosedPeriodLogs(42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
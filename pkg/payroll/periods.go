@@ -0,0 +1,129 @@
+package payroll
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PeriodState is where a pay period is in the payroll run, work logs can only be uploaded for open periods
+type PeriodState string
+
+const (
+	PeriodOpen   PeriodState = "open"
+	PeriodClosed PeriodState = "closed"
+	PeriodPaid   PeriodState = "paid"
+)
+
+// PayrollPeriod is a range of days paid out together, closed once its report is final and paid once the pay
+// is out. Dates are inclusive
+type PayrollPeriod struct {
+	Id        int
+	StartDate time.Time
+	EndDate   time.Time
+	State     PeriodState
+	// Snapshot is the report of the pay periods within the period, taken when it was closed
+	Snapshot  []EmployeeReport
+	ClosedTs  *time.Time
+	PaidTs    *time.Time
+	CreatedTs time.Time
+	UpdatedTs time.Time
+}
+
+// ParsePeriodState func converts api value to a pay period state
+func ParsePeriodState(s string) (PeriodState, error) {
+	switch PeriodState(s) {
+	case PeriodOpen, PeriodClosed, PeriodPaid:
+		return PeriodState(s), nil
+	}
+	return "", fmt.Errorf("unknown pay period state: %s", s)
+}
+
+// Contains func reports whether the date is in the period, only the date part is compared
+func (p PayrollPeriod) Contains(date time.Time) bool {
+	return daysBetween(p.StartDate, date) >= 0 && daysBetween(date, p.EndDate) >= 0
+}
+
+// Overlaps func reports whether the periods share a day
+func (p PayrollPeriod) Overlaps(o PayrollPeriod) bool {
+	return daysBetween(p.StartDate, o.EndDate) >= 0 && daysBetween(o.StartDate, p.EndDate) >= 0
+}
+
+// Locked func reports whether work logs dated in the period are refused, ie. it isn't open
+func (p PayrollPeriod) Locked() bool {
+	return p.State != PeriodOpen
+}
+
+// CanMoveTo func reports whether the period can change to the state. Open periods are closed, closed periods
+// are paid or opened again for corrections, and paid periods are final
+func (p PayrollPeriod) CanMoveTo(state PeriodState) bool {
+	switch p.State {
+	case PeriodOpen:
+		return state == PeriodClosed
+	case PeriodClosed:
+		return state == PeriodPaid || state == PeriodOpen
+	}
+	return false
+}
+
+// CheckClosedPeriods func flags the logs dated in locked periods as adjustments when they're posted as ones,
+// or returns a row error for each of them otherwise. Adjustments are paid on their upload date, so a row error is
+// returned for each of them as well when that's in a locked period
+func CheckClosedPeriods(periods []PayrollPeriod, logs []WorkLog, adjustment bool) ([]WorkLog, []RowError) {
+	locked := func(date time.Time) (PayrollPeriod, bool) {
+		for _, p := range periods {
+			if p.Locked() && p.Contains(date) {
+				return p, true
+			}
+		}
+		return PayrollPeriod{}, false
+	}
+
+	res := make([]WorkLog, 0, len(logs))
+	rowErrors := make([]RowError, 0)
+	for _, log := range logs {
+		if p, ok := locked(log.Date); ok && !adjustment {
+			rowErrors = append(rowErrors, RowError{
+				Line:  log.Line,
+				Value: log.Date.Format(time.DateOnly),
+				Reason: fmt.Sprintf("pay period %s to %s is %s, post the work log as an adjustment",
+					p.StartDate.Format(time.DateOnly), p.EndDate.Format(time.DateOnly), p.State),
+			})
+		} else if ok {
+			log.Adjustment = true
+			if p, ok := locked(log.UploadedTs); ok {
+				rowErrors = append(rowErrors, RowError{
+					Line:  log.Line,
+					Value: log.Date.Format(time.DateOnly),
+					Reason: fmt.Sprintf("adjustment is paid in pay period %s to %s, which is %s",
+						p.StartDate.Format(time.DateOnly), p.EndDate.Format(time.DateOnly), p.State),
+				})
+			}
+		}
+		res = append(res, log)
+	}
+	return res, rowErrors
+}
+
+// SnapshotReport func returns the report lines of the pay periods within the period, sorted by employee id and
+// pay period
+func SnapshotReport(period PayrollPeriod, report PayrollReport) []EmployeeReport {
+	snapshot := make([]EmployeeReport, 0)
+	for _, r := range report.EmployeeReports {
+		if period.Contains(r.PayPeriod.StartDate) && period.Contains(r.PayPeriod.EndDate) {
+			snapshot = append(snapshot, r)
+		}
+	}
+	sortEmployeeReports(snapshot)
+	return snapshot
+}
+
+// sortEmployeeReports func sorts the report lines by employee id and pay period
+func sortEmployeeReports(reports []EmployeeReport) {
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].EmployeeId != reports[j].EmployeeId {
+			return reports[i].EmployeeId < reports[j].EmployeeId
+		}
+		return reports[i].PayPeriod.StartDate.Before(reports[j].PayPeriod.StartDate)
+	})
+}
@@ -0,0 +1,134 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClosedPeriods(t *testing.T) {
+	periods := []payroll.PayrollPeriod{
+		{Id: 1, StartDate: day(1), EndDate: day(15), State: payroll.PeriodClosed},
+		{Id: 2, StartDate: day(16), EndDate: day(30), State: payroll.PeriodOpen},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(15), HoursLogged: dec("8"), JobGroup: "A", Line: 2},
+		{EmployeeId: 1, Date: day(16), HoursLogged: dec("8"), JobGroup: "A", Line: 3},
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		res, rowErrors := payroll.CheckClosedPeriods(periods, logs, false)
+
+		assert.Equal(t, logs, res)
+		assert.Equal(t, []payroll.RowError{{Line: 2, Value: "2023-11-15",
+			Reason: "pay period 2023-11-01 to 2023-11-15 is closed, post the work log as an adjustment"}}, rowErrors)
+	})
+
+	t.Run("posted as adjustments", func(t *testing.T) {
+		res, rowErrors := payroll.CheckClosedPeriods(periods, logs, true)
+
+		assert.Empty(t, rowErrors)
+		assert.True(t, res[0].Adjustment)
+		assert.False(t, res[1].Adjustment)
+	})
+
+	t.Run("adjustment paid in closed period", func(t *testing.T) {
+		late := []payroll.WorkLog{{EmployeeId: 1, Date: day(6), HoursLogged: dec("8"), JobGroup: "A", Line: 2, UploadedTs: day(14)}}
+
+		_, rowErrors := payroll.CheckClosedPeriods(periods, late, true)
+
+		assert.Equal(t, []payroll.RowError{{Line: 2, Value: "2023-11-06",
+			Reason: "adjustment is paid in pay period 2023-11-01 to 2023-11-15, which is closed"}}, rowErrors)
+	})
+}
+
+func TestPayrollPeriod_CanMoveTo(t *testing.T) {
+	tests := []struct {
+		from, to payroll.PeriodState
+		expected bool
+	}{
+		{payroll.PeriodOpen, payroll.PeriodClosed, true},
+		{payroll.PeriodOpen, payroll.PeriodPaid, false},
+		{payroll.PeriodClosed, payroll.PeriodPaid, true},
+		{payroll.PeriodClosed, payroll.PeriodOpen, true},
+		{payroll.PeriodPaid, payroll.PeriodOpen, false},
+		{payroll.PeriodPaid, payroll.PeriodClosed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.expected, payroll.PayrollPeriod{State: tt.from}.CanMoveTo(tt.to))
+		})
+	}
+}
+
+func TestParsePeriodState(t *testing.T) {
+	state, err := payroll.ParsePeriodState("closed")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PeriodClosed, state)
+
+	_, err = payroll.ParsePeriodState("locked")
+	assert.Error(t, err)
+}
+
+func TestSnapshotReport(t *testing.T) {
+	p := payroll.PayrollPeriod{StartDate: day(1), EndDate: day(15), State: payroll.PeriodClosed}
+	report := payroll.PayrollReport{EmployeeReports: []payroll.EmployeeReport{
+		{EmployeeId: 2, PayPeriod: period(day(1), day(15)), AmountPaid: decimal.NewFromInt(300)},
+		{EmployeeId: 1, PayPeriod: period(day(16), day(30)), AmountPaid: decimal.NewFromInt(200)},
+		{EmployeeId: 1, PayPeriod: period(day(1), day(15)), AmountPaid: decimal.NewFromInt(100)},
+	}}
+
+	snapshot := payroll.SnapshotReport(p, report)
+
+	assert.Equal(t, []payroll.EmployeeReport{
+		{EmployeeId: 1, PayPeriod: period(day(1), day(15)), AmountPaid: decimal.NewFromInt(100)},
+		{EmployeeId: 2, PayPeriod: period(day(1), day(15)), AmountPaid: decimal.NewFromInt(300)},
+	}, snapshot)
+}
+
+func TestSetPeriodState_ReadsAfterLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	// the lock waits for an upload holding the period for share, the rates and logs are read once it commits,
+	// so the log it inserted is in the snapshot
+	mock.ExpectBegin()
+	mock.ExpectQuery("select (.+) from pay_periods where id = (.+) for update;").
+		WithArgs(1).
+		WillDelayFor(50 * time.Millisecond).
+		WillReturnRows(sqlmock.NewRows(periodRows).AddRow(1, day(1), day(15), "open", nil, nil, nil, timeVal, timeVal))
+	mock.ExpectQuery("select (.+) from jobgroup_rate (.+);").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).AddRow("A", 1, 20, nil, nil))
+	mock.ExpectQuery("select (.+) from rate_overrides (.+);").
+		WillReturnRows(sqlmock.NewRows(overrideRows))
+	mock.ExpectQuery("select (.+) from worklog w where retired_ts is null (.+);").
+		WithArgs(day(1).AddDate(0, 0, -6), day(15)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "report_id",
+			"report_version", "line", "uploaded_ts", "retired_ts", "cost_center", "department", "adjustment"}).
+			AddRow(7, 1, day(6), "8", nil, "A", 42, 1, 2, timeVal, nil, nil, nil, false))
+	mock.ExpectExec("update pay_periods set (.+) where id = (.+);").
+		WithArgs(1, payroll.PeriodClosed, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	p, err := service.SetPeriodState(1, payroll.PeriodClosed)
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PeriodClosed, p.State)
+	assert.Len(t, p.Snapshot, 1)
+	assertEqualDecimals(t, dec("160"), p.Snapshot[0].AmountPaid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
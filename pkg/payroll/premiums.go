@@ -0,0 +1,131 @@
+package payroll
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PremiumKind is why hours are paid a premium
+type PremiumKind string
+
+const (
+	PremiumHoliday PremiumKind = "holiday"
+	PremiumWeekend PremiumKind = "weekend"
+)
+
+// PremiumRules holds the rate multipliers of hours worked on holidays and weekend days, a multiplier
+// of 1 or less pays no premium
+type PremiumRules struct {
+	// Calendar is the holiday calendar reports are generated with
+	Calendar          string
+	HolidayMultiplier decimal.Decimal
+	WeekendMultiplier decimal.Decimal
+	WeekendDays       []time.Weekday
+}
+
+// Holidays holds the holidays of a calendar by date
+type Holidays map[string]Holiday
+
+// NewHolidays func indexes the holidays by date
+func NewHolidays(holidays []Holiday) Holidays {
+	h := make(Holidays, len(holidays))
+	for _, holiday := range holidays {
+		h[holiday.Date.Format(time.DateOnly)] = holiday
+	}
+	return h
+}
+
+// Lookup func returns the holiday on the date, only the date part is compared
+func (h Holidays) Lookup(date time.Time) (Holiday, bool) {
+	holiday, ok := h[date.Format(time.DateOnly)]
+	return holiday, ok
+}
+
+// PremiumLine is the premium paid on an employee's hours of a job group at the same rate within a pay period.
+// The amount is the hours times the rate times the multiplier less one, as the hours themselves are paid
+// by the line items
+type PremiumLine struct {
+	Kind       PremiumKind
+	JobGroup   JobGroup
+	Rate       decimal.Decimal
+	Multiplier decimal.Decimal
+	Hours      decimal.Decimal
+	Amount     decimal.Decimal
+}
+
+// premium func returns the premium of hours worked on the date, a holiday premium takes precedence
+// over a weekend one
+func (p PremiumRules) premium(holidays Holidays, date time.Time) (PremiumKind, decimal.Decimal, bool) {
+	if _, ok := holidays.Lookup(date); ok && p.HolidayMultiplier.GreaterThan(decimal.NewFromInt(1)) {
+		return PremiumHoliday, p.HolidayMultiplier, true
+	}
+	if p.WeekendMultiplier.GreaterThan(decimal.NewFromInt(1)) {
+		for _, d := range p.WeekendDays {
+			if date.Weekday() == d {
+				return PremiumWeekend, p.WeekendMultiplier, true
+			}
+		}
+	}
+	return "", decimal.Zero, false
+}
+
+// pricePremiums func sums the premiums of the hours paid the same premium at the same rate, sorted by kind,
+// job group and rate. Amounts are summed exactly, then rounded per line if configured
+func pricePremiums(cfg ReportConfig, rates Rates, hours []BucketHours) []PremiumLine {
+	type key struct {
+		kind     PremiumKind
+		jobGroup JobGroup
+		// rate is the canonical string of the rate, as decimals aren't comparable
+		rate string
+	}
+
+	lines := make(map[key]*PremiumLine)
+	for _, h := range hours {
+		log := h.WorkLog
+		kind, multiplier, ok := cfg.Premiums.premium(cfg.Holidays, log.Date)
+		if !ok {
+			continue
+		}
+
+		rate, _ := rates.Resolve(log.EmployeeId, log.JobGroup, log.Date)
+		k := key{kind, log.JobGroup, rate.Rate.String()}
+		if _, ok := lines[k]; !ok {
+			lines[k] = &PremiumLine{
+				Kind:       kind,
+				JobGroup:   log.JobGroup,
+				Rate:       rate.Rate,
+				Multiplier: multiplier,
+			}
+		}
+		lines[k].Hours = lines[k].Hours.Add(h.Hours)
+		lines[k].Amount = lines[k].Amount.Add(h.Hours.Mul(rate.Rate).Mul(multiplier.Sub(decimal.NewFromInt(1))))
+	}
+
+	res := make([]PremiumLine, 0, len(lines))
+	for _, line := range lines {
+		line.Amount = cfg.Rounding.line(line.Amount)
+		res = append(res, *line)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+		if res[i].JobGroup != res[j].JobGroup {
+			return res[i].JobGroup < res[j].JobGroup
+		}
+		return res[i].Rate.LessThan(res[j].Rate)
+	})
+
+	return res
+}
+
+func sumPremiums(lines []PremiumLine) decimal.Decimal {
+	total := decimal.Zero
+	for _, line := range lines {
+		total = total.Add(line.Amount)
+	}
+	return total
+}
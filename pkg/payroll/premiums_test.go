@@ -0,0 +1,50 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateReport_Premiums(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	cfg := payroll.ReportConfig{
+		Premiums: payroll.PremiumRules{
+			HolidayMultiplier: dec("2"),
+			WeekendMultiplier: dec("1.5"),
+			WeekendDays:       []time.Weekday{time.Saturday, time.Sunday},
+		},
+		// 11 November 2023 is a saturday
+		Holidays: payroll.NewHolidays([]payroll.Holiday{
+			{Calendar: "ca", Date: time.Date(2023, 11, 11, 0, 0, 0, 0, time.UTC), Name: "Remembrance Day"},
+		}),
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(10), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(11), HoursLogged: dec("4"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(12), HoursLogged: dec("2"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateReport(cfg, rates, logs)
+
+	assert.Len(t, report.EmployeeReports, 1)
+	assertEqualDecimals(t, []payroll.PremiumLine{
+		{Kind: payroll.PremiumHoliday, JobGroup: "A", Rate: dec("20"), Multiplier: dec("2"), Hours: dec("4"), Amount: dec("80")},
+		{Kind: payroll.PremiumWeekend, JobGroup: "A", Rate: dec("20"), Multiplier: dec("1.5"), Hours: dec("2"), Amount: dec("20")},
+	}, report.EmployeeReports[0].Premiums)
+	assertEqualDecimals(t, dec("380"), report.EmployeeReports[0].AmountPaid)
+}
+
+func TestGenerateReport_NoPremiums(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("20")}}, nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(11), HoursLogged: dec("4"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateReport(payroll.ReportConfig{}, rates, logs)
+
+	assert.Empty(t, report.EmployeeReports[0].Premiums)
+	assertEqualDecimals(t, dec("80"), report.EmployeeReports[0].AmountPaid)
+}
@@ -0,0 +1,142 @@
+package payroll
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// InForce func returns true if the rate applies on the date, only the date part is compared
+func (r JobGroupRate) InForce(date time.Time) bool {
+	return inForce(r.EffectiveFrom, r.EffectiveTo, date)
+}
+
+// InForce func returns true if the override applies on the date, only the date part is compared
+func (o RateOverride) InForce(date time.Time) bool {
+	return inForce(o.EffectiveFrom, o.EffectiveTo, date)
+}
+
+// Overlaps func returns true if both overrides replace the same rate on a common day
+func (o RateOverride) Overlaps(other RateOverride) bool {
+	if o.EmployeeId != other.EmployeeId || (o.JobGroup == nil) != (other.JobGroup == nil) {
+		return false
+	}
+	if o.JobGroup != nil && *o.JobGroup != *other.JobGroup {
+		return false
+	}
+	if o.EffectiveTo != nil && other.EffectiveFrom != nil && o.EffectiveTo.Format(time.DateOnly) < other.EffectiveFrom.Format(time.DateOnly) {
+		return false
+	}
+	if other.EffectiveTo != nil && o.EffectiveFrom != nil && other.EffectiveTo.Format(time.DateOnly) < o.EffectiveFrom.Format(time.DateOnly) {
+		return false
+	}
+	return true
+}
+
+func inForce(from, to *time.Time, date time.Time) bool {
+	day := date.Format(time.DateOnly)
+	if from != nil && day < from.Format(time.DateOnly) {
+		return false
+	}
+	if to != nil && day > to.Format(time.DateOnly) {
+		return false
+	}
+	return true
+}
+
+// RateTable holds every rate version of each job group, oldest first
+type RateTable map[JobGroup][]JobGroupRate
+
+// NewRateTable func groups the rate versions by job group
+func NewRateTable(rates []JobGroupRate) RateTable {
+	t := make(RateTable)
+	for _, r := range rates {
+		t[r.JobGroup] = append(t[r.JobGroup], r)
+	}
+	for _, versions := range t {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].Version < versions[j].Version
+		})
+	}
+	return t
+}
+
+// Lookup func returns the rate of the job group in force on the date
+func (t RateTable) Lookup(group JobGroup, date time.Time) (JobGroupRate, bool) {
+	for _, r := range t[group] {
+		if r.InForce(date) {
+			return r, true
+		}
+	}
+	return JobGroupRate{}, false
+}
+
+// Current func returns the rate of every job group in force on the date, or the latest version
+// of groups whose rates only start later
+func (t RateTable) Current(date time.Time) []JobGroupRate {
+	res := make([]JobGroupRate, 0, len(t))
+	for group, versions := range t {
+		r, ok := t.Lookup(group, date)
+		if !ok {
+			r = versions[len(versions)-1]
+		}
+		res = append(res, r)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].JobGroup < res[j].JobGroup
+	})
+	return res
+}
+
+// ResolvedRate is the rate a work log is priced at, and where it came from
+type ResolvedRate struct {
+	Source      RateSource
+	RateVersion int
+	OverrideId  int
+	Rate        decimal.Decimal
+}
+
+// Rates resolves the rate of each work log, an employee's override takes precedence over the job group rate
+type Rates struct {
+	Groups    RateTable
+	Overrides map[int][]RateOverride
+}
+
+// NewRates func indexes the job group rate versions, and the rate overrides by employee
+func NewRates(groupRates []JobGroupRate, overrides []RateOverride) Rates {
+	r := Rates{
+		Groups:    NewRateTable(groupRates),
+		Overrides: make(map[int][]RateOverride),
+	}
+	for _, o := range overrides {
+		r.Overrides[o.EmployeeId] = append(r.Overrides[o.EmployeeId], o)
+	}
+	return r
+}
+
+// Resolve func returns the rate of the employee's work in the job group on the date. An override for the
+// job group comes first, then an override for every group, then the job group rate
+func (r Rates) Resolve(employeeId int, group JobGroup, date time.Time) (ResolvedRate, bool) {
+	var employeeRate *RateOverride
+	for i, o := range r.Overrides[employeeId] {
+		if !o.InForce(date) {
+			continue
+		}
+		if o.JobGroup != nil && *o.JobGroup == group {
+			return ResolvedRate{Source: SourceEmployeeJobGroup, OverrideId: o.Id, Rate: o.Rate}, true
+		}
+		if o.JobGroup == nil && employeeRate == nil {
+			employeeRate = &r.Overrides[employeeId][i]
+		}
+	}
+	if employeeRate != nil {
+		return ResolvedRate{Source: SourceEmployee, OverrideId: employeeRate.Id, Rate: employeeRate.Rate}, true
+	}
+
+	if rate, ok := r.Groups.Lookup(group, date); ok {
+		return ResolvedRate{Source: SourceJobGroup, RateVersion: rate.Version, Rate: rate.Rate}, true
+	}
+	return ResolvedRate{Source: SourceNone}, false
+}
@@ -0,0 +1,133 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func rateHistory() []payroll.JobGroupRate {
+	from := time.Date(2023, 11, 8, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, -1)
+	return []payroll.JobGroupRate{
+		{JobGroup: "A", Version: 2, Rate: dec("25"), EffectiveFrom: &from},
+		{JobGroup: "A", Version: 1, Rate: dec("20"), EffectiveTo: &to},
+		{JobGroup: "B", Version: 1, Rate: dec("30")},
+	}
+}
+
+func TestRateTable_Lookup(t *testing.T) {
+	rates := payroll.NewRateTable(rateHistory())
+
+	rate, ok := rates.Lookup("A", time.Date(2023, 11, 7, 23, 0, 0, 0, time.Local))
+	assert.True(t, ok)
+	assert.Equal(t, 1, rate.Version)
+
+	rate, ok = rates.Lookup("A", time.Date(2023, 11, 8, 0, 0, 0, 0, time.Local))
+	assert.True(t, ok)
+	assert.Equal(t, 2, rate.Version)
+
+	_, ok = rates.Lookup("C", time.Date(2023, 11, 8, 0, 0, 0, 0, time.Local))
+	assert.False(t, ok)
+}
+
+func TestRateTable_Current(t *testing.T) {
+	rates := payroll.NewRateTable(rateHistory())
+
+	current := rates.Current(time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local))
+
+	assert.Len(t, current, 2)
+	assert.Equal(t, payroll.JobGroup("A"), current[0].JobGroup)
+	assert.Equal(t, 1, current[0].Version)
+	assert.Equal(t, payroll.JobGroup("B"), current[1].JobGroup)
+}
+
+func TestCalcLineItems_SplitsRateChange(t *testing.T) {
+	rates := payroll.NewRates(rateHistory(), nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: time.Date(2023, 11, 6, 0, 0, 0, 0, time.Local), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 1, Date: time.Date(2023, 11, 9, 0, 0, 0, 0, time.Local), HoursLogged: dec("4"), JobGroup: "A"},
+		{EmployeeId: 1, Date: time.Date(2023, 11, 10, 0, 0, 0, 0, time.Local), HoursLogged: dec("2"), JobGroup: "B"},
+		{EmployeeId: 1, Date: time.Date(2023, 11, 10, 0, 0, 0, 0, time.Local), HoursLogged: dec("3"), JobGroup: "A"},
+	}
+
+	items := payroll.CalcLineItems(payroll.ReportConfig{}, rates, logs)
+
+	assertEqualDecimals(t, []payroll.LineItem{
+		{JobGroup: "A", Source: payroll.SourceJobGroup, RateVersion: 1, Rate: dec("20"), Bucket: payroll.BucketRegular, Multiplier: dec("1"), Hours: dec("8"), Amount: dec("160")},
+		{JobGroup: "A", Source: payroll.SourceJobGroup, RateVersion: 2, Rate: dec("25"), Bucket: payroll.BucketRegular, Multiplier: dec("1"), Hours: dec("7"), Amount: dec("175")},
+		{JobGroup: "B", Source: payroll.SourceJobGroup, RateVersion: 1, Rate: dec("30"), Bucket: payroll.BucketRegular, Multiplier: dec("1"), Hours: dec("2"), Amount: dec("60")},
+	}, items)
+	assertEqualDecimals(t, dec("395"), payroll.CalcAmountPaid(payroll.ReportConfig{}, rates, logs))
+}
+
+func TestRates_Resolve(t *testing.T) {
+	groupA := payroll.JobGroup("A")
+	raise := time.Date(2023, 11, 10, 0, 0, 0, 0, time.Local)
+	rates := payroll.NewRates(rateHistory(), []payroll.RateOverride{
+		{Id: 1, EmployeeId: 1, Rate: dec("35")},
+		{Id: 2, EmployeeId: 1, JobGroup: &groupA, Rate: dec("40"), EffectiveFrom: &raise},
+	})
+
+	tests := []struct {
+		name       string
+		employeeId int
+		group      payroll.JobGroup
+		date       time.Time
+		want       payroll.ResolvedRate
+	}{
+		{
+			name:       "group rate without override",
+			employeeId: 2,
+			group:      "A",
+			date:       raise,
+			want:       payroll.ResolvedRate{Source: payroll.SourceJobGroup, RateVersion: 2, Rate: dec("25")},
+		},
+		{
+			name:       "employee override before the group override starts",
+			employeeId: 1,
+			group:      "A",
+			date:       raise.AddDate(0, 0, -1),
+			want:       payroll.ResolvedRate{Source: payroll.SourceEmployee, OverrideId: 1, Rate: dec("35")},
+		},
+		{
+			name:       "group override takes precedence",
+			employeeId: 1,
+			group:      "A",
+			date:       raise,
+			want:       payroll.ResolvedRate{Source: payroll.SourceEmployeeJobGroup, OverrideId: 2, Rate: dec("40")},
+		},
+		{
+			name:       "employee override of another group",
+			employeeId: 1,
+			group:      "B",
+			date:       raise,
+			want:       payroll.ResolvedRate{Source: payroll.SourceEmployee, OverrideId: 1, Rate: dec("35")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rates.Resolve(tt.employeeId, tt.group, tt.date)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := rates.Resolve(2, "C", raise)
+	assert.False(t, ok)
+}
+
+func TestRateOverride_Overlaps(t *testing.T) {
+	groupA := payroll.JobGroup("A")
+	nov := time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)
+	endOct := nov.AddDate(0, 0, -1)
+
+	existing := payroll.RateOverride{EmployeeId: 1, JobGroup: &groupA, EffectiveFrom: &nov}
+
+	assert.True(t, existing.Overlaps(payroll.RateOverride{EmployeeId: 1, JobGroup: &groupA}))
+	assert.False(t, existing.Overlaps(payroll.RateOverride{EmployeeId: 1, JobGroup: &groupA, EffectiveTo: &endOct}))
+	assert.False(t, existing.Overlaps(payroll.RateOverride{EmployeeId: 1}))
+	assert.False(t, existing.Overlaps(payroll.RateOverride{EmployeeId: 2, JobGroup: &groupA}))
+}
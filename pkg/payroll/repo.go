@@ -8,23 +8,46 @@ import (
 	"time"
 
 	"github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	worklogTable   = "worklog"
 	jobgroupTable  = "jobgroup_rate"
+	groupsTable    = "job_groups"
 	processedTable = "processed_files"
+	deletionTable  = "report_deletions"
+	overrideTable  = "rate_overrides"
+	holidayTable   = "holidays"
+	employeeTable  = "employees"
+	scheduleTable  = "pay_schedule_assignments"
+	periodTable    = "pay_periods"
 )
 
 var (
-	selectCols              = "employee_id, log_date, log_hours, job_group"
-	insertCols              = "employee_id, log_date, log_hours, job_group, updated_ts"
-	insertColsCount         = 5
-	selectJobGroupRateQuery = "select job_group, rate from " + jobgroupTable + ";"
-	selectLogsQuery         = "select " + selectCols + " from " + worklogTable + " order by log_date limit $1 offset $2;"
-	insertFileIdQuery       = "insert into " + processedTable + " values ($1);"
-	insertLogsQuery         = "insert into " + worklogTable + " (" + insertCols + ") values <replace> returning id;"
+	selectCols      = "employee_id, log_date, log_hours, rounded_hours, job_group, cost_center, department, adjustment, coalesce(uploaded_ts, updated_ts)"
+	insertCols      = "employee_id, log_date, log_hours, rounded_hours, job_group, updated_ts, report_id, report_version, line, uploaded_ts, cost_center, department, adjustment"
+	insertColsCount = 13
+	// work logs inserted before provenance was recorded have no report, line or upload time
+	selectProvenanceCols    = "id, employee_id, log_date, log_hours, rounded_hours, job_group, coalesce(report_id, 0), coalesce(report_version, 0), coalesce(line, 0), coalesce(uploaded_ts, updated_ts), retired_ts, cost_center, department, adjustment"
+	selectJobGroupRateQuery = "select job_group, version, rate, effective_from, effective_to from " + jobgroupTable + " order by job_group, version;"
+	// work logs of superseded report versions are retired, and left out of the report
+	selectActiveLogsQuery      = "select " + selectCols + " from " + worklogTable + " where retired_ts is null order by log_date;"
+	selectEmployeeLogsQuery    = "select " + selectProvenanceCols + " from " + worklogTable + " where retired_ts is null and employee_id = any($1) order by log_date;"
+	insertFileIdQuery          = "insert into " + processedTable + " (id, version, created_ts) values ($1, $2, $3);"
+	insertLogsQuery            = "insert into " + worklogTable + " (" + insertCols + ") values <replace> returning id;"
+	selectLatestVersionQuery   = "select version from " + processedTable + " where id = $1 and superseded_ts is null for update;"
+	supersedeFileQuery         = "update " + processedTable + " set superseded_ts = $2 where id = $1 and superseded_ts is null;"
+	selectReportEmployeesQuery = "select distinct employee_id from " + worklogTable + " where report_id = $1 and retired_ts is null;"
+	retireLogsQuery            = "update " + worklogTable + " set retired_ts = $2 where report_id = $1 and retired_ts is null;"
+	selectVersionsQuery        = "select p.version, p.created_ts, p.superseded_ts, count(w.id) from " + processedTable + " p " +
+		"left join " + worklogTable + " w on w.report_id = p.id and w.report_version = p.version " +
+		"where p.id = $1 group by p.version, p.created_ts, p.superseded_ts order by p.version;"
+	deleteReportLogsQuery   = "delete from " + worklogTable + " where report_id = $1;"
+	deleteFileIdQuery       = "delete from " + processedTable + " where id = $1;"
+	insertReportDeleteQuery = "insert into " + deletionTable + " (report_id, versions, worklog_count, deleted_by, deleted_ts) values ($1, $2, $3, $4, $5);"
 )
 
 type payrollRepository struct {
@@ -37,10 +60,11 @@ func NewPayrollRepository(dbW *db.DbWrapper) *payrollRepository {
 	}
 }
 
+// GetJobGroupRates func returns every rate version of every job group
 func (r payrollRepository) GetJobGroupRates() ([]JobGroupRate, error) {
 	gr := make([]JobGroupRate, 0)
 
-	rows, err := r.dbW.DB.Query(selectJobGroupRateQuery)
+	rows, err := r.dbW.Querier().Query(selectJobGroupRateQuery)
 	if err != nil {
 		logrus.Errorf(fmt.Sprintf("error while fetching group rates: %v", err))
 		return gr, err
@@ -49,9 +73,8 @@ func (r payrollRepository) GetJobGroupRates() ([]JobGroupRate, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		var j JobGroupRate
-
-		if err := rows.Scan(&j.JobGroup, &j.Rate); err != nil {
+		j, err := scanJobGroupRate(rows)
+		if err != nil {
 			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
 			return gr, err
 		}
@@ -62,10 +85,12 @@ func (r payrollRepository) GetJobGroupRates() ([]JobGroupRate, error) {
 	return gr, nil
 }
 
-func (r payrollRepository) Get(limit, offset uint64) ([]WorkLog, error) {
+// GetActive func returns every work log of the latest report versions, reports are built from all of them as
+// overtime weeks, daily caps and adjustments span logs of any upload
+func (r payrollRepository) GetActive() ([]WorkLog, error) {
 	wl := make([]WorkLog, 0)
 
-	rows, err := r.dbW.DB.Query(selectLogsQuery, limit, offset)
+	rows, err := r.dbW.DB.Query(selectActiveLogsQuery)
 	if err != nil {
 		logrus.Errorf(fmt.Sprintf("error while fetching work logs: %v", err))
 		return wl, err
@@ -75,8 +100,92 @@ func (r payrollRepository) Get(limit, offset uint64) ([]WorkLog, error) {
 
 	for rows.Next() {
 		var j WorkLog
+		var roundedHours decimal.NullDecimal
+		var costCenter, department sql.NullString
+
+		if err := rows.Scan(&j.EmployeeId, &j.Date, &j.HoursLogged, &roundedHours, &j.JobGroup, &costCenter, &department, &j.Adjustment,
+			&j.UploadedTs); err != nil {
+			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
+			return wl, err
+		}
+		j.CostCenter, j.Department = nullStringPtr(costCenter), nullStringPtr(department)
+		if roundedHours.Valid {
+			j.RoundedHours = &roundedHours.Decimal
+		}
+
+		wl = append(wl, j)
+	}
+
+	return wl, nil
+}
+
+// scanProvenanceLog func scans a work log selected with its provenance columns
+func scanProvenanceLog(row rowScanner) (WorkLog, error) {
+	var j WorkLog
+	var roundedHours decimal.NullDecimal
+	var retiredTs sql.NullTime
+	var costCenter, department sql.NullString
+
+	if err := row.Scan(&j.Id, &j.EmployeeId, &j.Date, &j.HoursLogged, &roundedHours, &j.JobGroup, &j.ReportId, &j.ReportVersion,
+		&j.Line, &j.UploadedTs, &retiredTs, &costCenter, &department, &j.Adjustment); err != nil {
+		return j, err
+	}
+	j.CostCenter, j.Department = nullStringPtr(costCenter), nullStringPtr(department)
+	if roundedHours.Valid {
+		j.RoundedHours = &roundedHours.Decimal
+	}
+	if retiredTs.Valid {
+		j.RetiredTs = &retiredTs.Time
+	}
+
+	return j, nil
+}
+
+// GetWorkLogs func returns the work logs matching the filter along with their provenance
+func (r payrollRepository) GetWorkLogs(filter WorkLogFilter) ([]WorkLog, error) {
+	wl := make([]WorkLog, 0)
+
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.Replace(condition, "<replace>", "$"+strconv.Itoa(len(args)), 1))
+	}
+
+	if !filter.IncludeRetired {
+		conditions = append(conditions, "retired_ts is null")
+	}
+	if filter.EmployeeId != nil {
+		addCondition("employee_id = <replace>", *filter.EmployeeId)
+	}
+	if filter.ReportId != nil {
+		addCondition("report_id = <replace>", *filter.ReportId)
+	}
+	if filter.StartDate != nil {
+		addCondition("log_date >= <replace>", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		addCondition("log_date <= <replace>", *filter.EndDate)
+	}
+
+	query := "select " + selectProvenanceCols + " from " + worklogTable
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" order by log_date, id limit $%d offset $%d;", len(args)-1, len(args))
+
+	rows, err := r.dbW.DB.Query(query, args...)
+	if err != nil {
+		logrus.Errorf(fmt.Sprintf("error while fetching work logs: %v", err))
+		return wl, err
+	}
 
-		if err := rows.Scan(&j.EmployeeId, &j.Date, &j.HoursLogged, &j.JobGroup); err != nil {
+	defer rows.Close()
+
+	for rows.Next() {
+		j, err := scanProvenanceLog(rows)
+		if err != nil {
 			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
 			return wl, err
 		}
@@ -87,7 +196,33 @@ func (r payrollRepository) Get(limit, offset uint64) ([]WorkLog, error) {
 	return wl, nil
 }
 
-func (r payrollRepository) InsertFileId(id int) error {
+// GetByEmployees func returns every active work log of the employees along with their provenance,
+// reading through the running tx if there's one
+func (r payrollRepository) GetByEmployees(employeeIds []int) ([]WorkLog, error) {
+	wl := make([]WorkLog, 0)
+
+	rows, err := r.dbW.Querier().Query(selectEmployeeLogsQuery, pq.Array(employeeIds))
+	if err != nil {
+		logrus.Errorf(fmt.Sprintf("error while fetching work logs: %v", err))
+		return wl, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		j, err := scanProvenanceLog(rows)
+		if err != nil {
+			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
+			return wl, err
+		}
+
+		wl = append(wl, j)
+	}
+
+	return wl, nil
+}
+
+func (r payrollRepository) InsertFileId(id int, version int) error {
 	if r.dbW.Tx == nil {
 		logrus.Errorf("not running in tx, stopping")
 		return fmt.Errorf("no tx running")
@@ -95,7 +230,7 @@ func (r payrollRepository) InsertFileId(id int) error {
 
 	var rows *sql.Rows
 	var err error
-	if rows, err = r.dbW.Tx.Query(insertFileIdQuery, id); err != nil {
+	if rows, err = r.dbW.Tx.Query(insertFileIdQuery, id, version, time.Now()); err != nil {
 		logrus.Infof("error while inserting file id: %v", err)
 		r.dbW.Tx.Rollback()
 		return fmt.Errorf("error while inserting file id: %v", err)
@@ -105,6 +240,151 @@ func (r payrollRepository) InsertFileId(id int) error {
 	return nil
 }
 
+// GetReportEmployees func returns the employees with work logs in the latest version of a report
+func (r payrollRepository) GetReportEmployees(id int) ([]int, error) {
+	ids := make([]int, 0)
+
+	rows, err := r.dbW.Querier().Query(selectReportEmployeesQuery, id)
+	if err != nil {
+		logrus.Errorf(fmt.Sprintf("error while fetching report employees: %v", err))
+		return ids, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+
+		if err := rows.Scan(&id); err != nil {
+			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// SupersedeFileId func marks the latest version of a report as superseded and retires its work logs,
+// returning the version the amendment should be inserted as
+func (r payrollRepository) SupersedeFileId(id int) (int, error) {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return 0, fmt.Errorf("no tx running")
+	}
+
+	var version int
+	err := r.dbW.Tx.QueryRow(selectLatestVersionQuery, id).Scan(&version)
+	if err == sql.ErrNoRows {
+		r.dbW.Tx.Rollback()
+		return 0, ErrReportNotFound
+	} else if err != nil {
+		logrus.Errorf("error while fetching report version: %v", err)
+		r.dbW.Tx.Rollback()
+		return 0, err
+	}
+
+	now := time.Now()
+	if _, err := r.dbW.Tx.Exec(supersedeFileQuery, id, now); err != nil {
+		logrus.Errorf("error while superseding report: %v", err)
+		r.dbW.Tx.Rollback()
+		return 0, err
+	}
+
+	if _, err := r.dbW.Tx.Exec(retireLogsQuery, id, now); err != nil {
+		logrus.Errorf("error while retiring work logs: %v", err)
+		r.dbW.Tx.Rollback()
+		return 0, err
+	}
+
+	return version + 1, nil
+}
+
+// GetFileVersions func returns every version of a report, including superseded ones
+func (r payrollRepository) GetFileVersions(id int) ([]ReportVersion, error) {
+	vs := make([]ReportVersion, 0)
+
+	rows, err := r.dbW.DB.Query(selectVersionsQuery, id)
+	if err != nil {
+		logrus.Errorf(fmt.Sprintf("error while fetching report versions: %v", err))
+		return vs, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		v := ReportVersion{
+			ReportId: id,
+		}
+		var supersededTs sql.NullTime
+
+		if err := rows.Scan(&v.Version, &v.CreatedTs, &supersededTs, &v.WorkLogs); err != nil {
+			logrus.Error(fmt.Sprintf("unable to scan db rows: %v", err))
+			return vs, err
+		}
+		if supersededTs.Valid {
+			v.SupersededTs = &supersededTs.Time
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+// DeleteReport func deletes every version of a report and all the work logs inserted from it,
+// and records the deletion in the audit table
+func (r payrollRepository) DeleteReport(id int, deletedBy string) (ReportDeletion, error) {
+	if r.dbW.Tx == nil {
+		logrus.Errorf("not running in tx, stopping")
+		return ReportDeletion{}, fmt.Errorf("no tx running")
+	}
+
+	res, err := r.dbW.Tx.Exec(deleteReportLogsQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting work logs: %v", err)
+		r.dbW.Tx.Rollback()
+		return ReportDeletion{}, err
+	}
+	logs, _ := res.RowsAffected()
+
+	res, err = r.dbW.Tx.Exec(deleteFileIdQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting file id: %v", err)
+		r.dbW.Tx.Rollback()
+		return ReportDeletion{}, err
+	}
+	versions, _ := res.RowsAffected()
+	if versions == 0 {
+		r.dbW.Tx.Rollback()
+		return ReportDeletion{}, ErrReportNotFound
+	}
+
+	d := ReportDeletion{
+		ReportId:  id,
+		Versions:  int(versions),
+		WorkLogs:  int(logs),
+		DeletedBy: deletedBy,
+		DeletedTs: time.Now(),
+	}
+	if _, err := r.dbW.Tx.Exec(insertReportDeleteQuery, d.ReportId, d.Versions, d.WorkLogs, d.DeletedBy, d.DeletedTs); err != nil {
+		logrus.Errorf("error while recording report deletion: %v", err)
+		r.dbW.Tx.Rollback()
+		return ReportDeletion{}, err
+	}
+
+	return d, nil
+}
+
+// nullStringPtr func returns nil for a null column
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
 func (r payrollRepository) CreateN(js []WorkLog) ([]uint64, error) {
 	var ids []uint64
 
@@ -142,11 +422,6 @@ func (r payrollRepository) CreateN(js []WorkLog) ([]uint64, error) {
 
 	logrus.Debugf(fmt.Sprintf("created logs with id: %d", ids))
 
-	if err := r.dbW.Tx.Commit(); err != nil {
-		r.dbW.Tx.Rollback()
-		return nil, err
-	}
-
 	return ids, nil
 }
 
@@ -200,7 +475,8 @@ func PlaceholderGenBulk(query string, argsLen int, totalArgs int, startIdx int)
 	return strings.Replace(query, "<replace>", res.String(), 1), nil
 }
 
-// "employee_id, log_date, log_hours, job_group"
+// "employee_id, log_date, log_hours, rounded_hours, job_group, updated_ts, report_id, report_version, line, uploaded_ts,
+// cost_center, department, adjustment"
 func FlattenLogInsertArgs(params []WorkLog) []any {
 	r := make([]any, 0)
 	now := time.Now()
@@ -209,8 +485,16 @@ func FlattenLogInsertArgs(params []WorkLog) []any {
 		r = append(r, param.EmployeeId)
 		r = append(r, param.Date)
 		r = append(r, param.HoursLogged)
+		r = append(r, param.RoundedHours)
 		r = append(r, param.JobGroup)
 		r = append(r, now)
+		r = append(r, param.ReportId)
+		r = append(r, param.ReportVersion)
+		r = append(r, param.Line)
+		r = append(r, param.UploadedTs)
+		r = append(r, param.CostCenter)
+		r = append(r, param.Department)
+		r = append(r, param.Adjustment)
 	}
 
 	return r
@@ -2,6 +2,7 @@ package payroll_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -12,12 +13,12 @@ import (
 )
 
 var (
-	selectCols              = "employee_id, log_date, log_hours, job_group"
-	insertCols              = "employee_id, log_date, log_hours, job_group, updated_ts"
-	insertColsCount         = 5
-	selectJobGroupRateQuery = "select job_group, rate from jobgroup_rate;"
-	selectLogsQuery         = "select " + selectCols + " from worklog order by log_date limit $1 offset $2;"
-	insertFileIdQuery       = "insert into processed_files values ($1);"
+	selectCols              = "employee_id, log_date, log_hours, rounded_hours, job_group, cost_center, department, adjustment, coalesce(uploaded_ts, updated_ts)"
+	insertCols              = "employee_id, log_date, log_hours, rounded_hours, job_group, updated_ts, report_id, report_version, line, uploaded_ts, cost_center, department, adjustment"
+	insertColsCount         = 13
+	selectJobGroupRateQuery = "select job_group, version, rate, effective_from, effective_to from jobgroup_rate order by job_group, version;"
+	selectActiveLogsQuery   = "select " + selectCols + " from worklog where retired_ts is null order by log_date;"
+	insertFileIdQuery       = "insert into processed_files (id, version, created_ts) values ($1, $2, $3);"
 	insertLogsQuery         = "insert into worklog (" + insertCols + ") values <replace> returning id;"
 	timeVal                 = time.Now()
 )
@@ -33,16 +34,20 @@ func TestGetJobGroupRates(t *testing.T) {
 		DB: db,
 	})
 
+	from := time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, -1)
 	expectedRates := []payroll.JobGroupRate{
-		{JobGroup: "A", Rate: 30},
-		{JobGroup: "B", Rate: 20},
+		{JobGroup: "A", Version: 1, Rate: dec("20"), EffectiveTo: &to},
+		{JobGroup: "A", Version: 2, Rate: dec("30"), EffectiveFrom: &from},
+		{JobGroup: "B", Version: 1, Rate: dec("20")},
 	}
 
-	rows := sqlmock.NewRows([]string{"job_group", "rate"}).
-		AddRow("A", 30).
-		AddRow("B", 20)
+	rows := sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).
+		AddRow("A", 1, 20, nil, to).
+		AddRow("A", 2, 30, from, nil).
+		AddRow("B", 1, 20, nil, nil)
 
-	mock.ExpectQuery("select job_group, rate from jobgroup_rate;").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(selectJobGroupRateQuery)).WillReturnRows(rows)
 
 	actualRates, err := repo.GetJobGroupRates()
 
@@ -63,7 +68,7 @@ func TestGetJobGroupRates_ErrorOnQuery(t *testing.T) {
 	})
 
 	expectedError := fmt.Errorf("query error")
-	mock.ExpectQuery("select job_group, rate from jobgroup_rate;").WillReturnError(expectedError)
+	mock.ExpectQuery(regexp.QuoteMeta(selectJobGroupRateQuery)).WillReturnError(expectedError)
 
 	_, err = repo.GetJobGroupRates()
 
@@ -83,11 +88,11 @@ func TestGetJobGroupRates_ErrorOnScan(t *testing.T) {
 		DB: db,
 	})
 
-	rows := sqlmock.NewRows([]string{"job_group", "rate"}).
-		AddRow("A", 30).
-		AddRow("B", "invalid")
+	rows := sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).
+		AddRow("A", 1, 30, nil, nil).
+		AddRow("B", 1, "invalid", nil, nil)
 
-	mock.ExpectQuery("select job_group, rate from jobgroup_rate;").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(selectJobGroupRateQuery)).WillReturnRows(rows)
 
 	_, err = repo.GetJobGroupRates()
 
@@ -109,17 +114,20 @@ func TestCreateN(t *testing.T) {
 		Tx: tx,
 	})
 
+	rounded := dec("8")
+	costCenter, department := "CC-100", "R&D"
 	expectedLogs := []payroll.WorkLog{
-		{EmployeeId: 1, Date: timeVal, HoursLogged: 8, JobGroup: "A"},
-		{EmployeeId: 2, Date: timeVal, HoursLogged: 6, JobGroup: "B"},
+		{EmployeeId: 1, Date: timeVal, HoursLogged: dec("8.1"), RoundedHours: &rounded, JobGroup: "A", ReportId: 42, ReportVersion: 1, Line: 2, UploadedTs: timeVal,
+			CostCenter: &costCenter, Department: &department},
+		{EmployeeId: 2, Date: timeVal, HoursLogged: dec("6"), JobGroup: "B", ReportId: 42, ReportVersion: 1, Line: 3, UploadedTs: timeVal},
 	}
 
 	rows := sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2)
 
 	mock.ExpectQuery("insert into worklog *").
-		WithArgs(1, timeVal, 8.0, "A", sqlmock.AnyArg(), 2, timeVal, 6.0, "B", sqlmock.AnyArg()).
+		WithArgs(1, timeVal, dec("8.1"), dec("8"), "A", sqlmock.AnyArg(), 42, 1, 2, timeVal, &costCenter, &department, false,
+			2, timeVal, dec("6"), nil, "B", sqlmock.AnyArg(), 42, 1, 3, timeVal, nil, nil, false).
 		WillReturnRows(rows)
-	mock.ExpectCommit()
 
 	_, err = repo.CreateN(expectedLogs)
 
@@ -141,14 +149,18 @@ func TestCreateN_ErrorOnQuery(t *testing.T) {
 		Tx: tx,
 	})
 
+	rounded := dec("8")
+	costCenter, department := "CC-100", "R&D"
 	expectedLogs := []payroll.WorkLog{
-		{EmployeeId: 1, Date: timeVal, HoursLogged: 8, JobGroup: "A"},
-		{EmployeeId: 2, Date: timeVal, HoursLogged: 6, JobGroup: "B"},
+		{EmployeeId: 1, Date: timeVal, HoursLogged: dec("8.1"), RoundedHours: &rounded, JobGroup: "A", ReportId: 42, ReportVersion: 1, Line: 2, UploadedTs: timeVal,
+			CostCenter: &costCenter, Department: &department},
+		{EmployeeId: 2, Date: timeVal, HoursLogged: dec("6"), JobGroup: "B", ReportId: 42, ReportVersion: 1, Line: 3, UploadedTs: timeVal},
 	}
 
 	expectedError := fmt.Errorf("query error")
 	mock.ExpectQuery("insert into worklog (.+) values (.+) returning id;").
-		WithArgs(1, timeVal, 8.0, "A", sqlmock.AnyArg(), 2, timeVal, 6.0, "B", sqlmock.AnyArg()).
+		WithArgs(1, timeVal, dec("8.1"), dec("8"), "A", sqlmock.AnyArg(), 42, 1, 2, timeVal, &costCenter, &department, false,
+			2, timeVal, dec("6"), nil, "B", sqlmock.AnyArg(), 42, 1, 3, timeVal, nil, nil, false).
 		WillReturnError(expectedError)
 
 	_, err = repo.CreateN(expectedLogs)
@@ -172,9 +184,12 @@ func TestCreateN_ErrorOnScan(t *testing.T) {
 		Tx: tx,
 	})
 
+	rounded := dec("8")
+	costCenter, department := "CC-100", "R&D"
 	expectedLogs := []payroll.WorkLog{
-		{EmployeeId: 1, Date: timeVal, HoursLogged: 8, JobGroup: "A"},
-		{EmployeeId: 2, Date: timeVal, HoursLogged: 6, JobGroup: "B"},
+		{EmployeeId: 1, Date: timeVal, HoursLogged: dec("8.1"), RoundedHours: &rounded, JobGroup: "A", ReportId: 42, ReportVersion: 1, Line: 2, UploadedTs: timeVal,
+			CostCenter: &costCenter, Department: &department},
+		{EmployeeId: 2, Date: timeVal, HoursLogged: dec("6"), JobGroup: "B", ReportId: 42, ReportVersion: 1, Line: 3, UploadedTs: timeVal},
 	}
 
 	rows := sqlmock.NewRows([]string{"id"}).
@@ -182,7 +197,8 @@ func TestCreateN_ErrorOnScan(t *testing.T) {
 		AddRow("invalid")
 
 	mock.ExpectQuery("insert into worklog (.+) values (.+) returning id;").
-		WithArgs(1, timeVal, 8.0, "A", sqlmock.AnyArg(), 2, timeVal, 6.0, "B", sqlmock.AnyArg()).
+		WithArgs(1, timeVal, dec("8.1"), dec("8"), "A", sqlmock.AnyArg(), 42, 1, 2, timeVal, &costCenter, &department, false,
+			2, timeVal, dec("6"), nil, "B", sqlmock.AnyArg(), 42, 1, 3, timeVal, nil, nil, false).
 		WillReturnRows(rows)
 
 	_, err = repo.CreateN(expectedLogs)
@@ -191,6 +207,217 @@ func TestCreateN_ErrorOnScan(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	rows := sqlmock.NewRows([]string{"employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "cost_center", "department",
+		"adjustment", "uploaded_ts"}).
+		AddRow(1, timeVal, "8.1", "8", "A", nil, nil, false, timeVal).
+		AddRow(2, timeVal, "4", nil, "B", nil, nil, false, timeVal)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectActiveLogsQuery)).WithArgs().WillReturnRows(rows)
+
+	logs, err := repo.GetActive()
+
+	// logs are paid the hours rounded on upload, logs inserted before hours were rounded have none
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assertEqualDecimals(t, dec("8"), logs[0].PaidHours())
+	assert.Nil(t, logs[1].RoundedHours)
+	assertEqualDecimals(t, dec("4"), logs[1].PaidHours())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByEmployees(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "report_id",
+		"report_version", "line", "uploaded_ts", "retired_ts", "cost_center", "department", "adjustment"}).
+		AddRow(7, 1, timeVal, "8.1", nil, "A", 42, 1, 5, timeVal, nil, nil, nil, false)
+
+	mock.ExpectQuery("select (.+) from worklog where retired_ts is null and employee_id = any\\(\\$1\\) order by log_date;").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	logs, err := repo.GetByEmployees([]int{1})
+
+	assert.NoError(t, err)
+	assertEqualDecimals(t, []payroll.WorkLog{
+		{Id: 7, EmployeeId: 1, Date: timeVal, HoursLogged: dec("8.1"), JobGroup: "A", ReportId: 42, ReportVersion: 1, Line: 5, UploadedTs: timeVal},
+	}, logs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSupersedeFileId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectQuery("select version from processed_files where id = (.+) for update;").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec("update processed_files set superseded_ts").
+		WithArgs(42, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("update worklog set retired_ts").
+		WithArgs(42, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	version, err := repo.SupersedeFileId(42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSupersedeFileId_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectQuery("select version from processed_files where id = (.+) for update;").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectRollback()
+
+	_, err = repo.SupersedeFileId(42)
+
+	assert.ErrorIs(t, err, payroll.ErrReportNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteReport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectExec("delete from worklog where report_id = (.+);").
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec("delete from processed_files where id = (.+);").
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("insert into report_deletions").
+		WithArgs(42, 2, 5, "josh", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	d, err := repo.DeleteReport(42, "josh")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d.Versions)
+	assert.Equal(t, 5, d.WorkLogs)
+	assert.Equal(t, "josh", d.DeletedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteReport_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, _ := db.Begin()
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+		Tx: tx,
+	})
+
+	mock.ExpectExec("delete from worklog where report_id = (.+);").
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("delete from processed_files where id = (.+);").
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = repo.DeleteReport(42, "josh")
+
+	assert.ErrorIs(t, err, payroll.ErrReportNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWorkLogs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	employeeId := 1
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "report_id",
+		"report_version", "line", "uploaded_ts", "retired_ts", "cost_center", "department", "adjustment"}).
+		AddRow(7, 1, timeVal, "8.1", "8", "A", 42, 1, 5, timeVal, nil, nil, nil, false)
+
+	mock.ExpectQuery("select (.+) from worklog where retired_ts is null and employee_id = \\$1 order by log_date, id limit \\$2 offset \\$3;").
+		WithArgs(1, 100, 0).
+		WillReturnRows(rows)
+
+	logs, err := repo.GetWorkLogs(payroll.WorkLogFilter{
+		EmployeeId: &employeeId,
+		Limit:      100,
+	})
+
+	assert.NoError(t, err)
+	rounded := dec("8")
+	assertEqualDecimals(t, []payroll.WorkLog{
+		{Id: 7, EmployeeId: 1, Date: timeVal, HoursLogged: dec("8.1"), RoundedHours: &rounded, JobGroup: "A", ReportId: 42, ReportVersion: 1, Line: 5,
+			UploadedTs: timeVal},
+	}, logs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestPlaceholderGen(t *testing.T) {
 	query := "insert into worklog (employee_id, log_date, log_hours) values <replace>;"
 	argsLen := 3
@@ -257,12 +484,12 @@ func TestPlaceholderGenBulk_NoReplace(t *testing.T) {
 
 func TestFlattenLogInsertArgs(t *testing.T) {
 	params := []payroll.WorkLog{
-		{EmployeeId: 1, Date: time.Now(), HoursLogged: 8, JobGroup: "A"},
-		{EmployeeId: 2, Date: time.Now(), HoursLogged: 6, JobGroup: "B"},
+		{EmployeeId: 1, Date: time.Now(), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 2, Date: time.Now(), HoursLogged: dec("6"), JobGroup: "B"},
 	}
 
 	result := payroll.FlattenLogInsertArgs(params)
-	assert.Equal(t, 10, len(result))
+	assert.Equal(t, 26, len(result))
 }
 
 func TestFlattenLogInsertArgs_EmptyParams(t *testing.T) {
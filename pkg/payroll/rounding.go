@@ -0,0 +1,74 @@
+package payroll
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// amountPlaces is the number of decimal places amounts are rounded to, ie. cents
+const amountPlaces = 2
+
+// RoundingMode decides how an amount halfway between two cents is rounded
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds halves away from zero, eg. 0.125 to 0.13
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfEven rounds halves to the even cent, aka. banker's rounding, eg. 0.125 to 0.12
+	RoundHalfEven RoundingMode = "half_even"
+)
+
+// RoundingScope decides which amounts of a report are rounded
+type RoundingScope string
+
+const (
+	// RoundLine rounds each line item and premium, the total is the sum of the rounded amounts
+	RoundLine RoundingScope = "line"
+	// RoundTotal keeps the exact line amounts, and only rounds the employee's total
+	RoundTotal RoundingScope = "total"
+)
+
+// Rounding decides how report amounts are rounded to cents, the zero value rounds half up per line
+type Rounding struct {
+	Mode  RoundingMode
+	Scope RoundingScope
+}
+
+// ParseRoundingMode func converts config value to a rounding mode, empty value is half up
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch RoundingMode(s) {
+	case "", RoundHalfUp:
+		return RoundHalfUp, nil
+	case RoundHalfEven, "bankers":
+		return RoundHalfEven, nil
+	}
+	return "", fmt.Errorf("unknown rounding mode: %s", s)
+}
+
+// ParseRoundingScope func converts config value to a rounding scope, empty value is per line
+func ParseRoundingScope(s string) (RoundingScope, error) {
+	switch RoundingScope(s) {
+	case "", RoundLine:
+		return RoundLine, nil
+	case RoundTotal:
+		return RoundTotal, nil
+	}
+	return "", fmt.Errorf("unknown rounding scope: %s", s)
+}
+
+// Round func rounds the amount to cents
+func (r Rounding) Round(amount decimal.Decimal) decimal.Decimal {
+	if r.Mode == RoundHalfEven {
+		return amount.RoundBank(amountPlaces)
+	}
+	return amount.Round(amountPlaces)
+}
+
+// line func rounds the amount of a line when rounding per line, otherwise it is kept exact
+func (r Rounding) line(amount decimal.Decimal) decimal.Decimal {
+	if r.Scope == RoundTotal {
+		return amount
+	}
+	return r.Round(amount)
+}
@@ -0,0 +1,71 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRounding_Round(t *testing.T) {
+	halfUp := payroll.Rounding{Mode: payroll.RoundHalfUp}
+	halfEven := payroll.Rounding{Mode: payroll.RoundHalfEven}
+
+	assert.Equal(t, "0.13", halfUp.Round(dec("0.125")).String())
+	assert.Equal(t, "0.12", halfEven.Round(dec("0.125")).String())
+	assert.Equal(t, "0.14", halfEven.Round(dec("0.135")).String())
+	assert.Equal(t, "-0.13", halfUp.Round(dec("-0.125")).String())
+}
+
+func TestGenerateReport_Rounding(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{
+		{JobGroup: "A", Version: 1, Rate: dec("0.125")},
+		{JobGroup: "B", Version: 1, Rate: dec("0.125")},
+	}, nil)
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: time.Date(2023, 11, 6, 0, 0, 0, 0, time.Local), HoursLogged: dec("1"), JobGroup: "A"},
+		{EmployeeId: 1, Date: time.Date(2023, 11, 6, 0, 0, 0, 0, time.Local), HoursLogged: dec("1"), JobGroup: "B"},
+	}
+
+	tests := []struct {
+		name     string
+		rounding payroll.Rounding
+		line     string
+		total    string
+	}{
+		{name: "half up per line", rounding: payroll.Rounding{Mode: payroll.RoundHalfUp, Scope: payroll.RoundLine}, line: "0.13", total: "0.26"},
+		{name: "half even per line", rounding: payroll.Rounding{Mode: payroll.RoundHalfEven, Scope: payroll.RoundLine}, line: "0.12", total: "0.24"},
+		{name: "half up per total", rounding: payroll.Rounding{Mode: payroll.RoundHalfUp, Scope: payroll.RoundTotal}, line: "0.125", total: "0.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := payroll.GenerateReport(payroll.ReportConfig{Rounding: tt.rounding}, rates, logs)
+
+			assert.Len(t, report.EmployeeReports, 1)
+			assert.Equal(t, tt.line, report.EmployeeReports[0].LineItems[0].Amount.String())
+			assert.Equal(t, tt.total, report.EmployeeReports[0].AmountPaid.String())
+		})
+	}
+}
+
+func TestParseRounding(t *testing.T) {
+	mode, err := payroll.ParseRoundingMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.RoundHalfUp, mode)
+
+	mode, err = payroll.ParseRoundingMode("bankers")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.RoundHalfEven, mode)
+
+	_, err = payroll.ParseRoundingMode("down")
+	assert.Error(t, err)
+
+	scope, err := payroll.ParseRoundingScope("total")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.RoundTotal, scope)
+
+	_, err = payroll.ParseRoundingScope("employee")
+	assert.Error(t, err)
+}
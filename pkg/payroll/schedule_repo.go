@@ -0,0 +1,80 @@
+package payroll
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	scheduleCols                   = "id, employee_id, schedule, effective_from, created_ts"
+	selectScheduleAssignmentsQuery = "select " + scheduleCols + " from " + scheduleTable + " order by employee_id, effective_from;"
+	selectEmployeeSchedulesQuery   = "select " + scheduleCols + " from " + scheduleTable + " where employee_id = $1 order by effective_from;"
+	insertScheduleAssignmentQuery  = "insert into " + scheduleTable + " (employee_id, schedule, effective_from, created_ts) values ($1, $2, $3, $4) returning id;"
+	deleteScheduleAssignmentQuery  = "delete from " + scheduleTable + " where id = $1;"
+)
+
+func queryScheduleAssignments(rows *sql.Rows) ([]ScheduleAssignment, error) {
+	defer rows.Close()
+
+	assignments := make([]ScheduleAssignment, 0)
+	for rows.Next() {
+		var a ScheduleAssignment
+		if err := rows.Scan(&a.Id, &a.EmployeeId, &a.Schedule, &a.EffectiveFrom, &a.CreatedTs); err != nil {
+			logrus.Errorf("unable to scan db rows: %v", err)
+			return assignments, err
+		}
+
+		assignments = append(assignments, a)
+	}
+
+	return assignments, nil
+}
+
+// GetScheduleAssignments func returns the pay schedule assignments of the employee, or of every employee if not set
+func (r payrollRepository) GetScheduleAssignments(employeeId *int) ([]ScheduleAssignment, error) {
+	var rows *sql.Rows
+	var err error
+	if employeeId != nil {
+		rows, err = r.dbW.Querier().Query(selectEmployeeSchedulesQuery, *employeeId)
+	} else {
+		rows, err = r.dbW.Querier().Query(selectScheduleAssignmentsQuery)
+	}
+	if err != nil {
+		logrus.Errorf("error while fetching pay schedule assignments: %v", err)
+		return nil, err
+	}
+
+	return queryScheduleAssignments(rows)
+}
+
+func (r payrollRepository) InsertScheduleAssignment(a ScheduleAssignment) (ScheduleAssignment, error) {
+	a.CreatedTs = time.Now()
+
+	err := r.dbW.DB.QueryRow(insertScheduleAssignmentQuery, a.EmployeeId, a.Schedule, a.EffectiveFrom, a.CreatedTs).Scan(&a.Id)
+	if isPqError(err, uniqueViolation) {
+		return ScheduleAssignment{}, ErrScheduleAssignmentExists
+	} else if isPqError(err, foreignKeyViolation) {
+		return ScheduleAssignment{}, ErrEmployeeNotFound
+	} else if err != nil {
+		logrus.Errorf("error while inserting pay schedule assignment: %v", err)
+		return ScheduleAssignment{}, err
+	}
+
+	return a, nil
+}
+
+func (r payrollRepository) DeleteScheduleAssignment(id int) error {
+	res, err := r.dbW.DB.Exec(deleteScheduleAssignmentQuery, id)
+	if err != nil {
+		logrus.Errorf("error while deleting pay schedule assignment: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrScheduleAssignmentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package payroll_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduleRows = []string{"id", "employee_id", "schedule", "effective_from", "created_ts"}
+
+func TestGetScheduleAssignments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("select (.+) from pay_schedule_assignments where employee_id = (.+) order by effective_from;").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(scheduleRows).
+			AddRow(1, 1, "hourly", day(6), timeVal).
+			AddRow(2, 1, "supervisors", day(20), timeVal))
+
+	employeeId := 1
+	assignments, err := repo.GetScheduleAssignments(&employeeId)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []payroll.ScheduleAssignment{
+		{Id: 1, EmployeeId: 1, Schedule: "hourly", EffectiveFrom: day(6), CreatedTs: timeVal},
+		{Id: 2, EmployeeId: 1, Schedule: "supervisors", EffectiveFrom: day(20), CreatedTs: timeVal},
+	}, assignments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertScheduleAssignment_Exists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("insert into pay_schedule_assignments (.+) returning id;").
+		WithArgs(1, "hourly", day(6), sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.InsertScheduleAssignment(payroll.ScheduleAssignment{EmployeeId: 1, Schedule: "hourly", EffectiveFrom: day(6)})
+
+	assert.ErrorIs(t, err, payroll.ErrScheduleAssignmentExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteScheduleAssignment_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectExec("delete from pay_schedule_assignments where id = (.+);").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteScheduleAssignment(3)
+
+	assert.ErrorIs(t, err, payroll.ErrScheduleAssignmentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,237 @@
+package payroll
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PayFrequency is how often employees are paid
+type PayFrequency string
+
+const (
+	PayWeekly      PayFrequency = "weekly"
+	PayBiweekly    PayFrequency = "biweekly"
+	PaySemiMonthly PayFrequency = "semi_monthly"
+	PayMonthly     PayFrequency = "monthly"
+)
+
+// PaySchedule splits the calendar into consecutive pay periods
+type PaySchedule interface {
+	// Period returns the pay period the date is in, only the date part is used
+	Period(date time.Time) PayPeriod
+}
+
+// WeeklySchedule pays every week, periods starting on the start week day
+type WeeklySchedule struct {
+	Start time.Weekday
+}
+
+// BiweeklySchedule pays every two weeks, periods starting on the anchor date or a multiple of 14 days from it
+type BiweeklySchedule struct {
+	Anchor time.Time
+}
+
+// SemiMonthlySchedule pays on the 15th and the last day of the month
+type SemiMonthlySchedule struct{}
+
+// MonthlySchedule pays on the last day of the month
+type MonthlySchedule struct{}
+
+// ParsePayFrequency func converts config value to a pay frequency, empty value is semi monthly
+func ParsePayFrequency(s string) (PayFrequency, error) {
+	switch PayFrequency(s) {
+	case "", PaySemiMonthly:
+		return PaySemiMonthly, nil
+	case PayWeekly, PayBiweekly, PayMonthly:
+		return PayFrequency(s), nil
+	}
+	return "", fmt.Errorf("unknown pay frequency: %s", s)
+}
+
+// NewPaySchedule func returns the schedule of the frequency. Weekly periods start on the week day of the anchor,
+// and biweekly periods on the anchor, which is required for them. The anchor is ignored by the other schedules
+func NewPaySchedule(frequency PayFrequency, anchor *time.Time) (PaySchedule, error) {
+	switch frequency {
+	case PayWeekly:
+		if anchor == nil {
+			return nil, fmt.Errorf("weekly pay schedule requires an anchor date")
+		}
+		return WeeklySchedule{Start: anchor.Weekday()}, nil
+	case PayBiweekly:
+		if anchor == nil {
+			return nil, fmt.Errorf("biweekly pay schedule requires an anchor date")
+		}
+		return BiweeklySchedule{Anchor: dateOf(*anchor)}, nil
+	case "", PaySemiMonthly:
+		return SemiMonthlySchedule{}, nil
+	case PayMonthly:
+		return MonthlySchedule{}, nil
+	}
+	return nil, fmt.Errorf("unknown pay frequency: %s", frequency)
+}
+
+func (s WeeklySchedule) Period(date time.Time) PayPeriod {
+	offset := (int(date.Weekday()) - int(s.Start) + 7) % 7
+	return periodOfDays(date, -offset, 7)
+}
+
+func (s BiweeklySchedule) Period(date time.Time) PayPeriod {
+	offset := daysBetween(s.Anchor, date) % 14
+	if offset < 0 {
+		offset += 14
+	}
+	return periodOfDays(date, -offset, 14)
+}
+
+func (SemiMonthlySchedule) Period(date time.Time) PayPeriod {
+	if date.Day() <= 15 {
+		return PayPeriod{
+			StartDate: time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local),
+			EndDate:   time.Date(date.Year(), date.Month(), 15, 0, 0, 0, 0, time.Local),
+		}
+	}
+	return PayPeriod{
+		StartDate: time.Date(date.Year(), date.Month(), 16, 0, 0, 0, 0, time.Local),
+		EndDate:   time.Date(date.Year(), date.Month(), DaysInMonth(date.Year(), date.Month()), 0, 0, 0, 0, time.Local),
+	}
+}
+
+func (MonthlySchedule) Period(date time.Time) PayPeriod {
+	return PayPeriod{
+		StartDate: time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local),
+		EndDate:   time.Date(date.Year(), date.Month(), DaysInMonth(date.Year(), date.Month()), 0, 0, 0, 0, time.Local),
+	}
+}
+
+// PaySchedules holds the named pay schedules employees can be assigned to, and the name of the schedule
+// each job group is paid on
+type PaySchedules struct {
+	Named     map[string]PaySchedule
+	JobGroups map[JobGroup]string
+}
+
+// ScheduleAssignments holds each employee's pay schedule assignments by effective date
+type ScheduleAssignments map[int][]ScheduleAssignment
+
+// NewScheduleAssignments func indexes the assignments by employee, sorted by effective date
+func NewScheduleAssignments(assignments []ScheduleAssignment) ScheduleAssignments {
+	a := make(ScheduleAssignments)
+	for _, assignment := range assignments {
+		a[assignment.EmployeeId] = append(a[assignment.EmployeeId], assignment)
+	}
+	for _, employeeAssignments := range a {
+		sort.Slice(employeeAssignments, func(i, j int) bool {
+			return employeeAssignments[i].EffectiveFrom.Before(employeeAssignments[j].EffectiveFrom)
+		})
+	}
+	return a
+}
+
+// Lookup func returns the name of the schedule the employee is assigned to on the date, empty before their
+// first assignment, along with the first and last days it's in force. Nil days are unbounded
+func (a ScheduleAssignments) Lookup(employeeId int, date time.Time) (string, *time.Time, *time.Time) {
+	var name string
+	var from, to *time.Time
+	for _, assignment := range a[employeeId] {
+		effectiveFrom := dateOf(assignment.EffectiveFrom)
+		if daysBetween(effectiveFrom, date) < 0 {
+			dayBefore := effectiveFrom.AddDate(0, 0, -1)
+			to = &dayBefore
+			break
+		}
+		name, from = assignment.Schedule, &effectiveFrom
+	}
+	return name, from, to
+}
+
+// paySchedule func returns the configured pay schedule, semi monthly when not set
+func (cfg ReportConfig) paySchedule() PaySchedule {
+	if cfg.Schedule == nil {
+		return SemiMonthlySchedule{}
+	}
+	return cfg.Schedule
+}
+
+// payPeriod func returns the pay period the work log is paid in. The schedule the employee is assigned to on the
+// log's date takes precedence over the schedule of the log's job group, and logs of neither are paid on the default
+// schedule. Periods are cut at the dates an employee switches schedules, so the period spanning a switch ends the
+// day before it and the new schedule's period starts on it, and every log is paid in exactly one period
+func (cfg ReportConfig) payPeriod(log WorkLog) PayPeriod {
+	schedule := cfg.paySchedule()
+	if s, ok := cfg.Schedules.Named[cfg.Schedules.JobGroups[log.JobGroup]]; ok {
+		schedule = s
+	}
+
+	name, from, to := cfg.Assignments.Lookup(log.EmployeeId, log.Date)
+	if s, ok := cfg.Schedules.Named[name]; ok {
+		schedule = s
+	}
+
+	period := schedule.Period(log.Date)
+	if from != nil && period.StartDate.Before(*from) {
+		period.StartDate = *from
+	}
+	if to != nil && period.EndDate.After(*to) {
+		period.EndDate = *to
+	}
+	return period
+}
+
+// CheckPeriodOverlaps func returns a row error for each of the logs paid in a pay period overlapping another pay
+// period of the employee, among the existing logs and the logs. Periods only overlap when an employee's logs are of
+// job groups paid on different schedules, eg. after a job group switch mid-period, which is resolved by assigning
+// the employee a pay schedule from the switch date, as periods are cut at assignments
+func CheckPeriodOverlaps(cfg ReportConfig, existing, logs []WorkLog) []RowError {
+	periods := make(map[int][]PayPeriod)
+	for _, log := range append(append([]WorkLog{}, existing...), logs...) {
+		periods[log.EmployeeId] = append(periods[log.EmployeeId], cfg.paidPeriod(log))
+	}
+
+	rowErrors := make([]RowError, 0)
+	for _, log := range logs {
+		p := cfg.paidPeriod(log)
+		for _, other := range periods[log.EmployeeId] {
+			if p.same(other) || !p.overlaps(other) {
+				continue
+			}
+			rowErrors = append(rowErrors, RowError{
+				Line:  log.Line,
+				Value: string(log.JobGroup),
+				Reason: fmt.Sprintf("pay period %s to %s of the job group overlaps the employee's pay period %s to %s, "+
+					"assign the employee a pay schedule", p.StartDate.Format(time.DateOnly), p.EndDate.Format(time.DateOnly),
+					other.StartDate.Format(time.DateOnly), other.EndDate.Format(time.DateOnly)),
+			})
+			break
+		}
+	}
+	return rowErrors
+}
+
+func (p PayPeriod) same(o PayPeriod) bool {
+	return daysBetween(p.StartDate, o.StartDate) == 0 && daysBetween(p.EndDate, o.EndDate) == 0
+}
+
+func (p PayPeriod) overlaps(o PayPeriod) bool {
+	return daysBetween(p.StartDate, o.EndDate) >= 0 && daysBetween(o.StartDate, p.EndDate) >= 0
+}
+
+// periodOfDays func returns the period of the given days starting offset days from the date
+func periodOfDays(date time.Time, offset, days int) PayPeriod {
+	start := time.Date(date.Year(), date.Month(), date.Day()+offset, 0, 0, 0, 0, time.Local)
+	return PayPeriod{
+		StartDate: start,
+		EndDate:   time.Date(start.Year(), start.Month(), start.Day()+days-1, 0, 0, 0, 0, time.Local),
+	}
+}
+
+// daysBetween func counts the calendar days from a to b, ignoring the time of day and daylight saving changes
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
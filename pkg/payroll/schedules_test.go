@@ -0,0 +1,189 @@
+package payroll_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func period(start, end time.Time) payroll.PayPeriod {
+	return payroll.PayPeriod{StartDate: start, EndDate: end}
+}
+
+func TestPaySchedules(t *testing.T) {
+	// weekly periods start on the anchor's week day, a monday, and biweekly ones every 14 days from it
+	anchor := day(6)
+	tests := []struct {
+		name      string
+		frequency payroll.PayFrequency
+		date      time.Time
+		expected  payroll.PayPeriod
+	}{
+		{"weekly", payroll.PayWeekly, day(12), period(day(6), day(12))},
+		{"weekly next period", payroll.PayWeekly, day(13), period(day(13), day(19))},
+		{"biweekly", payroll.PayBiweekly, day(19), period(day(6), day(19))},
+		{"biweekly next period", payroll.PayBiweekly, day(20), period(day(20), time.Date(2023, 12, 3, 0, 0, 0, 0, time.Local))},
+		{"biweekly before anchor", payroll.PayBiweekly, day(5), period(time.Date(2023, 10, 23, 0, 0, 0, 0, time.Local), day(5))},
+		{"semi monthly first half", payroll.PaySemiMonthly, day(15), period(day(1), day(15))},
+		{"semi monthly second half", payroll.PaySemiMonthly, day(16), period(day(16), day(30))},
+		{"monthly", payroll.PayMonthly, time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local),
+			period(time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local), time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := payroll.NewPaySchedule(tt.frequency, &anchor)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Period(tt.date))
+		})
+	}
+}
+
+func TestNewPaySchedule_RequiresAnchor(t *testing.T) {
+	_, err := payroll.NewPaySchedule(payroll.PayBiweekly, nil)
+	assert.Error(t, err)
+
+	_, err = payroll.NewPaySchedule(payroll.PayWeekly, nil)
+	assert.Error(t, err)
+
+	schedule, err := payroll.NewPaySchedule(payroll.PayMonthly, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.MonthlySchedule{}, schedule)
+}
+
+func TestParsePayFrequency(t *testing.T) {
+	frequency, err := payroll.ParsePayFrequency("")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PaySemiMonthly, frequency)
+
+	frequency, err = payroll.ParsePayFrequency("biweekly")
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PayBiweekly, frequency)
+
+	_, err = payroll.ParsePayFrequency("fortnightly")
+	assert.Error(t, err)
+}
+
+func TestGenerateReport_PaySchedule(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{{JobGroup: "A", Version: 1, Rate: dec("10")}}, nil)
+	worklogs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(14), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(17), HoursLogged: dec("4"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(20), HoursLogged: dec("2"), JobGroup: "A"},
+	}
+
+	report := payroll.GenerateReport(payroll.ReportConfig{Schedule: payroll.BiweeklySchedule{Anchor: day(6)}}, rates, worklogs)
+
+	amounts := make(map[payroll.PayPeriod]string)
+	for _, r := range report.EmployeeReports {
+		amounts[r.PayPeriod] = r.AmountPaid.String()
+	}
+	assert.Equal(t, map[payroll.PayPeriod]string{
+		period(day(6), day(19)): "120",
+		period(day(20), time.Date(2023, 12, 3, 0, 0, 0, 0, time.Local)): "20",
+	}, amounts)
+}
+
+func TestGenerateReport_ScheduleAssignments(t *testing.T) {
+	rates := payroll.NewRates([]payroll.JobGroupRate{
+		{JobGroup: "A", Version: 1, Rate: dec("10")},
+		{JobGroup: "B", Version: 1, Rate: dec("20")},
+	}, nil)
+	cfg := payroll.ReportConfig{
+		Schedule: payroll.MonthlySchedule{},
+		Schedules: payroll.PaySchedules{
+			Named: map[string]payroll.PaySchedule{
+				"hourly":      payroll.BiweeklySchedule{Anchor: day(6)},
+				"supervisors": payroll.SemiMonthlySchedule{},
+			},
+			JobGroups: map[payroll.JobGroup]string{"A": "hourly"},
+		},
+		// employee 1 is promoted to supervisor on the 13th, in the middle of a biweekly period
+		Assignments: payroll.NewScheduleAssignments([]payroll.ScheduleAssignment{
+			{EmployeeId: 1, Schedule: "supervisors", EffectiveFrom: day(13)},
+		}),
+	}
+	worklogs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(8), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(14), HoursLogged: dec("4"), JobGroup: "A"},
+		{EmployeeId: 1, Date: day(17), HoursLogged: dec("2"), JobGroup: "A"},
+		{EmployeeId: 2, Date: day(14), HoursLogged: dec("5"), JobGroup: "A"},
+		{EmployeeId: 2, Date: day(14), HoursLogged: dec("1"), JobGroup: "B"},
+	}
+
+	report := payroll.GenerateReport(cfg, rates, worklogs)
+
+	type key struct {
+		employeeId int
+		period     payroll.PayPeriod
+	}
+	amounts := make(map[key]string)
+	for _, r := range report.EmployeeReports {
+		amounts[key{r.EmployeeId, r.PayPeriod}] = r.AmountPaid.String()
+	}
+	assert.Equal(t, map[key]string{
+		{1, period(day(6), day(12))}:  "80",
+		{1, period(day(13), day(15))}: "40",
+		{1, period(day(16), day(30))}: "20",
+		{2, period(day(6), day(19))}:  "50",
+		{2, period(day(1), day(30))}:  "20",
+	}, amounts)
+}
+
+func TestCheckPeriodOverlaps(t *testing.T) {
+	cfg := payroll.ReportConfig{
+		Schedule: payroll.MonthlySchedule{},
+		Schedules: payroll.PaySchedules{
+			Named:     map[string]payroll.PaySchedule{"hourly": payroll.BiweeklySchedule{Anchor: day(6)}},
+			JobGroups: map[payroll.JobGroup]string{"A": "hourly"},
+		},
+	}
+	// employee 1 switches from job group A to B on the 13th, in the middle of a biweekly period, and B is paid
+	// monthly, so the month's period overlaps the biweekly one
+	existing := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(8), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 2, Date: day(8), HoursLogged: dec("8"), JobGroup: "A"},
+	}
+	logs := []payroll.WorkLog{
+		{EmployeeId: 1, Date: day(14), HoursLogged: dec("4"), JobGroup: "B", Line: 2},
+		{EmployeeId: 2, Date: day(14), HoursLogged: dec("4"), JobGroup: "A", Line: 3},
+	}
+
+	assert.Equal(t, []payroll.RowError{
+		{Line: 2, Value: "B", Reason: "pay period 2023-11-01 to 2023-11-30 of the job group overlaps the employee's pay period " +
+			"2023-11-06 to 2023-11-19, assign the employee a pay schedule"},
+	}, payroll.CheckPeriodOverlaps(cfg, existing, logs))
+
+	// periods are cut at the employee's assignment to the monthly schedule on the switch date
+	cfg.Schedules.Named["monthly"] = payroll.MonthlySchedule{}
+	cfg.Assignments = payroll.NewScheduleAssignments([]payroll.ScheduleAssignment{
+		{EmployeeId: 1, Schedule: "monthly", EffectiveFrom: day(13)},
+	})
+
+	assert.Empty(t, payroll.CheckPeriodOverlaps(cfg, existing, logs))
+}
+
+func TestScheduleAssignments_Lookup(t *testing.T) {
+	assignments := payroll.NewScheduleAssignments([]payroll.ScheduleAssignment{
+		{EmployeeId: 1, Schedule: "supervisors", EffectiveFrom: day(20)},
+		{EmployeeId: 1, Schedule: "hourly", EffectiveFrom: day(6)},
+	})
+
+	name, from, to := assignments.Lookup(1, day(1))
+	assert.Equal(t, "", name)
+	assert.Nil(t, from)
+	assert.Equal(t, day(5), *to)
+
+	name, from, to = assignments.Lookup(1, day(19))
+	assert.Equal(t, "hourly", name)
+	assert.Equal(t, day(6), *from)
+	assert.Equal(t, day(19), *to)
+
+	name, from, to = assignments.Lookup(1, day(20))
+	assert.Equal(t, "supervisors", name)
+	assert.Equal(t, day(20), *from)
+	assert.Nil(t, to)
+}
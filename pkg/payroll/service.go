@@ -1,92 +1,1047 @@
 package payroll
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
 type payrollService struct {
 	payrollRepo *payrollRepository
+	cfg         Config
 }
 
-func NewPayrollService(dbW *db.DbWrapper) payrollService {
+// Config holds the rules applied to the work logs being inserted, and to the reports generated from them
+type Config struct {
+	Duplicates DuplicateRules
+	// Employees decides what happens to work logs of unknown, not yet hired or terminated employees
+	Employees EmployeeCheck
+	Report    ReportConfig
+}
+
+func NewPayrollService(dbW *db.DbWrapper, cfg Config) payrollService {
 	return payrollService{
 		payrollRepo: NewPayrollRepository(dbW),
+		cfg:         cfg,
 	}
 }
 
+// GetReport func returns a page of the employee reports of every active work log, sorted by employee id and pay
+// period. The report is built from all the logs before it's paged, as overtime weeks, daily caps and adjustments
+// span logs far apart
 func (s payrollService) GetReport(limit, offset uint64) (PayrollReport, error) {
-	groupRates, err := s.payrollRepo.GetJobGroupRates()
+	rates, err := s.getRates(s.payrollRepo)
 	if err != nil {
 		return PayrollReport{}, ErrReportGenerate
 	}
 
-	worklogs, err := s.payrollRepo.Get(limit, offset)
+	worklogs, err := s.payrollRepo.GetActive()
 	if err != nil {
 		return PayrollReport{}, ErrReportGenerate
 	}
 
-	return GenerateReport(groupRates, worklogs), nil
+	reportCfg, err := s.reportConfig(s.payrollRepo)
+	if err != nil {
+		return PayrollReport{}, ErrReportGenerate
+	}
+
+	report := GenerateReport(reportCfg, rates, worklogs)
+	sortEmployeeReports(report.EmployeeReports)
+	report.EmployeeReports = pageReports(report.EmployeeReports, limit, offset)
+	return report, nil
+}
+
+// pageReports func returns the limit report lines from the offset, none past the end
+func pageReports(reports []EmployeeReport, limit, offset uint64) []EmployeeReport {
+	if offset >= uint64(len(reports)) {
+		return make([]EmployeeReport, 0)
+	}
+	end := offset + limit
+	if end > uint64(len(reports)) {
+		end = uint64(len(reports))
+	}
+	return reports[offset:end]
+}
+
+// GetCostReport func returns the labor cost of the work logs by cost center, department, job group and pay period,
+// the lines of the pay periods overlapping the dates. Nil dates are unbounded. The report is built from every active
+// work log before its lines are picked, as overtime weeks, daily caps and adjustments span logs far apart
+func (s payrollService) GetCostReport(from, to *time.Time) (CostReport, error) {
+	rates, err := s.getRates(s.payrollRepo)
+	if err != nil {
+		return CostReport{}, ErrReportGenerate
+	}
+
+	worklogs, err := s.payrollRepo.GetActive()
+	if err != nil {
+		return CostReport{}, ErrReportGenerate
+	}
+
+	reportCfg, err := s.reportConfig(s.payrollRepo)
+	if err != nil {
+		return CostReport{}, ErrReportGenerate
+	}
+
+	report := GenerateCostReport(reportCfg, rates, worklogs)
+	lines := make([]CostLine, 0, len(report.CostLines))
+	for _, l := range report.CostLines {
+		if (from == nil || daysBetween(*from, l.PayPeriod.EndDate) >= 0) && (to == nil || daysBetween(l.PayPeriod.StartDate, *to) >= 0) {
+			lines = append(lines, l)
+		}
+	}
+	report.CostLines = lines
+	return report, nil
+}
+
+// reportConfig func returns the configured report rules with the holidays of the premium calendar, and the
+// employees' pay schedule assignments when named schedules are configured, read through the repository
+func (s payrollService) reportConfig(repo *payrollRepository) (ReportConfig, error) {
+	cfg := s.cfg.Report
+	if len(cfg.Schedules.Named) > 0 {
+		assignments, err := repo.GetScheduleAssignments(nil)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Assignments = NewScheduleAssignments(assignments)
+	}
+
+	if cfg.Premiums.Calendar == "" {
+		return cfg, nil
+	}
+
+	holidays, err := repo.GetHolidays(cfg.Premiums.Calendar)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Holidays = NewHolidays(holidays)
+	return cfg, nil
+}
+
+// getRates func loads every job group rate version and employee rate override through the repository
+func (s payrollService) getRates(repo *payrollRepository) (Rates, error) {
+	groupRates, err := repo.GetJobGroupRates()
+	if err != nil {
+		return Rates{}, err
+	}
+
+	overrides, err := repo.GetRateOverrides(nil)
+	if err != nil {
+		return Rates{}, err
+	}
+
+	return NewRates(groupRates, overrides), nil
+}
+
+// GetJobGroups func returns the job groups work logs can be uploaded for
+func (s payrollService) GetJobGroups() ([]JobGroup, error) {
+	return s.payrollRepo.GetJobGroups()
+}
+
+// GetJobGroupRates func returns the rate of every job group in force today
+func (s payrollService) GetJobGroupRates() ([]JobGroupRate, error) {
+	rates, err := s.payrollRepo.GetJobGroupRates()
+	if err != nil {
+		return nil, err
+	}
+	return NewRateTable(rates).Current(time.Now()), nil
+}
+
+// GetJobGroupRate func returns the rate of the job group in force today
+func (s payrollService) GetJobGroupRate(group JobGroup) (JobGroupRate, error) {
+	rates, err := s.payrollRepo.GetJobGroupRateHistory(group)
+	if err != nil {
+		return JobGroupRate{}, err
+	}
+	return NewRateTable(rates).Current(time.Now())[0], nil
+}
+
+func (s payrollService) GetJobGroupRateHistory(group JobGroup) ([]JobGroupRate, error) {
+	return s.payrollRepo.GetJobGroupRateHistory(group)
+}
+
+// CreateJobGroup func adds a job group, uploads and reports pick it up from the next request
+func (s payrollService) CreateJobGroup(j JobGroupRate) (JobGroupRate, error) {
+	j.JobGroup = JobGroup(strings.TrimSpace(string(j.JobGroup)))
+	j.Version = 1
+	j.EffectiveTo = nil
+	if err := validateJobGroupRate(j); err != nil {
+		return JobGroupRate{}, err
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return JobGroupRate{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+
+	if err := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx)).InsertJobGroup(j); err != nil {
+		return JobGroupRate{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return JobGroupRate{}, fmt.Errorf("error while committing job group: %v", err)
+	}
+	return j, nil
+}
+
+// ChangeJobGroupRate func adds a rate version in force from its effective date, work logs before
+// the date keep being paid at the previous rate. Only the date part of the effective date is kept
+func (s payrollService) ChangeJobGroupRate(j JobGroupRate) (JobGroupRate, error) {
+	if err := validateJobGroupRate(j); err != nil {
+		return JobGroupRate{}, err
+	}
+	if j.EffectiveFrom != nil {
+		from := dateOf(*j.EffectiveFrom)
+		j.EffectiveFrom = &from
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return JobGroupRate{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+
+	j, err = NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx)).AddJobGroupRate(j)
+	if err != nil {
+		return JobGroupRate{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return JobGroupRate{}, fmt.Errorf("error while committing job group rate: %v", err)
+	}
+	return j, nil
+}
+
+// GetRateOverrides func returns the rate overrides of the employee, or of every employee if not set
+func (s payrollService) GetRateOverrides(employeeId *int) ([]RateOverride, error) {
+	return s.payrollRepo.GetRateOverrides(employeeId)
+}
+
+// CreateRateOverride func adds an employee's rate override, overrides of the same employee and job group
+// can't be in force on the same day
+func (s payrollService) CreateRateOverride(o RateOverride) (RateOverride, error) {
+	if o.EmployeeId <= 0 {
+		return RateOverride{}, ErrInvalidRateOverride
+	}
+	if o.Rate.IsNegative() {
+		return RateOverride{}, ErrInvalidRate
+	}
+	if o.EffectiveFrom != nil && o.EffectiveTo != nil && o.EffectiveTo.Before(*o.EffectiveFrom) {
+		return RateOverride{}, ErrInvalidRateOverride
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return RateOverride{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+
+	o, err = NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx)).InsertRateOverride(o)
+	if err != nil {
+		return RateOverride{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return RateOverride{}, fmt.Errorf("error while committing rate override: %v", err)
+	}
+	return o, nil
+}
+
+func (s payrollService) DeleteRateOverride(id int) error {
+	return s.payrollRepo.DeleteRateOverride(id)
+}
+
+// GetScheduleAssignments func returns the pay schedule assignments of the employee, or of every employee if not set
+func (s payrollService) GetScheduleAssignments(employeeId *int) ([]ScheduleAssignment, error) {
+	return s.payrollRepo.GetScheduleAssignments(employeeId)
+}
+
+// CreateScheduleAssignment func switches a registered employee to a named pay schedule of the config from the
+// effective date, an employee switches schedule at most once a day
+func (s payrollService) CreateScheduleAssignment(a ScheduleAssignment) (ScheduleAssignment, error) {
+	if a.EmployeeId <= 0 || a.EffectiveFrom.IsZero() {
+		return ScheduleAssignment{}, ErrInvalidScheduleAssignment
+	}
+	if _, ok := s.cfg.Report.Schedules.Named[a.Schedule]; !ok {
+		return ScheduleAssignment{}, ErrPayScheduleNotFound
+	}
+
+	a.EffectiveFrom = dateOf(a.EffectiveFrom)
+	return s.payrollRepo.InsertScheduleAssignment(a)
+}
+
+func (s payrollService) DeleteScheduleAssignment(id int) error {
+	return s.payrollRepo.DeleteScheduleAssignment(id)
+}
+
+func (s payrollService) GetPeriods() ([]PayrollPeriod, error) {
+	return s.payrollRepo.GetPeriods()
+}
+
+func (s payrollService) GetPeriod(id int) (PayrollPeriod, error) {
+	return s.payrollRepo.GetPeriod(id)
+}
+
+// CreatePeriod func adds an open pay period, pay periods can't overlap
+func (s payrollService) CreatePeriod(p PayrollPeriod) (PayrollPeriod, error) {
+	if p.StartDate.IsZero() || p.EndDate.IsZero() || p.EndDate.Before(p.StartDate) {
+		return PayrollPeriod{}, ErrInvalidPeriod
+	}
+
+	p.StartDate, p.EndDate = dateOf(p.StartDate), dateOf(p.EndDate)
+	return s.payrollRepo.InsertPeriod(p)
+}
+
+// SetPeriodState func moves the pay period to the state. Closing a period snapshots the report of the pay periods
+// within it, which is cleared when it's opened again, and paid periods are final. The snapshot's rates, rules and
+// logs are read in the transaction the period is closed in, after the period is locked. Uploads hold the periods
+// of their logs for share until they commit, so the lock waits for them, and as the transaction is read committed
+// the logs read after it include theirs
+func (s payrollService) SetPeriodState(id int, state PeriodState) (PayrollPeriod, error) {
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return PayrollPeriod{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	p, err := repo.LockPeriod(id)
+	if err != nil {
+		tx.Rollback()
+		return PayrollPeriod{}, err
+	}
+	if !p.CanMoveTo(state) {
+		tx.Rollback()
+		return PayrollPeriod{}, ErrInvalidPeriodTransition
+	}
+
+	now := time.Now()
+	switch state {
+	case PeriodClosed:
+		rates, err := s.getRates(repo)
+		if err != nil {
+			tx.Rollback()
+			return PayrollPeriod{}, ErrReportGenerate
+		}
+		reportCfg, err := s.reportConfig(repo)
+		if err != nil {
+			tx.Rollback()
+			return PayrollPeriod{}, ErrReportGenerate
+		}
+		logs, err := s.periodLogs(repo, p)
+		if err != nil {
+			tx.Rollback()
+			return PayrollPeriod{}, ErrReportGenerate
+		}
+		p.Snapshot = SnapshotReport(p, GenerateReport(reportCfg, rates, logs))
+		p.ClosedTs = &now
+	case PeriodPaid:
+		p.PaidTs = &now
+	case PeriodOpen:
+		p.Snapshot, p.ClosedTs = nil, nil
+	}
+	p.State = state
+	p.UpdatedTs = now
+
+	if err := repo.UpdatePeriod(p); err != nil {
+		tx.Rollback()
+		return PayrollPeriod{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return PayrollPeriod{}, fmt.Errorf("error while committing pay period: %v", err)
+	}
+
+	logrus.Infof("pay period %d from %s to %s is %s", p.Id, p.StartDate.Format(time.DateOnly), p.EndDate.Format(time.DateOnly), p.State)
+	return p, nil
 }
 
-func (s payrollService) InsertLogs(filenameId int, logs []WorkLog) error {
+// periodLogs func returns the work logs paid in the pay period, along with the logs their hours are counted with.
+// Overtime weeks can start before the period, and adjustments paid in it were worked in earlier periods, so the
+// days from the week before the earliest of them are read too
+func (s payrollService) periodLogs(repo *payrollRepository, p PayrollPeriod) ([]WorkLog, error) {
+	from := p.StartDate.AddDate(0, 0, -6)
+	logs, err := repo.GetPeriodLogs(from, p.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	earliest := from
+	for _, log := range logs {
+		if log.Date.Before(earliest) {
+			earliest = log.Date
+		}
+	}
+	if !earliest.Before(from) {
+		return logs, nil
+	}
+	return repo.GetPeriodLogs(dateOf(earliest).AddDate(0, 0, -6), p.EndDate)
+}
+
+// DeletePeriod func deletes an open pay period
+func (s payrollService) DeletePeriod(id int) error {
 	tx, err := s.payrollRepo.dbW.DB.Begin()
 	if err != nil {
 		return fmt.Errorf("error while starting tx: %v", err)
 	}
-	s.payrollRepo.dbW.Tx = tx
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	p, err := repo.LockPeriod(id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if p.Locked() {
+		tx.Rollback()
+		return ErrPeriodLocked
+	}
+
+	if err := repo.DeletePeriod(id); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	if err := s.payrollRepo.InsertFileId(filenameId); err != nil {
-		return ErrFileIdExists
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error while committing pay period deletion: %v", err)
 	}
+	return nil
+}
 
-	ids, err := s.payrollRepo.CreateN(logs)
+// GetEmployees func returns the registered employees, or only the ones of the status if set
+func (s payrollService) GetEmployees(status *EmployeeStatus) ([]Employee, error) {
+	employees, err := s.payrollRepo.GetEmployees()
+	if err != nil || status == nil {
+		return employees, err
+	}
+
+	filtered := make([]Employee, 0, len(employees))
+	for _, e := range employees {
+		if e.Status == *status {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+func (s payrollService) GetEmployee(id int) (Employee, error) {
+	return s.payrollRepo.GetEmployee(id)
+}
+
+// CreateEmployee func registers an employee, work logs are only paid to registered employees when the
+// employee check is on
+func (s payrollService) CreateEmployee(e Employee) (Employee, error) {
+	e, err := validateEmployee(e)
 	if err != nil {
-		logrus.Errorf("error while inserting logs: %v", err)
-		return ErrWorkLogCreate
+		return Employee{}, err
 	}
+	return s.payrollRepo.InsertEmployee(e)
+}
 
-	logrus.Infof(fmt.Sprintf("created log ids: %d", ids))
+// UpdateEmployee func replaces the employee's details, already uploaded work logs aren't checked again
+func (s payrollService) UpdateEmployee(e Employee) (Employee, error) {
+	e, err := validateEmployee(e)
+	if err != nil {
+		return Employee{}, err
+	}
+	return s.payrollRepo.UpdateEmployee(e)
+}
+
+// ImportEmployees func upserts the employees in a single tx, employees whose details are unchanged aren't written
+func (s payrollService) ImportEmployees(employees []Employee) (EmployeeImport, error) {
+	ids := make([]int, 0, len(employees))
+	for i := range employees {
+		e, err := validateEmployee(employees[i])
+		if err != nil {
+			return EmployeeImport{}, err
+		}
+		employees[i] = e
+		ids = append(ids, e.Id)
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return EmployeeImport{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	existing, err := repo.GetEmployeesById(ids)
+	if err != nil {
+		tx.Rollback()
+		return EmployeeImport{}, err
+	}
+	current := make(map[int]Employee, len(existing))
+	for _, e := range existing {
+		current[e.Id] = e
+	}
+
+	res := EmployeeImport{
+		Created:   make([]int, 0),
+		Updated:   make([]int, 0),
+		Unchanged: make([]int, 0),
+	}
+	for _, e := range employees {
+		c, ok := current[e.Id]
+		switch {
+		case !ok:
+			_, err = repo.InsertEmployee(e)
+			res.Created = append(res.Created, e.Id)
+		case c.sameDetails(e):
+			res.Unchanged = append(res.Unchanged, e.Id)
+		default:
+			_, err = repo.UpdateEmployee(e)
+			res.Updated = append(res.Updated, e.Id)
+		}
+		if err != nil {
+			tx.Rollback()
+			return EmployeeImport{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return EmployeeImport{}, fmt.Errorf("error while committing employees: %v", err)
+	}
+	return res, nil
+}
+
+func (s payrollService) DeleteEmployee(id int) error {
+	return s.payrollRepo.DeleteEmployee(id)
+}
+
+func validateEmployee(e Employee) (Employee, error) {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Department, e.CostCenter = trimOptional(e.Department), trimOptional(e.CostCenter)
+	if e.Status == "" {
+		e.Status = EmployeeActive
+	}
+	if e.Id <= 0 || e.Name == "" || (e.Status != EmployeeActive && e.Status != EmployeeTerminated) {
+		return Employee{}, ErrInvalidEmployee
+	}
+	if e.HireDate != nil && e.TerminationDate != nil && e.TerminationDate.Before(*e.HireDate) {
+		return Employee{}, ErrInvalidEmployee
+	}
+	return e, nil
+}
+
+// trimOptional func trims an optional detail, unsetting it when blank
+func trimOptional(s *string) *string {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	return &trimmed
+}
+
+func (s payrollService) GetHolidayCalendars() ([]HolidayCalendar, error) {
+	return s.payrollRepo.GetHolidayCalendars()
+}
+
+// GetHolidays func returns the holidays of the calendar by date
+func (s payrollService) GetHolidays(calendar string) ([]Holiday, error) {
+	holidays, err := s.payrollRepo.GetHolidays(calendar)
+	if err != nil {
+		return nil, err
+	}
+	if len(holidays) == 0 {
+		return nil, ErrCalendarNotFound
+	}
+	return holidays, nil
+}
+
+// SaveHoliday func adds a holiday to its calendar, creating the calendar if it has no holidays yet
+func (s payrollService) SaveHoliday(h Holiday) (Holiday, error) {
+	h.Calendar, h.Name = strings.TrimSpace(h.Calendar), strings.TrimSpace(h.Name)
+	if err := validateHoliday(h); err != nil {
+		return Holiday{}, err
+	}
+
+	return h, s.payrollRepo.SaveHoliday(h)
+}
+
+// ImportHolidays func replaces every holiday of the calendar with the imported ones
+func (s payrollService) ImportHolidays(calendar string, holidays []Holiday) ([]Holiday, error) {
+	calendar = strings.TrimSpace(calendar)
+	for i := range holidays {
+		holidays[i].Calendar, holidays[i].Name = calendar, strings.TrimSpace(holidays[i].Name)
+		if err := validateHoliday(holidays[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error while starting tx: %v", err)
+	}
+
+	if err := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx)).ReplaceHolidays(calendar, holidays); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error while committing holidays: %v", err)
+	}
+	return s.payrollRepo.GetHolidays(calendar)
+}
+
+func (s payrollService) DeleteHoliday(calendar string, date time.Time) error {
+	return s.payrollRepo.DeleteHoliday(calendar, date)
+}
+
+func (s payrollService) DeleteHolidayCalendar(calendar string) error {
+	return s.payrollRepo.DeleteHolidayCalendar(calendar)
+}
+
+func validateHoliday(h Holiday) error {
+	if h.Calendar == "" || len(h.Calendar) > 64 || h.Name == "" || h.Date.IsZero() {
+		return ErrInvalidHoliday
+	}
+	return nil
+}
+
+func (s payrollService) DeleteJobGroup(group JobGroup) error {
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("error while starting tx: %v", err)
+	}
+
+	if err := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx)).DeleteJobGroup(group); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error while committing job group deletion: %v", err)
+	}
+	return nil
+}
+
+func validateJobGroupRate(j JobGroupRate) error {
+	if j.JobGroup == "" || len(j.JobGroup) > 32 {
+		return ErrInvalidJobGroup
+	}
+	if j.Rate.IsNegative() {
+		return ErrInvalidRate
+	}
 	return nil
 }
 
-func GenerateReport(jobGroupRates []JobGroupRate, worklogs []WorkLog) PayrollReport {
-	empPerPeriodData := make(map[int]map[string][]WorkLog)
-	for _, worklog := range worklogs {
-		if _, ok := empPerPeriodData[worklog.EmployeeId]; !ok {
-			empPerPeriodData[worklog.EmployeeId] = make(map[string][]WorkLog)
+func (s payrollService) InsertLogs(reportId int, logs []WorkLog, opts InsertOptions) (InsertResult, error) {
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return InsertResult{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+	// upload workers insert concurrently, so every call runs on its own tx bound repository
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	// the job is touched in the insert tx, which keeps its row locked so it can't be claimed again until the logs
+	// are committed, and refuses the insert if it was claimed again already
+	if opts.Job != nil {
+		if err := repo.TouchUploadJob(*opts.Job); err != nil {
+			tx.Rollback()
+			return InsertResult{}, err
 		}
+	}
 
-		empPerPeriodData[worklog.EmployeeId][GetPayPeriodString(worklog.Date)] =
-			append(empPerPeriodData[worklog.EmployeeId][GetPayPeriodString(worklog.Date)], worklog)
+	res, err := s.insertLogs(repo, reportId, logs, opts)
+	if err != nil {
+		tx.Rollback()
+		return res, err
 	}
 
-	groupRatesMap := make(map[JobGroup]float64)
-	for _, jobGroupRate := range jobGroupRates {
-		groupRatesMap[jobGroupRate.JobGroup] = jobGroupRate.Rate
+	if err := tx.Commit(); err != nil {
+		logrus.Errorf("error while committing logs: %v", err)
+		tx.Rollback()
+		return InsertResult{}, ErrWorkLogCreate
 	}
 
-	empReports := make([]EmployeeReport, 0, len(worklogs))
-	for empId, payPeriodData := range empPerPeriodData {
-		for payPeriod, workLogs := range payPeriodData {
-			empReports = append(empReports, EmployeeReport{
-				EmployeeId: empId,
-				PayPeriod:  ParsePayPeriodString(payPeriod),
-				AmountPaid: CalcAmountPaid(groupRatesMap, workLogs),
-			})
+	return res, nil
+}
+
+// PreviewLogs func runs the same inserts as InsertLogs in a transaction that's rolled back, and returns
+// how the report of the employees in the file, or in the amended report version, would change
+func (s payrollService) PreviewLogs(reportId int, logs []WorkLog, opts InsertOptions) (ReportPreview, error) {
+	rates, err := s.getRates(s.payrollRepo)
+	if err != nil {
+		return ReportPreview{}, ErrReportGenerate
+	}
+	reportCfg, err := s.reportConfig(s.payrollRepo)
+	if err != nil {
+		return ReportPreview{}, ErrReportGenerate
+	}
+
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return ReportPreview{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+	defer tx.Rollback()
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	employeeIds, err := repo.GetReportEmployees(reportId)
+	if err != nil {
+		return ReportPreview{}, ErrReportGenerate
+	}
+	for _, log := range logs {
+		employeeIds = append(employeeIds, log.EmployeeId)
+	}
+
+	before, err := repo.GetByEmployees(employeeIds)
+	if err != nil {
+		return ReportPreview{}, ErrReportGenerate
+	}
+
+	res, err := s.insertLogs(repo, reportId, logs, opts)
+	if err != nil {
+		return ReportPreview{
+			Duplicates:            res.Duplicates,
+			EmployeeIssues:        res.EmployeeIssues,
+			ClosedPeriodLogs:      res.ClosedPeriodLogs,
+			OverlappingPeriodLogs: res.OverlappingPeriodLogs,
+		}, err
+	}
+
+	after, err := repo.GetByEmployees(employeeIds)
+	if err != nil {
+		return ReportPreview{}, ErrReportGenerate
+	}
+
+	return ReportPreview{
+		ReportVersion:         res.ReportVersion,
+		Changes:               DiffReports(GenerateReport(reportCfg, rates, before), GenerateReport(reportCfg, rates, after)),
+		Duplicates:            res.Duplicates,
+		EmployeeIssues:        res.EmployeeIssues,
+		ClosedPeriodLogs:      res.ClosedPeriodLogs,
+		OverlappingPeriodLogs: res.OverlappingPeriodLogs,
+	}, nil
+}
+
+// insertLogs func records the report version and inserts its work logs using the tx bound repository,
+// an amendment supersedes the report's latest version and retires its work logs first. Duplicates are
+// checked against the active work logs, so the superseded version of an amended report doesn't count
+func (s payrollService) insertLogs(repo *payrollRepository, reportId int, logs []WorkLog, opts InsertOptions) (InsertResult, error) {
+	version := 1
+	if opts.Amend {
+		// retiring the logs of the amended version would change the pay of closed periods
+		if closed, err := repo.CountClosedPeriodLogs(reportId); err != nil {
+			return InsertResult{}, ErrWorkLogCreate
+		} else if closed > 0 {
+			return InsertResult{}, ErrReportPeriodClosed
+		}
+
+		var err error
+		if version, err = repo.SupersedeFileId(reportId); errors.Is(err, ErrReportNotFound) {
+			return InsertResult{}, ErrReportNotFound
+		} else if err != nil {
+			logrus.Errorf("error while superseding report: %v", err)
+			return InsertResult{}, ErrWorkLogCreate
 		}
 	}
 
+	if err := repo.InsertFileId(reportId, version); err != nil {
+		return InsertResult{}, ErrFileIdExists
+	}
+
+	uploadedTs := opts.UploadedTs
+	if uploadedTs.IsZero() {
+		uploadedTs = time.Now()
+	}
+
+	versionLogs := make([]WorkLog, 0, len(logs))
+	employeeIds := make([]int, 0, len(logs))
+	for _, log := range logs {
+		log.ReportId = reportId
+		log.ReportVersion = version
+		log.UploadedTs = uploadedTs
+		versionLogs = append(versionLogs, log)
+		employeeIds = append(employeeIds, log.EmployeeId)
+	}
+
+	existing, err := repo.GetByEmployees(employeeIds)
+	if err != nil {
+		logrus.Errorf("error while fetching existing logs: %v", err)
+		return InsertResult{}, ErrWorkLogCreate
+	}
+
+	res := InsertResult{
+		ReportId:      reportId,
+		ReportVersion: version,
+	}
+	res.Duplicates, versionLogs = FindDuplicates(s.cfg.Duplicates, existing, versionLogs)
+	if rejected(res.Duplicates) {
+		return res, ErrDuplicateLogs
+	}
+
+	// employees are read regardless of the employee check, as logs are allocated to their cost centers
+	employees, err := repo.GetEmployeesById(employeeIds)
+	if err != nil {
+		logrus.Errorf("error while fetching employees: %v", err)
+		return InsertResult{}, ErrWorkLogCreate
+	}
+
+	res.EmployeeIssues = CheckEmployees(s.cfg.Employees, employees, versionLogs)
+	if s.cfg.Employees == EmployeeCheckReject && len(res.EmployeeIssues) > 0 {
+		return res, ErrEmployeeLogs
+	}
+	versionLogs = AllocateLogs(employees, versionLogs)
+
+	if len(versionLogs) > 0 {
+		// adjustments are paid on the upload date, whose period is checked as well
+		from, to := uploadedTs, uploadedTs
+		for _, log := range versionLogs {
+			if log.Date.Before(from) {
+				from = log.Date
+			}
+			if log.Date.After(to) {
+				to = log.Date
+			}
+		}
+
+		periods, err := repo.GetPeriodsBetween(dateOf(from), dateOf(to))
+		if err != nil {
+			logrus.Errorf("error while fetching pay periods: %v", err)
+			return InsertResult{}, ErrWorkLogCreate
+		}
+
+		versionLogs, res.ClosedPeriodLogs = CheckClosedPeriods(periods, versionLogs, opts.Adjustment)
+		if len(res.ClosedPeriodLogs) > 0 {
+			return res, ErrClosedPeriodLogs
+		}
+	}
+
+	// job groups can be paid on different schedules, an employee's periods must not overlap across them
+	if len(s.cfg.Report.Schedules.Named) > 0 && len(versionLogs) > 0 {
+		assignments, err := repo.GetScheduleAssignments(nil)
+		if err != nil {
+			logrus.Errorf("error while fetching pay schedule assignments: %v", err)
+			return InsertResult{}, ErrWorkLogCreate
+		}
+
+		reportCfg := s.cfg.Report
+		reportCfg.Assignments = NewScheduleAssignments(assignments)
+		res.OverlappingPeriodLogs = CheckPeriodOverlaps(reportCfg, existing, versionLogs)
+		if len(res.OverlappingPeriodLogs) > 0 {
+			return res, ErrOverlappingPeriodLogs
+		}
+	}
+
+	// the rounded hours are stored along with the raw ones for audits, the daily cap counts the active logs
+	// of the same day
+	rounded := s.cfg.Report.Hours.RoundHours(append(existing, versionLogs...))
+	versionLogs = rounded[len(existing):]
+
+	// every log of the file can be merged into existing ones
+	if len(versionLogs) == 0 {
+		return res, nil
+	}
+
+	ids, err := repo.CreateN(versionLogs)
+	if err != nil {
+		logrus.Errorf("error while inserting logs: %v", err)
+		return InsertResult{}, ErrWorkLogCreate
+	}
+
+	logrus.Infof(fmt.Sprintf("created log ids: %d", ids))
+	res.Inserted = len(ids)
+	return res, nil
+}
+
+// GetWorkLogs func returns the work logs matching the filter, so a paycheck can be traced back to its csv rows
+func (s payrollService) GetWorkLogs(filter WorkLogFilter) ([]WorkLog, error) {
+	return s.payrollRepo.GetWorkLogs(filter)
+}
+
+// GetReportVersions func returns every processed version of a report, oldest first
+func (s payrollService) GetReportVersions(reportId int) ([]ReportVersion, error) {
+	versions, err := s.payrollRepo.GetFileVersions(reportId)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrReportNotFound
+	}
+	return versions, nil
+}
+
+// DeleteReport func rolls back an upload, deleting the report with all its versions and work logs in a single tx.
+// Reports with work logs in closed pay periods can't be deleted
+func (s payrollService) DeleteReport(reportId int, deletedBy string) (ReportDeletion, error) {
+	tx, err := s.payrollRepo.dbW.DB.Begin()
+	if err != nil {
+		return ReportDeletion{}, fmt.Errorf("error while starting tx: %v", err)
+	}
+	repo := NewPayrollRepository(s.payrollRepo.dbW.WithTx(tx))
+
+	// the pay of closed periods can't change
+	if closed, err := repo.CountClosedPeriodLogs(reportId); err != nil {
+		tx.Rollback()
+		return ReportDeletion{}, ErrReportDelete
+	} else if closed > 0 {
+		tx.Rollback()
+		return ReportDeletion{}, ErrReportPeriodClosed
+	}
+
+	d, err := repo.DeleteReport(reportId, deletedBy)
+	if errors.Is(err, ErrReportNotFound) {
+		return ReportDeletion{}, ErrReportNotFound
+	} else if err != nil {
+		return ReportDeletion{}, ErrReportDelete
+	}
+
+	if err := tx.Commit(); err != nil {
+		logrus.Errorf("error while committing report deletion: %v", err)
+		tx.Rollback()
+		return ReportDeletion{}, ErrReportDelete
+	}
+
+	logrus.Infof("report %d deleted by %s, removed %d versions and %d work logs", reportId, deletedBy, d.Versions, d.WorkLogs)
+	return d, nil
+}
+
+// EnqueueUpload func stores the uploaded file as a queued job for the upload workers
+func (s payrollService) EnqueueUpload(job UploadJob) (UploadJob, error) {
+	job.Id = uuid.New().String()
+	job.Status = UploadQueued
+	job.CreatedTs = time.Now()
+	job.UpdatedTs = job.CreatedTs
+
+	if err := s.payrollRepo.InsertUploadJob(job); err != nil {
+		return UploadJob{}, ErrUploadEnqueue
+	}
+
+	return job, nil
+}
+
+func (s payrollService) GetUploadJob(id string) (UploadJob, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return UploadJob{}, ErrUploadNotFound
+	}
+
+	return s.payrollRepo.GetUploadJob(id)
+}
+
+// ClaimUploadJob func returns the next job to process, jobs left in processing longer than timeout
+// are picked up again as their worker is assumed to be gone
+func (s payrollService) ClaimUploadJob(timeout time.Duration) (*UploadJob, error) {
+	return s.payrollRepo.ClaimUploadJob(time.Now().Add(-timeout))
+}
+
+// TouchUploadJob func keeps a job claimed by its worker while it's still processing
+func (s payrollService) TouchUploadJob(job UploadJob) error {
+	return s.payrollRepo.TouchUploadJob(job)
+}
+
+func (s payrollService) FinishUploadJob(job UploadJob) error {
+	return s.payrollRepo.FinishUploadJob(job)
+}
+
+// GenerateReport func groups the work logs by employee and pay period of the pay schedule, pricing each log at the
+// employee's override or the rate of its job group in force on the log's date. Hours are split into overtime buckets
+// across all of an employee's logs first, as a week can span two pay periods. Hours on holidays and weekends
+// are paid a premium on top. Hours are rounded by the hours policy before they're priced, and amounts are
+// rounded to cents as configured. Adjustments, work logs of closed pay periods posted late, are paid in the pay
+// period of their upload date as separate lines referencing the pay period they were worked in
+func GenerateReport(cfg ReportConfig, rates Rates, worklogs []WorkLog) PayrollReport {
+	type key struct {
+		employeeId int
+		payPeriod  PayPeriod
+	}
+
+	type periodHours struct {
+		hours       []BucketHours
+		adjustments []BucketHours
+	}
+
+	periods := make(map[key]*periodHours)
+	for _, h := range cfg.classifyHours(worklogs) {
+		k := key{h.WorkLog.EmployeeId, cfg.paidPeriod(h.WorkLog)}
+		if _, ok := periods[k]; !ok {
+			periods[k] = &periodHours{}
+		}
+		if h.WorkLog.Adjustment {
+			periods[k].adjustments = append(periods[k].adjustments, h)
+		} else {
+			periods[k].hours = append(periods[k].hours, h)
+		}
+	}
+
+	empReports := make([]EmployeeReport, 0, len(periods))
+	for k, p := range periods {
+		lineItems := priceHours(cfg, rates, p.hours)
+		premiums := pricePremiums(cfg, rates, p.hours)
+		adjustments := priceAdjustments(cfg, rates, p.adjustments)
+		empReports = append(empReports, EmployeeReport{
+			EmployeeId:  k.employeeId,
+			PayPeriod:   k.payPeriod,
+			AmountPaid:  cfg.Rounding.Round(sumLineItems(lineItems).Add(sumPremiums(premiums)).Add(sumAdjustments(adjustments))),
+			LineItems:   lineItems,
+			Buckets:     sumBuckets(lineItems),
+			Premiums:    premiums,
+			Adjustments: adjustments,
+		})
+	}
+
 	return PayrollReport{
 		EmployeeReports: empReports,
 	}
 }
 
+// DiffReports func returns the employee pay periods whose amount differs between the two reports,
+// sorted by employee id and pay period
+func DiffReports(before, after PayrollReport) []ReportChange {
+	type key struct {
+		employeeId int
+		startDate  string
+	}
+
+	changes := make(map[key]*ReportChange)
+	for _, r := range before.EmployeeReports {
+		changes[key{r.EmployeeId, r.PayPeriod.StartDate.Format(time.DateOnly)}] = &ReportChange{
+			EmployeeId:   r.EmployeeId,
+			PayPeriod:    r.PayPeriod,
+			AmountBefore: r.AmountPaid,
+		}
+	}
+	for _, r := range after.EmployeeReports {
+		k := key{r.EmployeeId, r.PayPeriod.StartDate.Format(time.DateOnly)}
+		if _, ok := changes[k]; !ok {
+			changes[k] = &ReportChange{
+				EmployeeId: r.EmployeeId,
+				PayPeriod:  r.PayPeriod,
+			}
+		}
+		changes[k].AmountAfter = r.AmountPaid
+	}
+
+	res := make([]ReportChange, 0)
+	for _, c := range changes {
+		c.Difference = c.AmountAfter.Sub(c.AmountBefore)
+		if !c.Difference.IsZero() {
+			res = append(res, *c)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].EmployeeId != res[j].EmployeeId {
+			return res[i].EmployeeId < res[j].EmployeeId
+		}
+		return res[i].PayPeriod.StartDate.Before(res[j].PayPeriod.StartDate)
+	})
+
+	return res
+}
+
+// ParsePayPeriodString func returns the semi monthly pay period of a startdate-month-year string.
+// Deprecated: reports use the pay periods of the configured PaySchedule
 func ParsePayPeriodString(logs string) PayPeriod {
 	parts := strings.Split(logs, "-")
 	dayStart, _ := strconv.Atoi(parts[0])
@@ -112,17 +1067,110 @@ func DaysInMonth(year int, month time.Month) int {
 	return lastDay.Day()
 }
 
-func CalcAmountPaid(groupRatesMap map[JobGroup]float64, logs []WorkLog) float64 {
-	var totalAmountPaid float64
+// CalcAmountPaid func returns the pay of the logs, including overtime and premiums
+func CalcAmountPaid(cfg ReportConfig, rates Rates, logs []WorkLog) decimal.Decimal {
+	hours := cfg.classifyHours(logs)
+	return cfg.Rounding.Round(sumLineItems(priceHours(cfg, rates, hours)).Add(sumPremiums(pricePremiums(cfg, rates, hours))))
+}
 
-	for _, log := range logs {
-		totalAmountPaid += float64(log.HoursLogged) * groupRatesMap[log.JobGroup]
+// CalcLineItems func splits the logs into overtime buckets, and sums the hours priced at the same rate
+// and multiplier
+func CalcLineItems(cfg ReportConfig, rates Rates, logs []WorkLog) []LineItem {
+	return priceHours(cfg, rates, cfg.classifyHours(logs))
+}
+
+// classifyHours func rounds the hours of the logs, and splits them into overtime buckets
+func (cfg ReportConfig) classifyHours(logs []WorkLog) []BucketHours {
+	return cfg.Overtime.ClassifyHours(cfg.Hours.RoundHours(logs))
+}
+
+// priceHours func sums the hours priced at the same rate and bucket, sorted by job group, group rate version,
+// override and bucket. Hours without a rate in force on their date are reported with source none, and aren't paid.
+// Amounts are summed exactly, then rounded per line if configured
+func priceHours(cfg ReportConfig, rates Rates, hours []BucketHours) []LineItem {
+	type key struct {
+		jobGroup   JobGroup
+		source     RateSource
+		version    int
+		overrideId int
+		bucket     HoursBucket
+	}
+
+	items := make(map[key]*LineItem)
+	for _, h := range hours {
+		log := h.WorkLog
+		rate, _ := rates.Resolve(log.EmployeeId, log.JobGroup, log.Date)
+
+		k := key{log.JobGroup, rate.Source, rate.RateVersion, rate.OverrideId, h.Bucket}
+		if _, ok := items[k]; !ok {
+			items[k] = &LineItem{
+				JobGroup:    log.JobGroup,
+				Source:      rate.Source,
+				RateVersion: rate.RateVersion,
+				OverrideId:  rate.OverrideId,
+				Rate:        rate.Rate,
+				Bucket:      h.Bucket,
+				Multiplier:  cfg.Overtime.Multiplier(h.Bucket),
+			}
+		}
+		items[k].Hours = items[k].Hours.Add(h.Hours)
+		items[k].Amount = items[k].Amount.Add(h.Hours.Mul(rate.Rate).Mul(items[k].Multiplier))
+	}
+
+	res := make([]LineItem, 0, len(items))
+	for _, item := range items {
+		item.Amount = cfg.Rounding.line(item.Amount)
+		res = append(res, *item)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].JobGroup != res[j].JobGroup {
+			return res[i].JobGroup < res[j].JobGroup
+		}
+		if res[i].RateVersion != res[j].RateVersion {
+			return res[i].RateVersion < res[j].RateVersion
+		}
+		if res[i].OverrideId != res[j].OverrideId {
+			return res[i].OverrideId < res[j].OverrideId
+		}
+		return bucketOrder(res[i].Bucket) < bucketOrder(res[j].Bucket)
+	})
+
+	return res
+}
+
+// sumBuckets func sums the hours and pay of each bucket, in bucket order
+func sumBuckets(items []LineItem) []BucketTotal {
+	totals := make(map[HoursBucket]*BucketTotal)
+	for _, item := range items {
+		if _, ok := totals[item.Bucket]; !ok {
+			totals[item.Bucket] = &BucketTotal{Bucket: item.Bucket}
+		}
+		totals[item.Bucket].Hours = totals[item.Bucket].Hours.Add(item.Hours)
+		totals[item.Bucket].Amount = totals[item.Bucket].Amount.Add(item.Amount)
+	}
+
+	res := make([]BucketTotal, 0, len(totals))
+	for _, t := range totals {
+		res = append(res, *t)
 	}
 
-	return totalAmountPaid
+	sort.Slice(res, func(i, j int) bool {
+		return bucketOrder(res[i].Bucket) < bucketOrder(res[j].Bucket)
+	})
+	return res
+}
+
+func sumLineItems(items []LineItem) decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range items {
+		total = total.Add(item.Amount)
+	}
+	return total
 }
 
-// generates startdate-month-year string for a time object
+// generates startdate-month-year string for a time object.
+// Deprecated: reports use the pay periods of the configured PaySchedule
 func GetPayPeriodString(date time.Time) string {
 	if date.Day() >= 1 && date.Day() <= 15 {
 		return fmt.Sprintf("1-%v-%v", int(date.Month()), date.Year())
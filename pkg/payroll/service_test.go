@@ -1,29 +1,71 @@
 package payroll_test
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
 	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// assertEqualDecimals func compares values holding decimals by their printed form, as equal decimals
+// can differ in scale, eg. 8 and 8.00
+func assertEqualDecimals(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	assert.Equal(t, fmt.Sprintf("%+v", expected), fmt.Sprintf("%+v", actual))
+}
+
 func TestGenerateReport(t *testing.T) {
 	jobGroupRates := []payroll.JobGroupRate{
-		{JobGroup: "A", Rate: 15.0},
-		{JobGroup: "B", Rate: 20.0},
+		{JobGroup: "A", Rate: dec("15.0")},
+		{JobGroup: "B", Rate: dec("20.0")},
 	}
 	worklogs := []payroll.WorkLog{
-		{EmployeeId: 1, Date: time.Date(2023, 1, 2, 0, 0, 0, 0, time.Local), HoursLogged: 8, JobGroup: "A"},
-		{EmployeeId: 1, Date: time.Date(2023, 1, 18, 0, 0, 0, 0, time.Local), HoursLogged: 4, JobGroup: "B"},
+		{EmployeeId: 1, Date: time.Date(2023, 1, 2, 0, 0, 0, 0, time.Local), HoursLogged: dec("8"), JobGroup: "A"},
+		{EmployeeId: 1, Date: time.Date(2023, 1, 18, 0, 0, 0, 0, time.Local), HoursLogged: dec("4"), JobGroup: "B"},
 	}
 
-	report := payroll.GenerateReport(jobGroupRates, worklogs)
+	report := payroll.GenerateReport(payroll.ReportConfig{}, payroll.NewRates(jobGroupRates, nil), worklogs)
 
 	assert.NotNil(t, report)
 	assert.Equal(t, len(worklogs), len(report.EmployeeReports))
 }
 
+func TestDiffReports(t *testing.T) {
+	firstHalf := payroll.ParsePayPeriodString("1-1-2023")
+	secondHalf := payroll.ParsePayPeriodString("16-1-2023")
+
+	before := payroll.PayrollReport{
+		EmployeeReports: []payroll.EmployeeReport{
+			{EmployeeId: 1, PayPeriod: firstHalf, AmountPaid: dec("100")},
+			{EmployeeId: 2, PayPeriod: firstHalf, AmountPaid: dec("50")},
+		},
+	}
+	after := payroll.PayrollReport{
+		EmployeeReports: []payroll.EmployeeReport{
+			{EmployeeId: 1, PayPeriod: secondHalf, AmountPaid: dec("40")},
+			{EmployeeId: 1, PayPeriod: firstHalf, AmountPaid: dec("160")},
+			{EmployeeId: 2, PayPeriod: firstHalf, AmountPaid: dec("50")},
+		},
+	}
+
+	changes := payroll.DiffReports(before, after)
+
+	assertEqualDecimals(t, []payroll.ReportChange{
+		{EmployeeId: 1, PayPeriod: firstHalf, AmountBefore: dec("100"), AmountAfter: dec("160"), Difference: dec("60")},
+		{EmployeeId: 1, PayPeriod: secondHalf, AmountBefore: dec("0"), AmountAfter: dec("40"), Difference: dec("40")},
+	}, changes)
+}
+
 func TestParsePayPeriodString(t *testing.T) {
 	payPeriodString := "1-1-2023"
 	payPeriod := payroll.ParsePayPeriodString(payPeriodString)
@@ -34,19 +76,19 @@ func TestParsePayPeriodString(t *testing.T) {
 }
 
 func TestCalcAmountPaid(t *testing.T) {
-	groupRates := map[payroll.JobGroup]float64{
-		payroll.GroupA: 15.0,
-		payroll.GroupB: 20.0,
-	}
+	groupRates := payroll.NewRates([]payroll.JobGroupRate{
+		{JobGroup: "A", Version: 1, Rate: dec("15.0")},
+		{JobGroup: "B", Version: 1, Rate: dec("20.0")},
+	}, nil)
 
 	logs := []payroll.WorkLog{
-		{HoursLogged: 8, JobGroup: "A"},
-		{HoursLogged: 4, JobGroup: "B"},
+		{HoursLogged: dec("8"), JobGroup: "A"},
+		{HoursLogged: dec("4"), JobGroup: "B"},
 	}
 
-	amountPaid := payroll.CalcAmountPaid(groupRates, logs)
+	amountPaid := payroll.CalcAmountPaid(payroll.ReportConfig{}, groupRates, logs)
 
-	assert.Equal(t, 8*15.0+4*20.0, amountPaid)
+	assertEqualDecimals(t, dec("200"), amountPaid)
 }
 
 func TestGetPayPeriodString(t *testing.T) {
@@ -103,3 +145,137 @@ func TestDaysInMonth(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReport_PagesEmployeeReports(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	mock.ExpectQuery("select (.+) from jobgroup_rate (.+);").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).AddRow("A", 1, 20, nil, nil))
+	mock.ExpectQuery("select (.+) from rate_overrides (.+);").
+		WillReturnRows(sqlmock.NewRows(overrideRows))
+	// every active log is read, the report is paged afterwards
+	mock.ExpectQuery(regexp.QuoteMeta("select employee_id, log_date, log_hours, rounded_hours, job_group, cost_center, department, " +
+		"adjustment, coalesce(uploaded_ts, updated_ts) from worklog where retired_ts is null order by log_date;")).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "cost_center",
+			"department", "adjustment", "uploaded_ts"}).
+			AddRow(2, day(6), "2", nil, "A", nil, nil, false, timeVal).
+			AddRow(1, day(6), "8", nil, "A", nil, nil, false, timeVal).
+			AddRow(1, day(7), "4", nil, "A", nil, nil, false, timeVal).
+			AddRow(1, day(20), "1", nil, "A", nil, nil, false, timeVal))
+
+	report, err := service.GetReport(2, 1)
+
+	assert.NoError(t, err)
+	amounts := make([]string, 0, len(report.EmployeeReports))
+	for _, r := range report.EmployeeReports {
+		amounts = append(amounts, fmt.Sprintf("%d %s %s", r.EmployeeId, r.PayPeriod.StartDate.Format(time.DateOnly), r.AmountPaid))
+	}
+	assert.Equal(t, []string{"1 2023-11-16 20", "2 2023-11-01 40"}, amounts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCostReport_Dates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	mock.ExpectQuery("select (.+) from jobgroup_rate (.+);").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).AddRow("A", 1, 20, nil, nil))
+	mock.ExpectQuery("select (.+) from rate_overrides (.+);").
+		WillReturnRows(sqlmock.NewRows(overrideRows))
+	mock.ExpectQuery("select (.+) from worklog where retired_ts is null order by log_date;").
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "cost_center",
+			"department", "adjustment", "uploaded_ts"}).
+			AddRow(1, day(6), "8", nil, "A", nil, nil, false, timeVal).
+			AddRow(1, day(20), "1", nil, "A", nil, nil, false, timeVal))
+
+	// the first half of the month ends before the dates
+	from, to := day(16), day(20)
+	report, err := service.GetCostReport(&from, &to)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.CostLines, 1)
+	assert.Equal(t, period(day(16), day(30)), report.CostLines[0].PayPeriod)
+	assertEqualDecimals(t, dec("20"), report.CostLines[0].AmountPaid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPreviewLogs_OverlappingPeriods(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{Report: payroll.ReportConfig{
+		Schedule: payroll.MonthlySchedule{},
+		Schedules: payroll.PaySchedules{
+			Named:     map[string]payroll.PaySchedule{"hourly": payroll.BiweeklySchedule{Anchor: day(6)}},
+			JobGroups: map[payroll.JobGroup]string{"A": "hourly"},
+		},
+	}})
+
+	logRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "employee_id", "log_date", "log_hours", "rounded_hours", "job_group", "report_id",
+			"report_version", "line", "uploaded_ts", "retired_ts", "cost_center", "department", "adjustment"}).
+			AddRow(7, 1, day(8), "8", nil, "A", 41, 1, 2, timeVal, nil, nil, nil, false)
+	}
+
+	mock.ExpectQuery("select (.+) from jobgroup_rate (.+);").
+		WillReturnRows(sqlmock.NewRows([]string{"job_group", "version", "rate", "effective_from", "effective_to"}).AddRow("A", 1, 20, nil, nil))
+	mock.ExpectQuery("select (.+) from rate_overrides (.+);").
+		WillReturnRows(sqlmock.NewRows(overrideRows))
+	mock.ExpectQuery("select (.+) from pay_schedule_assignments (.+);").
+		WillReturnRows(sqlmock.NewRows(scheduleRows))
+	mock.ExpectBegin()
+	mock.ExpectQuery("select distinct employee_id from worklog (.+);").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"employee_id"}))
+	mock.ExpectQuery("select (.+) from worklog where retired_ts is null and employee_id = (.+);").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(logRows())
+	mock.ExpectQuery("insert into processed_files (.+);").
+		WithArgs(42, 1, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("select (.+) from worklog where retired_ts is null and employee_id = (.+);").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(logRows())
+	mock.ExpectQuery("select (.+) from employees (.+);").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(employeeRows))
+	mock.ExpectQuery("select (.+) from pay_periods (.+);").
+		WithArgs(day(14), day(14)).
+		WillReturnRows(sqlmock.NewRows(periodRows))
+	mock.ExpectQuery("select (.+) from pay_schedule_assignments (.+);").
+		WillReturnRows(sqlmock.NewRows(scheduleRows))
+	mock.ExpectRollback()
+
+	// job group B is paid monthly, its period overlaps the employee's biweekly one of job group A
+	preview, err := service.PreviewLogs(42, []payroll.WorkLog{{EmployeeId: 1, Date: day(14), HoursLogged: dec("4"), JobGroup: "B", Line: 2}},
+		payroll.InsertOptions{UploadedTs: day(14)})
+
+	assert.ErrorIs(t, err, payroll.ErrOverlappingPeriodLogs)
+	assert.Equal(t, []payroll.RowError{
+		{Line: 2, Value: "B", Reason: "pay period 2023-11-01 to 2023-11-30 of the job group overlaps the employee's pay period " +
+			"2023-11-06 to 2023-11-19, assign the employee a pay schedule"},
+	}, preview.OverlappingPeriodLogs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,234 @@
+package payroll
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// snapshotVersion is the version of the json shape pay period snapshots are stored in, it's bumped whenever the
+// shape changes so stored snapshots can still be read
+const snapshotVersion = 1
+
+// reportSnapshot is the stored shape of a pay period snapshot. It mirrors the report of the api, with dates as
+// yyyy-mm-dd and amounts, rates and hours as exact decimal strings, and is kept apart from the report types so
+// changing them doesn't change the snapshots already taken
+type reportSnapshot struct {
+	Version         int                      `json:"version"`
+	EmployeeReports []employeeReportSnapshot `json:"employee_reports"`
+}
+
+type payPeriodSnapshot struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type employeeReportSnapshot struct {
+	EmployeeId  int                  `json:"employee_id"`
+	PayPeriod   payPeriodSnapshot    `json:"pay_period"`
+	AmountPaid  string               `json:"amount_paid"`
+	LineItems   []lineItemSnapshot   `json:"line_items"`
+	Buckets     []bucketSnapshot     `json:"buckets"`
+	Premiums    []premiumSnapshot    `json:"premiums"`
+	Adjustments []adjustmentSnapshot `json:"adjustments"`
+}
+
+type lineItemSnapshot struct {
+	JobGroup    string `json:"job_group"`
+	RateSource  string `json:"rate_source"`
+	RateVersion int    `json:"rate_version"`
+	OverrideId  int    `json:"override_id,omitempty"`
+	Rate        string `json:"rate"`
+	Bucket      string `json:"bucket"`
+	Multiplier  string `json:"multiplier"`
+	Hours       string `json:"hours"`
+	Amount      string `json:"amount"`
+}
+
+type bucketSnapshot struct {
+	Bucket string `json:"bucket"`
+	Hours  string `json:"hours"`
+	Amount string `json:"amount"`
+}
+
+type premiumSnapshot struct {
+	Kind       string `json:"kind"`
+	JobGroup   string `json:"job_group"`
+	Rate       string `json:"rate"`
+	Multiplier string `json:"multiplier"`
+	Hours      string `json:"hours"`
+	Amount     string `json:"amount"`
+}
+
+type adjustmentSnapshot struct {
+	OriginalPayPeriod payPeriodSnapshot  `json:"original_pay_period"`
+	LineItems         []lineItemSnapshot `json:"line_items"`
+	Premiums          []premiumSnapshot  `json:"premiums"`
+	Amount            string             `json:"amount"`
+}
+
+// encodeSnapshot func converts the report lines of a pay period to the json of the current snapshot version
+func encodeSnapshot(reports []EmployeeReport) ([]byte, error) {
+	s := reportSnapshot{Version: snapshotVersion, EmployeeReports: make([]employeeReportSnapshot, 0, len(reports))}
+	for _, r := range reports {
+		er := employeeReportSnapshot{
+			EmployeeId:  r.EmployeeId,
+			PayPeriod:   snapshotPayPeriod(r.PayPeriod),
+			AmountPaid:  r.AmountPaid.String(),
+			LineItems:   snapshotLineItems(r.LineItems),
+			Buckets:     make([]bucketSnapshot, 0, len(r.Buckets)),
+			Premiums:    snapshotPremiums(r.Premiums),
+			Adjustments: make([]adjustmentSnapshot, 0, len(r.Adjustments)),
+		}
+		for _, b := range r.Buckets {
+			er.Buckets = append(er.Buckets, bucketSnapshot{Bucket: string(b.Bucket), Hours: b.Hours.String(), Amount: b.Amount.String()})
+		}
+		for _, a := range r.Adjustments {
+			er.Adjustments = append(er.Adjustments, adjustmentSnapshot{
+				OriginalPayPeriod: snapshotPayPeriod(a.PayPeriod),
+				LineItems:         snapshotLineItems(a.LineItems),
+				Premiums:          snapshotPremiums(a.Premiums),
+				Amount:            a.Amount.String(),
+			})
+		}
+		s.EmployeeReports = append(s.EmployeeReports, er)
+	}
+	return json.Marshal(s)
+}
+
+// decodeSnapshot func converts a stored snapshot back to the report lines of the pay period, it fails on
+// snapshots of a version it doesn't know
+func decodeSnapshot(data []byte) ([]EmployeeReport, error) {
+	var s reportSnapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+
+	d := snapshotDecoder{}
+	reports := make([]EmployeeReport, 0, len(s.EmployeeReports))
+	for _, er := range s.EmployeeReports {
+		r := EmployeeReport{
+			EmployeeId: er.EmployeeId,
+			PayPeriod:  d.payPeriod(er.PayPeriod),
+			AmountPaid: d.decimal(er.AmountPaid),
+			LineItems:  d.lineItems(er.LineItems),
+			Premiums:   d.premiums(er.Premiums),
+		}
+		for _, b := range er.Buckets {
+			r.Buckets = append(r.Buckets, BucketTotal{Bucket: HoursBucket(b.Bucket), Hours: d.decimal(b.Hours), Amount: d.decimal(b.Amount)})
+		}
+		for _, a := range er.Adjustments {
+			r.Adjustments = append(r.Adjustments, Adjustment{
+				PayPeriod: d.payPeriod(a.OriginalPayPeriod),
+				LineItems: d.lineItems(a.LineItems),
+				Premiums:  d.premiums(a.Premiums),
+				Amount:    d.decimal(a.Amount),
+			})
+		}
+		reports = append(reports, r)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return reports, nil
+}
+
+func snapshotPayPeriod(p PayPeriod) payPeriodSnapshot {
+	return payPeriodSnapshot{StartDate: p.StartDate.Format(time.DateOnly), EndDate: p.EndDate.Format(time.DateOnly)}
+}
+
+func snapshotLineItems(items []LineItem) []lineItemSnapshot {
+	res := make([]lineItemSnapshot, 0, len(items))
+	for _, li := range items {
+		res = append(res, lineItemSnapshot{
+			JobGroup:    string(li.JobGroup),
+			RateSource:  string(li.Source),
+			RateVersion: li.RateVersion,
+			OverrideId:  li.OverrideId,
+			Rate:        li.Rate.String(),
+			Bucket:      string(li.Bucket),
+			Multiplier:  li.Multiplier.String(),
+			Hours:       li.Hours.String(),
+			Amount:      li.Amount.String(),
+		})
+	}
+	return res
+}
+
+func snapshotPremiums(premiums []PremiumLine) []premiumSnapshot {
+	res := make([]premiumSnapshot, 0, len(premiums))
+	for _, p := range premiums {
+		res = append(res, premiumSnapshot{
+			Kind:       string(p.Kind),
+			JobGroup:   string(p.JobGroup),
+			Rate:       p.Rate.String(),
+			Multiplier: p.Multiplier.String(),
+			Hours:      p.Hours.String(),
+			Amount:     p.Amount.String(),
+		})
+	}
+	return res
+}
+
+// snapshotDecoder parses the values of a snapshot, keeping the first error so a snapshot is decoded in one pass
+type snapshotDecoder struct {
+	err error
+}
+
+func (d *snapshotDecoder) decimal(s string) decimal.Decimal {
+	v, err := decimal.NewFromString(s)
+	if err != nil && d.err == nil {
+		d.err = fmt.Errorf("invalid decimal %q: %v", s, err)
+	}
+	return v
+}
+
+func (d *snapshotDecoder) date(s string) time.Time {
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil && d.err == nil {
+		d.err = fmt.Errorf("invalid date %q: %v", s, err)
+	}
+	return t
+}
+
+func (d *snapshotDecoder) payPeriod(p payPeriodSnapshot) PayPeriod {
+	return PayPeriod{StartDate: d.date(p.StartDate), EndDate: d.date(p.EndDate)}
+}
+
+func (d *snapshotDecoder) lineItems(items []lineItemSnapshot) []LineItem {
+	var res []LineItem
+	for _, li := range items {
+		res = append(res, LineItem{
+			JobGroup:    JobGroup(li.JobGroup),
+			Source:      RateSource(li.RateSource),
+			RateVersion: li.RateVersion,
+			OverrideId:  li.OverrideId,
+			Rate:        d.decimal(li.Rate),
+			Bucket:      HoursBucket(li.Bucket),
+			Multiplier:  d.decimal(li.Multiplier),
+			Hours:       d.decimal(li.Hours),
+			Amount:      d.decimal(li.Amount),
+		})
+	}
+	return res
+}
+
+func (d *snapshotDecoder) premiums(premiums []premiumSnapshot) []PremiumLine {
+	var res []PremiumLine
+	for _, p := range premiums {
+		res = append(res, PremiumLine{
+			Kind:       PremiumKind(p.Kind),
+			JobGroup:   JobGroup(p.JobGroup),
+			Rate:       d.decimal(p.Rate),
+			Multiplier: d.decimal(p.Multiplier),
+			Hours:      d.decimal(p.Hours),
+			Amount:     d.decimal(p.Amount),
+		})
+	}
+	return res
+}
@@ -0,0 +1,135 @@
+package payroll
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	uploadTable = "upload_jobs"
+)
+
+var (
+	selectUploadCols  = "id, report_id, coalesce(report_version, 0), amend, adjustment, filename, profile, status, coalesce(message, ''), rows_total, rows_imported, rows_rejected, coalesce(errors, '[]'), coalesce(duplicates, '[]'), coalesce(employee_issues, '[]'), created_ts, updated_ts"
+	insertUploadQuery = "insert into " + uploadTable + " (id, report_id, amend, adjustment, filename, profile, payload, status, created_ts, updated_ts) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9);"
+	selectUploadQuery = "select " + selectUploadCols + " from " + uploadTable + " where id = $1;"
+	// claims the oldest queued job, or a job whose worker stopped updating it before stale time. Every claim bumps
+	// the attempt, so only the worker holding the latest claim can touch or finish the job
+	claimUploadQuery = "update " + uploadTable + " set status = $1, updated_ts = $2, attempt = attempt + 1 where id = (" +
+		"select id from " + uploadTable + " where status = $3 or (status = $1 and updated_ts < $4) " +
+		"order by created_ts limit 1 for update skip locked) returning id, report_id, amend, adjustment, filename, profile, payload, attempt, created_ts;"
+	touchUploadQuery  = "update " + uploadTable + " set updated_ts = $3 where id = $1 and attempt = $2 and status = $4;"
+	finishUploadQuery = "update " + uploadTable + " set status = $2, message = $3, rows_total = $4, rows_imported = $5, " +
+		"rows_rejected = $6, errors = $7, updated_ts = $8, report_version = $9, duplicates = $10, employee_issues = $11 " +
+		"where id = $1 and attempt = $12;"
+)
+
+func (r payrollRepository) InsertUploadJob(job UploadJob) error {
+	if _, err := r.dbW.DB.Exec(insertUploadQuery, job.Id, job.ReportId, job.Amend, job.Adjustment, job.Filename, job.Profile,
+		job.Payload, job.Status, job.CreatedTs); err != nil {
+		logrus.Errorf("error while inserting upload job: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r payrollRepository) GetUploadJob(id string) (UploadJob, error) {
+	var j UploadJob
+	var errs, duplicates, issues []byte
+
+	err := r.dbW.DB.QueryRow(selectUploadQuery, id).Scan(&j.Id, &j.ReportId, &j.ReportVersion, &j.Amend, &j.Adjustment, &j.Filename,
+		&j.Profile, &j.Status, &j.Message, &j.RowsTotal, &j.RowsImported, &j.RowsRejected, &errs, &duplicates, &issues, &j.CreatedTs, &j.UpdatedTs)
+	if err == sql.ErrNoRows {
+		return j, ErrUploadNotFound
+	} else if err != nil {
+		logrus.Errorf("unable to scan db rows: %v", err)
+		return j, err
+	}
+
+	if err := json.Unmarshal(errs, &j.Errors); err != nil {
+		logrus.Errorf("unable to decode upload errors: %v", err)
+		return j, err
+	}
+
+	if err := json.Unmarshal(duplicates, &j.Duplicates); err != nil {
+		logrus.Errorf("unable to decode upload duplicates: %v", err)
+		return j, err
+	}
+
+	if err := json.Unmarshal(issues, &j.EmployeeIssues); err != nil {
+		logrus.Errorf("unable to decode upload employee issues: %v", err)
+		return j, err
+	}
+
+	return j, nil
+}
+
+// ClaimUploadJob func marks the next job as processing and returns it, nil is returned if there are no jobs waiting
+func (r payrollRepository) ClaimUploadJob(staleBefore time.Time) (*UploadJob, error) {
+	j := UploadJob{
+		Status: UploadProcessing,
+	}
+
+	err := r.dbW.DB.QueryRow(claimUploadQuery, UploadProcessing, time.Now(), UploadQueued, staleBefore).
+		Scan(&j.Id, &j.ReportId, &j.Amend, &j.Adjustment, &j.Filename, &j.Profile, &j.Payload, &j.Attempt, &j.CreatedTs)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		logrus.Errorf("unable to claim upload job: %v", err)
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// TouchUploadJob func refreshes the updated time of a processing job so it isn't reclaimed as stale,
+// ErrUploadReclaimed is returned if another worker claimed it since
+func (r payrollRepository) TouchUploadJob(job UploadJob) error {
+	res, err := r.dbW.Querier().Exec(touchUploadQuery, job.Id, job.Attempt, time.Now(), UploadProcessing)
+	if err != nil {
+		logrus.Errorf("error while touching upload job: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUploadReclaimed
+	}
+
+	return nil
+}
+
+// FinishUploadJob func saves the result of the job, ErrUploadReclaimed is returned if another worker claimed it since
+func (r payrollRepository) FinishUploadJob(job UploadJob) error {
+	errs, err := json.Marshal(job.Errors)
+	if err != nil {
+		return fmt.Errorf("unable to encode upload errors: %v", err)
+	}
+
+	duplicates, err := json.Marshal(job.Duplicates)
+	if err != nil {
+		return fmt.Errorf("unable to encode upload duplicates: %v", err)
+	}
+
+	issues, err := json.Marshal(job.EmployeeIssues)
+	if err != nil {
+		return fmt.Errorf("unable to encode upload employee issues: %v", err)
+	}
+
+	res, err := r.dbW.DB.Exec(finishUploadQuery, job.Id, job.Status, job.Message, job.RowsTotal,
+		job.RowsImported, job.RowsRejected, errs, time.Now(), job.ReportVersion, duplicates, issues, job.Attempt)
+	if err != nil {
+		logrus.Errorf("error while updating upload job: %v", err)
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUploadReclaimed
+	}
+
+	return nil
+}
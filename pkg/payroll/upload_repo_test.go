@@ -0,0 +1,177 @@
+package payroll_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	internaldb "github.com/joshinjohnson/wave-exercise/pkg/db"
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUploadJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	rows := sqlmock.NewRows([]string{"id", "report_id", "report_version", "amend", "adjustment", "filename", "profile", "status", "message",
+		"rows_total", "rows_imported", "rows_rejected", "errors", "duplicates", "employee_issues", "created_ts", "updated_ts"}).
+		AddRow("job-1", 42, 0, false, false, "time-report-42.csv", "default", "failed", "invalid rows", 2, 0, 2,
+			[]byte(`[{"line":2,"column":"date","value":"x","reason":"invalid date specified"}]`), []byte(`[]`),
+			[]byte(`[{"reason":"terminated","action":"warn","line":3,"employee_id":7,"date":"2023-11-14T00:00:00Z"}]`), timeVal, timeVal)
+
+	mock.ExpectQuery(regexp.QuoteMeta("from upload_jobs where id = $1;")).WithArgs("job-1").WillReturnRows(rows)
+
+	job, err := repo.GetUploadJob("job-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.UploadFailed, job.Status)
+	assert.Equal(t, []payroll.RowError{{Line: 2, Column: "date", Value: "x", Reason: "invalid date specified"}}, job.Errors)
+	assert.Equal(t, []payroll.EmployeeIssue{{Reason: payroll.IssueTerminated, Action: payroll.EmployeeCheckWarn, Line: 3,
+		EmployeeId: 7, Date: time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)}}, job.EmployeeIssues)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUploadJob_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery(regexp.QuoteMeta("from upload_jobs where id = $1;")).WithArgs("job-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetUploadJob("job-1")
+
+	assert.ErrorIs(t, err, payroll.ErrUploadNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimUploadJob_EmptyQueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery("update upload_jobs set status = (.+) for update skip locked").
+		WithArgs(payroll.UploadProcessing, sqlmock.AnyArg(), payroll.UploadQueued, timeVal).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "amend", "adjustment", "filename", "profile", "payload", "attempt", "created_ts"}))
+
+	job, err := repo.ClaimUploadJob(timeVal)
+
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimUploadJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectQuery(regexp.QuoteMeta("set status = $1, updated_ts = $2, attempt = attempt + 1 where id = (")).
+		WithArgs(payroll.UploadProcessing, sqlmock.AnyArg(), payroll.UploadQueued, timeVal).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "amend", "adjustment", "filename", "profile", "payload", "attempt", "created_ts"}).
+			AddRow("job-1", 42, false, false, "time-report-42.csv", "default", []byte("date"), 2, timeVal))
+
+	job, err := repo.ClaimUploadJob(timeVal)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", job.Id)
+	assert.Equal(t, 2, job.Attempt)
+	assert.Equal(t, payroll.UploadProcessing, job.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTouchUploadJob_Reclaimed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	mock.ExpectExec(regexp.QuoteMeta("update upload_jobs set updated_ts = $3 where id = $1 and attempt = $2 and status = $4;")).
+		WithArgs("job-1", 1, sqlmock.AnyArg(), payroll.UploadProcessing).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.TouchUploadJob(payroll.UploadJob{Id: "job-1", Attempt: 1})
+
+	assert.ErrorIs(t, err, payroll.ErrUploadReclaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinishUploadJob_Reclaimed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := payroll.NewPayrollRepository(&internaldb.DbWrapper{
+		DB: db,
+	})
+
+	// the job was claimed again while the worker was processing it, its result is dropped
+	mock.ExpectExec(regexp.QuoteMeta("where id = $1 and attempt = $12;")).
+		WithArgs("job-1", payroll.UploadSucceeded, "", 2, 2, 0, sqlmock.AnyArg(), sqlmock.AnyArg(), 1, sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.FinishUploadJob(payroll.UploadJob{Id: "job-1", Status: payroll.UploadSucceeded, RowsTotal: 2, RowsImported: 2,
+		ReportVersion: 1, Attempt: 1})
+
+	assert.ErrorIs(t, err, payroll.ErrUploadReclaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertLogs_Reclaimed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	service := payroll.NewPayrollService(&internaldb.DbWrapper{
+		DB: db,
+	}, payroll.Config{})
+
+	// the job was claimed again under attempt 2, the stale worker's insert is rolled back before any log is written
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("update upload_jobs set updated_ts = $3 where id = $1 and attempt = $2 and status = $4;")).
+		WithArgs("job-1", 1, sqlmock.AnyArg(), payroll.UploadProcessing).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = service.InsertLogs(42, []payroll.WorkLog{{EmployeeId: 1, Date: day(6), HoursLogged: dec("8"), JobGroup: "A", Line: 2}},
+		payroll.InsertOptions{Job: &payroll.UploadJob{Id: "job-1", Attempt: 1}})
+
+	assert.ErrorIs(t, err, payroll.ErrUploadReclaimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
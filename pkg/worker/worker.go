@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/sirupsen/logrus"
+)
+
+// UploadQueue is the postgres backed queue the workers pull upload jobs from
+type UploadQueue interface {
+	ClaimUploadJob(timeout time.Duration) (*payroll.UploadJob, error)
+	// TouchUploadJob refreshes a claimed job, so it isn't reclaimed while it's being processed
+	TouchUploadJob(job payroll.UploadJob) error
+	FinishUploadJob(job payroll.UploadJob) error
+}
+
+// UploadProcessor processes a claimed job and returns it with its result set
+type UploadProcessor func(job payroll.UploadJob) payroll.UploadJob
+
+type Pool struct {
+	queue        UploadQueue
+	process      UploadProcessor
+	workers      int
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+}
+
+func NewPool(queue UploadQueue, process UploadProcessor, workers int, pollInterval, jobTimeout time.Duration) Pool {
+	return Pool{
+		queue:        queue,
+		process:      process,
+		workers:      workers,
+		pollInterval: pollInterval,
+		jobTimeout:   jobTimeout,
+	}
+}
+
+// Run func starts the workers and blocks until ctx is cancelled and all the workers have stopped
+func (p Pool) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	var workersWg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		workersWg.Add(1)
+		go p.work(ctx, &workersWg, i)
+	}
+
+	logrus.Infof("started %d upload workers", p.workers)
+	workersWg.Wait()
+	logrus.Info("stopped upload workers")
+}
+
+func (p Pool) work(ctx context.Context, wg *sync.WaitGroup, id int) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// drain the queue before waiting for the next tick
+		for p.processNext(id) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext func processes a single job, returning false if there was nothing to process
+func (p Pool) processNext(id int) bool {
+	job, err := p.queue.ClaimUploadJob(p.jobTimeout)
+	if err != nil {
+		logrus.Errorf("worker %d: error while claiming upload job: %v", id, err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	logrus.Infof("worker %d: processing upload job %s", id, job.Id)
+	done := make(chan struct{})
+	go p.heartbeat(id, *job, done)
+	res := p.process(*job)
+	close(done)
+
+	if err := p.queue.FinishUploadJob(res); errors.Is(err, payroll.ErrUploadReclaimed) {
+		logrus.Warnf("worker %d: upload job %s was claimed by another worker, dropping its result", id, job.Id)
+		return true
+	} else if err != nil {
+		logrus.Errorf("worker %d: error while saving upload job %s: %v", id, job.Id, err)
+		return true
+	}
+
+	logrus.Infof("worker %d: upload job %s %s", id, res.Id, res.Status)
+	return true
+}
+
+// heartbeat func touches the job a few times per job timeout until done is closed, so a slow import isn't taken
+// for a dead worker and run again
+func (p Pool) heartbeat(id int, job payroll.UploadJob, done <-chan struct{}) {
+	ticker := time.NewTicker(p.jobTimeout / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		if err := p.queue.TouchUploadJob(job); errors.Is(err, payroll.ErrUploadReclaimed) {
+			logrus.Warnf("worker %d: upload job %s was claimed by another worker", id, job.Id)
+			return
+		} else if err != nil {
+			logrus.Errorf("worker %d: error while touching upload job %s: %v", id, job.Id, err)
+		}
+	}
+}
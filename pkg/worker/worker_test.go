@@ -0,0 +1,162 @@
+package worker_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joshinjohnson/wave-exercise/pkg/payroll"
+	"github.com/joshinjohnson/wave-exercise/pkg/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockQueue claims jobs the way the upload_jobs table does, processing jobs not touched within the timeout
+// are claimed again under a new attempt
+type mockQueue struct {
+	mu       sync.Mutex
+	jobs     []*queuedJob
+	finished []payroll.UploadJob
+	rejected int
+	// skipTouch drops the touches, as if the worker lost its db connection
+	skipTouch bool
+}
+
+type queuedJob struct {
+	job       payroll.UploadJob
+	touchedTs time.Time
+}
+
+func newMockQueue(jobs ...payroll.UploadJob) *mockQueue {
+	q := &mockQueue{}
+	for _, job := range jobs {
+		job.Status = payroll.UploadQueued
+		q.jobs = append(q.jobs, &queuedJob{job: job})
+	}
+	return q
+}
+
+func (q *mockQueue) ClaimUploadJob(timeout time.Duration) (*payroll.UploadJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, j := range q.jobs {
+		stale := j.job.Status == payroll.UploadProcessing && time.Since(j.touchedTs) > timeout
+		if j.job.Status == payroll.UploadQueued || stale {
+			j.job.Status = payroll.UploadProcessing
+			j.job.Attempt++
+			j.touchedTs = time.Now()
+			job := j.job
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *mockQueue) TouchUploadJob(job payroll.UploadJob) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j := q.find(job.Id)
+	if j.job.Attempt != job.Attempt || j.job.Status != payroll.UploadProcessing {
+		return payroll.ErrUploadReclaimed
+	}
+	if !q.skipTouch {
+		j.touchedTs = time.Now()
+	}
+	return nil
+}
+
+func (q *mockQueue) FinishUploadJob(job payroll.UploadJob) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j := q.find(job.Id)
+	if j.job.Attempt != job.Attempt {
+		q.rejected++
+		return payroll.ErrUploadReclaimed
+	}
+	j.job = job
+	q.finished = append(q.finished, job)
+	return nil
+}
+
+func (q *mockQueue) find(id string) *queuedJob {
+	for _, j := range q.jobs {
+		if j.job.Id == id {
+			return j
+		}
+	}
+	return nil
+}
+
+func (q *mockQueue) counts() (int, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.finished), q.rejected
+}
+
+func runPool(t *testing.T, queue *mockQueue, process worker.UploadProcessor, jobTimeout time.Duration, done func(finished, rejected int) bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.NewPool(queue, process, 2, 5*time.Millisecond, jobTimeout).Run(ctx, &wg)
+
+	assert.Eventually(t, func() bool { return done(queue.counts()) }, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	wg.Wait()
+}
+
+func TestPool_Run(t *testing.T) {
+	queue := newMockQueue(payroll.UploadJob{Id: "1"}, payroll.UploadJob{Id: "2"}, payroll.UploadJob{Id: "3"})
+	process := func(job payroll.UploadJob) payroll.UploadJob {
+		job.Status = payroll.UploadSucceeded
+		return job
+	}
+
+	runPool(t, queue, process, time.Minute, func(finished, _ int) bool { return finished == 3 })
+
+	for _, job := range queue.finished {
+		assert.Equal(t, payroll.UploadSucceeded, job.Status)
+	}
+}
+
+func TestPool_SlowJobIsTouched(t *testing.T) {
+	queue := newMockQueue(payroll.UploadJob{Id: "1"})
+	var runs atomic.Int32
+	process := func(job payroll.UploadJob) payroll.UploadJob {
+		runs.Add(1)
+		time.Sleep(150 * time.Millisecond)
+		job.Status = payroll.UploadSucceeded
+		return job
+	}
+
+	runPool(t, queue, process, 30*time.Millisecond, func(finished, _ int) bool { return finished == 1 })
+
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, 0, queue.rejected)
+	assert.Equal(t, payroll.UploadSucceeded, queue.finished[0].Status)
+	assert.Equal(t, 1, queue.finished[0].Attempt)
+}
+
+func TestPool_ReclaimedJob(t *testing.T) {
+	queue := newMockQueue(payroll.UploadJob{Id: "1"})
+	queue.skipTouch = true
+	var runs atomic.Int32
+	process := func(job payroll.UploadJob) payroll.UploadJob {
+		// the first run stalls past the timeout, so the other worker claims the job again
+		if runs.Add(1) == 1 {
+			time.Sleep(150 * time.Millisecond)
+		}
+		job.Status = payroll.UploadSucceeded
+		return job
+	}
+
+	runPool(t, queue, process, 30*time.Millisecond, func(finished, rejected int) bool { return finished == 1 && rejected == 1 })
+
+	// the stale worker's result is dropped instead of overwriting the job of the latest claim
+	assert.Equal(t, int32(2), runs.Load())
+	assert.Equal(t, 2, queue.finished[0].Attempt)
+}
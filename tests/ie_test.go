@@ -3,12 +3,15 @@ package tests
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/joshinjohnson/wave-exercise/handler"
 	"github.com/joshinjohnson/wave-exercise/pkg/db"
@@ -31,12 +34,24 @@ func TestUploadCSV(t *testing.T) {
 	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
 	rr := httptest.NewRecorder()
 
-	payAPIhandler, dbW = setupHandler()
+	payAPIhandler, dbW = setupHandler(t)
 	defer dbW.DB.Close()
-	payAPIhandler.PostUpload(rr, req)
+	resp := payAPIhandler.PostUpload(rr, req, handler.PostUploadParams{})
 
-	if status := rr.Code; status != http.StatusOK {
-		t.Errorf("TestUploadCSV returned wrong status code: got %v want %v", status, http.StatusOK)
+	// the upload is queued for the workers, the response is the job to poll
+	if status := resp.Code; status != http.StatusAccepted {
+		t.Fatalf("TestUploadCSV returned wrong status code: got %v want %v", status, http.StatusAccepted)
+	}
+
+	var job handler.UploadJob
+	if err := decodeResponse(resp, &job); err != nil {
+		t.Fatal(err)
+	}
+	if job.ID == "" {
+		t.Errorf("TestUploadCSV returned no upload job id")
+	}
+	if job.Status != handler.UploadJobStatusQueued {
+		t.Errorf("TestUploadCSV returned wrong job status: got %v want %v", job.Status, handler.UploadJobStatusQueued)
 	}
 }
 
@@ -48,15 +63,77 @@ func TestGetPayrollReport(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	payAPIhandler, dbW = setupHandler()
+	payAPIhandler, dbW = setupHandler(t)
 	defer dbW.DB.Close()
-	payAPIhandler.GetReport(rr, req)
+	resp := payAPIhandler.GetReport(rr, req, handler.GetReportParams{})
 
-	if status := rr.Code; status != http.StatusOK {
+	if status := resp.Code; status != http.StatusOK {
 		t.Errorf("TestGetPayrollReport returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 }
 
+func TestClosePeriodWaitsForUpload(t *testing.T) {
+	_, dbW = setupHandler(t)
+	defer dbW.DB.Close()
+	service := payroll.NewPayrollService(dbW, payroll.Config{})
+
+	start := time.Date(2099, 1, 1, 0, 0, 0, 0, time.Local)
+	p, err := service.CreatePeriod(payroll.PayrollPeriod{StartDate: start, EndDate: start.AddDate(0, 0, 14)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		dbW.DB.Exec("delete from worklog where employee_id = $1 and log_date = $2;", 9999, start.AddDate(0, 0, 5))
+		dbW.DB.Exec("delete from pay_periods where id = $1;", p.Id)
+	}()
+
+	// an upload holds the period for share until it commits, the way insertLogs does
+	upload, err := dbW.DB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := upload.Exec("select id from pay_periods where id = $1 for share;", p.Id); err != nil {
+		upload.Rollback()
+		t.Fatal(err)
+	}
+	if _, err := upload.Exec("insert into worklog (employee_id, log_date, log_hours, job_group, updated_ts) values ($1, $2, $3, $4, $5);",
+		9999, start.AddDate(0, 0, 5), "8", "A", time.Now()); err != nil {
+		upload.Rollback()
+		t.Fatal(err)
+	}
+
+	type result struct {
+		p   payroll.PayrollPeriod
+		err error
+	}
+	closed := make(chan result, 1)
+	go func() {
+		p, err := service.SetPeriodState(p.Id, payroll.PeriodClosed)
+		closed <- result{p, err}
+	}()
+
+	select {
+	case <-closed:
+		upload.Rollback()
+		t.Fatal("TestClosePeriodWaitsForUpload closed the period while the upload held it")
+	case <-time.After(200 * time.Millisecond):
+	}
+	if err := upload.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	res := <-closed
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+	for _, r := range res.p.Snapshot {
+		if r.EmployeeId == 9999 {
+			return
+		}
+	}
+	t.Errorf("TestClosePeriodWaitsForUpload snapshot is missing the log the upload committed")
+}
+
 func createCSVRequest() (*bytes.Buffer, string) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -86,7 +163,17 @@ func createCSVRequest() (*bytes.Buffer, string) {
 	return body, boundary
 }
 
-func setupHandler() (handler.PayrollHandler, *db.DbWrapper) {
+// decodeResponse func reads the body of a handler response into v
+func decodeResponse(resp *handler.Response, v interface{}) error {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// setupHandler func connects to the local payroll db, the test is skipped if it isn't running
+func setupHandler(t *testing.T) (handler.PayrollHandler, *db.DbWrapper) {
 	dbConfig := map[string]string{
 		"user":     "user",
 		"password": "pass@123",
@@ -102,8 +189,21 @@ func setupHandler() (handler.PayrollHandler, *db.DbWrapper) {
 		logrus.Errorf("error while setting up db client: %v", err)
 		os.Exit(1)
 	}
+	if err := dbW.DB.Ping(); err != nil {
+		dbW.DB.Close()
+		t.Skipf("payroll db isn't reachable: %v", err)
+	}
 
-	payrollService := payroll.NewPayrollService(dbW)
-	payrollHandler := handler.NewPayrollHandler(payrollService)
+	payrollService := payroll.NewPayrollService(dbW, payroll.Config{
+		Duplicates: payroll.DuplicateRules{
+			Exact:   payroll.DuplicateReject,
+			Overlap: payroll.DuplicateOff,
+		},
+	})
+	payrollHandler := handler.NewPayrollHandler(payrollService, handler.Config{
+		ValidationPolicy: handler.PolicyReject,
+		SchemaProfiles:   handler.NewSchemaProfiles(),
+		ReportIdPattern:  regexp.MustCompile(`time-report-(\d+)\.csv$`),
+	})
 	return payrollHandler, dbW
 }